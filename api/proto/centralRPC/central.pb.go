@@ -24,10 +24,12 @@ const (
 
 type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // 服務名稱 (ex: "slots-service")
-	Type          proto.ServiceType      `protobuf:"varint,2,opt,name=type,proto3,enum=common.ServiceType" json:"type,omitempty"`         // 服務類型 (STATELESS/STATEFUL)
-	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                          // 可被 Connector 連線的地址 (ex: "10.0.1.5:9001")
-	GameIds       []int32                `protobuf:"varint,4,rep,packed,name=game_ids,json=gameIds,proto3" json:"game_ids,omitempty"`     // 支援的遊戲 ID 列表
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`                                              // 服務名稱 (ex: "slots-service")
+	Type          proto.ServiceType      `protobuf:"varint,2,opt,name=type,proto3,enum=common.ServiceType" json:"type,omitempty"`                                                      // 服務類型 (STATELESS/STATEFUL)
+	Endpoint      string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`                                                                       // 可被 Connector 連線的地址 (ex: "10.0.1.5:9001")
+	GameIds       []int32                `protobuf:"varint,4,rep,packed,name=game_ids,json=gameIds,proto3" json:"game_ids,omitempty"`                                                  // 支援的遊戲 ID 列表
+	Version       string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`                                                                         // 實例版本 (ex: "v2"，用於版本路由；空字串代表未標記版本)
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 實例標籤 (ex: track=canary，僅供查詢)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,6 +92,20 @@ func (x *RegisterRequest) GetGameIds() []int32 {
 	return nil
 }
 
+func (x *RegisterRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type RegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LeaseId       string                 `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`           // 註冊成功後回傳的租約 ID (用於 Heartbeat)
@@ -326,16 +342,114 @@ func (x *DeregisterResponse) GetSuccess() bool {
 	return false
 }
 
+type SetDrainingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LeaseId       string                 `protobuf:"bytes,1,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"` // 從 Register 取得的 ID
+	Draining      bool                   `protobuf:"varint,2,opt,name=draining,proto3" json:"draining,omitempty"`             // true 標記為排空中，false 取消排空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDrainingRequest) Reset() {
+	*x = SetDrainingRequest{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDrainingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDrainingRequest) ProtoMessage() {}
+
+func (x *SetDrainingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDrainingRequest.ProtoReflect.Descriptor instead.
+func (*SetDrainingRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetDrainingRequest) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
+}
+
+func (x *SetDrainingRequest) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type SetDrainingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDrainingResponse) Reset() {
+	*x = SetDrainingResponse{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDrainingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDrainingResponse) ProtoMessage() {}
+
+func (x *SetDrainingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDrainingResponse.ProtoReflect.Descriptor instead.
+func (*SetDrainingResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SetDrainingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 認證 Token
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                      // 認證 Token
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`             // 新會話的 Session ID (用於同時在線會話數限制)
+	ConnectorHost string                 `protobuf:"bytes,3,opt,name=connector_host,json=connectorHost,proto3" json:"connector_host,omitempty"` // 新會話所在的 Connector (grpc host，同時在線會話數限制時用於保留名額)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[6]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -347,7 +461,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[6]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +474,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LoginRequest) GetToken() string {
@@ -370,20 +484,36 @@ func (x *LoginRequest) GetToken() string {
 	return ""
 }
 
+func (x *LoginRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetConnectorHost() string {
+	if x != nil {
+		return x.ConnectorHost
+	}
+	return ""
+}
+
 type LoginResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 驗證成功後回傳 UserID
-	Nickname      string                 `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`           // 暱稱
-	Balance       string                 `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`             // 餘額 (Decimal string)
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`      // 驗證成功後回傳 UserID
+	Nickname      string                 `protobuf:"bytes,4,opt,name=nickname,proto3" json:"nickname,omitempty"`                // 暱稱
+	Balance       string                 `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`                  // 餘額 (Decimal string)
+	Code          proto.ErrorCode        `protobuf:"varint,6,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"` // 錯誤代碼 (驗證失敗為 AUTH_FAILED，超過在線會話數上限為 SESSION_LIMIT，全服維護中為 MAINTENANCE)
+	Maintenance   *MaintenanceInfo       `protobuf:"bytes,7,opt,name=maintenance,proto3" json:"maintenance,omitempty"`          // code 為 MAINTENANCE 時的維護資訊
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[7]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -395,7 +525,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[7]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -408,7 +538,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LoginResponse) GetSuccess() bool {
@@ -446,16 +576,34 @@ func (x *LoginResponse) GetBalance() string {
 	return ""
 }
 
+func (x *LoginResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *LoginResponse) GetMaintenance() *MaintenanceInfo {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
 type GetRouteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameId        int32                  `protobuf:"varint,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // 玩家想玩的遊戲 (ex: 1001)
+	GameId        int32                  `protobuf:"varint,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`                     // 玩家想玩的遊戲 (ex: 1001)
+	Join          bool                   `protobuf:"varint,3,opt,name=join,proto3" json:"join,omitempty"`                                       // 是否為新加入遊戲 (enter / switch)，僅新加入時檢查維護狀態
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                      // 加入遊戲的使用者 (維護白名單檢查、版本路由規則)
+	ClientVersion string                 `protobuf:"bytes,5,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"` // 客戶端版本號 (同 PacketHeader.client_version，用於版本路由規則)
+	Version       string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`                                  // 固定版本: 已分配版本的會話沿用同一版本 (空字串代表依版本路由規則挑選)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetRouteRequest) Reset() {
 	*x = GetRouteRequest{}
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[8]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -467,7 +615,7 @@ func (x *GetRouteRequest) String() string {
 func (*GetRouteRequest) ProtoMessage() {}
 
 func (x *GetRouteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[8]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -480,7 +628,7 @@ func (x *GetRouteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRouteRequest.ProtoReflect.Descriptor instead.
 func (*GetRouteRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetRouteRequest) GetGameId() int32 {
@@ -490,17 +638,49 @@ func (x *GetRouteRequest) GetGameId() int32 {
 	return 0
 }
 
+func (x *GetRouteRequest) GetJoin() bool {
+	if x != nil {
+		return x.Join
+	}
+	return false
+}
+
+func (x *GetRouteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRouteRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *GetRouteRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
 type GetRouteResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	TargetEndpoint string                 `protobuf:"bytes,1,opt,name=target_endpoint,json=targetEndpoint,proto3" json:"target_endpoint,omitempty"` // 目標服務地址 (ex: "10.0.1.5:9001")
 	Type           proto.ServiceType      `protobuf:"varint,2,opt,name=type,proto3,enum=common.ServiceType" json:"type,omitempty"`
+	Code           proto.ErrorCode        `protobuf:"varint,3,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"` // 遊戲 (或全服) 維護中為 MAINTENANCE
+	ErrorMessage   string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Maintenance    *MaintenanceInfo       `protobuf:"bytes,5,opt,name=maintenance,proto3" json:"maintenance,omitempty"` // code 為 MAINTENANCE 時的維護資訊
+	Version        string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`         // 目標實例的版本 (未標記版本為空字串)
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetRouteResponse) Reset() {
 	*x = GetRouteResponse{}
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[9]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -512,7 +692,7 @@ func (x *GetRouteResponse) String() string {
 func (*GetRouteResponse) ProtoMessage() {}
 
 func (x *GetRouteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_centralRPC_central_proto_msgTypes[9]
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,7 +705,7 @@ func (x *GetRouteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRouteResponse.ProtoReflect.Descriptor instead.
 func (*GetRouteResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetRouteResponse) GetTargetEndpoint() string {
@@ -542,51 +722,891 @@ func (x *GetRouteResponse) GetType() proto.ServiceType {
 	return proto.ServiceType(0)
 }
 
-var File_api_proto_centralRPC_central_proto protoreflect.FileDescriptor
+func (x *GetRouteResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
 
-const file_api_proto_centralRPC_central_proto_rawDesc = "" +
-	"\n" +
-	"\"api/proto/centralRPC/central.proto\x12\n" +
-	"centralRPC\x1a\x16api/proto/common.proto\"\x94\x01\n" +
-	"\x0fRegisterRequest\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12'\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x13.common.ServiceTypeR\x04type\x12\x1a\n" +
-	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x19\n" +
-	"\bgame_ids\x18\x04 \x03(\x05R\agameIds\"N\n" +
-	"\x10RegisterResponse\x12\x19\n" +
-	"\blease_id\x18\x01 \x01(\tR\aleaseId\x12\x1f\n" +
-	"\vttl_seconds\x18\x02 \x01(\x03R\n" +
-	"ttlSeconds\"P\n" +
-	"\x10HeartbeatRequest\x12\x19\n" +
-	"\blease_id\x18\x01 \x01(\tR\aleaseId\x12!\n" +
-	"\fcurrent_load\x18\x02 \x01(\x05R\vcurrentLoad\"-\n" +
-	"\x11HeartbeatResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\".\n" +
-	"\x11DeregisterRequest\x12\x19\n" +
-	"\blease_id\x18\x01 \x01(\tR\aleaseId\".\n" +
-	"\x12DeregisterResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"$\n" +
-	"\fLoginRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\x9d\x01\n" +
-	"\rLoginResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
-	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x18\n" +
-	"\abalance\x18\x05 \x01(\tR\abalance\"*\n" +
-	"\x0fGetRouteRequest\x12\x17\n" +
-	"\agame_id\x18\x02 \x01(\x05R\x06gameId\"d\n" +
-	"\x10GetRouteResponse\x12'\n" +
-	"\x0ftarget_endpoint\x18\x01 \x01(\tR\x0etargetEndpoint\x12'\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x13.common.ServiceTypeR\x04type2\xef\x02\n" +
-	"\n" +
-	"CentralRPC\x12E\n" +
-	"\bRegister\x12\x1b.centralRPC.RegisterRequest\x1a\x1c.centralRPC.RegisterResponse\x12H\n" +
-	"\tHeartbeat\x12\x1c.centralRPC.HeartbeatRequest\x1a\x1d.centralRPC.HeartbeatResponse\x12K\n" +
-	"\n" +
-	"Deregister\x12\x1d.centralRPC.DeregisterRequest\x1a\x1e.centralRPC.DeregisterResponse\x12<\n" +
-	"\x05Login\x12\x18.centralRPC.LoginRequest\x1a\x19.centralRPC.LoginResponse\x12E\n" +
-	"\bGetRoute\x12\x1b.centralRPC.GetRouteRequest\x1a\x1c.centralRPC.GetRouteResponseBJZHgithub.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC;centralRPCb\x06proto3"
+func (x *GetRouteResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GetRouteResponse) GetMaintenance() *MaintenanceInfo {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
+func (x *GetRouteResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type MaintenanceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int32                  `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`                // 0 代表全服維護
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                             // 對玩家顯示的維護公告
+	StartAt       int64                  `protobuf:"varint,3,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`             // 開始時間 (Unix Timestamp，0 代表立即開始)
+	EndAt         int64                  `protobuf:"varint,4,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`                   // 預計結束時間 (Unix Timestamp，0 代表未定)
+	Whitelist     []string               `protobuf:"bytes,5,rep,name=whitelist,proto3" json:"whitelist,omitempty"`                         // 白名單 (僅 ListMaintenance 回傳)
+	KickPlayers   bool                   `protobuf:"varint,6,opt,name=kick_players,json=kickPlayers,proto3" json:"kick_players,omitempty"` // 開始時是否踢除遊戲中的玩家 (僅 ListMaintenance 回傳)
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`       // 排程最後更新時間 (Unix Timestamp)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceInfo) Reset() {
+	*x = MaintenanceInfo{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceInfo) ProtoMessage() {}
+
+func (x *MaintenanceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceInfo.ProtoReflect.Descriptor instead.
+func (*MaintenanceInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MaintenanceInfo) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *MaintenanceInfo) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MaintenanceInfo) GetStartAt() int64 {
+	if x != nil {
+		return x.StartAt
+	}
+	return 0
+}
+
+func (x *MaintenanceInfo) GetEndAt() int64 {
+	if x != nil {
+		return x.EndAt
+	}
+	return 0
+}
+
+func (x *MaintenanceInfo) GetWhitelist() []string {
+	if x != nil {
+		return x.Whitelist
+	}
+	return nil
+}
+
+func (x *MaintenanceInfo) GetKickPlayers() bool {
+	if x != nil {
+		return x.KickPlayers
+	}
+	return false
+}
+
+func (x *MaintenanceInfo) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type PushToUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`  // 目標使用者
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`              // 推送給客戶端的資料 (放在 push 封包的 data)
+	GameId        int32                  `protobuf:"varint,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // 僅推播給在此遊戲中的會話 (0 代表不限)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushToUserRequest) Reset() {
+	*x = PushToUserRequest{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushToUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushToUserRequest) ProtoMessage() {}
+
+func (x *PushToUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushToUserRequest.ProtoReflect.Descriptor instead.
+func (*PushToUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PushToUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PushToUserRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PushToUserRequest) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+type PushToUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"` // 使用者不在線為 INVALID_PARAMS
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	SessionCount  int32                  `protobuf:"varint,3,opt,name=session_count,json=sessionCount,proto3" json:"session_count,omitempty"` // 符合條件的在線會話數
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushToUserResponse) Reset() {
+	*x = PushToUserResponse{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushToUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushToUserResponse) ProtoMessage() {}
+
+func (x *PushToUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushToUserResponse.ProtoReflect.Descriptor instead.
+func (*PushToUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PushToUserResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *PushToUserResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *PushToUserResponse) GetSessionCount() int32 {
+	if x != nil {
+		return x.SessionCount
+	}
+	return 0
+}
+
+type BroadcastAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`                                  // 推送給客戶端的資料 (放在 push 封包的 data)
+	GameId        int32                  `protobuf:"varint,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`                     // 僅推播給在此遊戲中的會話 (0 代表不限)
+	ConnectorHost string                 `protobuf:"bytes,3,opt,name=connector_host,json=connectorHost,proto3" json:"connector_host,omitempty"` // 僅推播給此 Connector 上的會話 (空字串代表不限)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastAllRequest) Reset() {
+	*x = BroadcastAllRequest{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastAllRequest) ProtoMessage() {}
+
+func (x *BroadcastAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastAllRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastAllRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BroadcastAllRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *BroadcastAllRequest) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *BroadcastAllRequest) GetConnectorHost() string {
+	if x != nil {
+		return x.ConnectorHost
+	}
+	return ""
+}
+
+type BroadcastAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastAllResponse) Reset() {
+	*x = BroadcastAllResponse{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastAllResponse) ProtoMessage() {}
+
+func (x *BroadcastAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastAllResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastAllResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BroadcastAllResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *BroadcastAllResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type PresenceInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ConnectorHost string                 `protobuf:"bytes,3,opt,name=connector_host,json=connectorHost,proto3" json:"connector_host,omitempty"` // 所在的 Connector (grpc host)
+	GameId        int32                  `protobuf:"varint,4,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`                     // 所在遊戲 ID (0 代表未進入遊戲)
+	Since         int64                  `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`                                     // 登入時間 (Unix Timestamp)
+	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`            // 最後更新時間 (Unix Timestamp)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresenceInfo) Reset() {
+	*x = PresenceInfo{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceInfo) ProtoMessage() {}
+
+func (x *PresenceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceInfo.ProtoReflect.Descriptor instead.
+func (*PresenceInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PresenceInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PresenceInfo) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PresenceInfo) GetConnectorHost() string {
+	if x != nil {
+		return x.ConnectorHost
+	}
+	return ""
+}
+
+func (x *PresenceInfo) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *PresenceInfo) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *PresenceInfo) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceRequest) Reset() {
+	*x = GetPresenceRequest{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceRequest) ProtoMessage() {}
+
+func (x *GetPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetPresenceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetPresenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Online        bool                   `protobuf:"varint,3,opt,name=online,proto3" json:"online,omitempty"`
+	Sessions      []*PresenceInfo        `protobuf:"bytes,4,rep,name=sessions,proto3" json:"sessions,omitempty"` // 使用者所有在線會話 (可能多開)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPresenceResponse) Reset() {
+	*x = GetPresenceResponse{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPresenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPresenceResponse) ProtoMessage() {}
+
+func (x *GetPresenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPresenceResponse.ProtoReflect.Descriptor instead.
+func (*GetPresenceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPresenceResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *GetPresenceResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GetPresenceResponse) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+func (x *GetPresenceResponse) GetSessions() []*PresenceInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type ListOnlineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        int32                  `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // 0 代表所有在線會話
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOnlineRequest) Reset() {
+	*x = ListOnlineRequest{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOnlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOnlineRequest) ProtoMessage() {}
+
+func (x *ListOnlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOnlineRequest.ProtoReflect.Descriptor instead.
+func (*ListOnlineRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListOnlineRequest) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+type ListOnlineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Sessions      []*PresenceInfo        `protobuf:"bytes,3,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	UserCount     int32                  `protobuf:"varint,4,opt,name=user_count,json=userCount,proto3" json:"user_count,omitempty"` // 不重複的使用者數
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOnlineResponse) Reset() {
+	*x = ListOnlineResponse{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOnlineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOnlineResponse) ProtoMessage() {}
+
+func (x *ListOnlineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOnlineResponse.ProtoReflect.Descriptor instead.
+func (*ListOnlineResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListOnlineResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *ListOnlineResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ListOnlineResponse) GetSessions() []*PresenceInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListOnlineResponse) GetUserCount() int32 {
+	if x != nil {
+		return x.UserCount
+	}
+	return 0
+}
+
+type ListMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceRequest) Reset() {
+	*x = ListMaintenanceRequest{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceRequest) ProtoMessage() {}
+
+func (x *ListMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{22}
+}
+
+type ListMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Schedules     []*MaintenanceInfo     `protobuf:"bytes,3,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenanceResponse) Reset() {
+	*x = ListMaintenanceResponse{}
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenanceResponse) ProtoMessage() {}
+
+func (x *ListMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_centralRPC_central_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*ListMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_centralRPC_central_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListMaintenanceResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *ListMaintenanceResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ListMaintenanceResponse) GetSchedules() []*MaintenanceInfo {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+var File_api_proto_centralRPC_central_proto protoreflect.FileDescriptor
+
+const file_api_proto_centralRPC_central_proto_rawDesc = "" +
+	"\n" +
+	"\"api/proto/centralRPC/central.proto\x12\n" +
+	"centralRPC\x1a\x16api/proto/common.proto\"\xaa\x02\n" +
+	"\x0fRegisterRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12'\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x13.common.ServiceTypeR\x04type\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x19\n" +
+	"\bgame_ids\x18\x04 \x03(\x05R\agameIds\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x12?\n" +
+	"\x06labels\x18\x06 \x03(\v2'.centralRPC.RegisterRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"N\n" +
+	"\x10RegisterResponse\x12\x19\n" +
+	"\blease_id\x18\x01 \x01(\tR\aleaseId\x12\x1f\n" +
+	"\vttl_seconds\x18\x02 \x01(\x03R\n" +
+	"ttlSeconds\"P\n" +
+	"\x10HeartbeatRequest\x12\x19\n" +
+	"\blease_id\x18\x01 \x01(\tR\aleaseId\x12!\n" +
+	"\fcurrent_load\x18\x02 \x01(\x05R\vcurrentLoad\"-\n" +
+	"\x11HeartbeatResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\".\n" +
+	"\x11DeregisterRequest\x12\x19\n" +
+	"\blease_id\x18\x01 \x01(\tR\aleaseId\".\n" +
+	"\x12DeregisterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"K\n" +
+	"\x12SetDrainingRequest\x12\x19\n" +
+	"\blease_id\x18\x01 \x01(\tR\aleaseId\x12\x1a\n" +
+	"\bdraining\x18\x02 \x01(\bR\bdraining\"/\n" +
+	"\x13SetDrainingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"j\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12%\n" +
+	"\x0econnector_host\x18\x03 \x01(\tR\rconnectorHost\"\x83\x02\n" +
+	"\rLoginResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bnickname\x18\x04 \x01(\tR\bnickname\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\tR\abalance\x12%\n" +
+	"\x04code\x18\x06 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12=\n" +
+	"\vmaintenance\x18\a \x01(\v2\x1b.centralRPC.MaintenanceInfoR\vmaintenance\"\x98\x01\n" +
+	"\x0fGetRouteRequest\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\x05R\x06gameId\x12\x12\n" +
+	"\x04join\x18\x03 \x01(\bR\x04join\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12%\n" +
+	"\x0eclient_version\x18\x05 \x01(\tR\rclientVersion\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\"\x89\x02\n" +
+	"\x10GetRouteResponse\x12'\n" +
+	"\x0ftarget_endpoint\x18\x01 \x01(\tR\x0etargetEndpoint\x12'\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x13.common.ServiceTypeR\x04type\x12%\n" +
+	"\x04code\x18\x03 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12=\n" +
+	"\vmaintenance\x18\x05 \x01(\v2\x1b.centralRPC.MaintenanceInfoR\vmaintenance\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\tR\aversion\"\xd6\x01\n" +
+	"\x0fMaintenanceInfo\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\x05R\x06gameId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bstart_at\x18\x03 \x01(\x03R\astartAt\x12\x15\n" +
+	"\x06end_at\x18\x04 \x01(\x03R\x05endAt\x12\x1c\n" +
+	"\twhitelist\x18\x05 \x03(\tR\twhitelist\x12!\n" +
+	"\fkick_players\x18\x06 \x01(\bR\vkickPlayers\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"_\n" +
+	"\x11PushToUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x12\x17\n" +
+	"\agame_id\x18\x03 \x01(\x05R\x06gameId\"\x85\x01\n" +
+	"\x12PushToUserResponse\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12#\n" +
+	"\rsession_count\x18\x03 \x01(\x05R\fsessionCount\"o\n" +
+	"\x13BroadcastAllRequest\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\x05R\x06gameId\x12%\n" +
+	"\x0econnector_host\x18\x03 \x01(\tR\rconnectorHost\"b\n" +
+	"\x14BroadcastAllResponse\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\xbb\x01\n" +
+	"\fPresenceInfo\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12%\n" +
+	"\x0econnector_host\x18\x03 \x01(\tR\rconnectorHost\x12\x17\n" +
+	"\agame_id\x18\x04 \x01(\x05R\x06gameId\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\x03R\x05since\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\"-\n" +
+	"\x12GetPresenceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xaf\x01\n" +
+	"\x13GetPresenceResponse\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12\x16\n" +
+	"\x06online\x18\x03 \x01(\bR\x06online\x124\n" +
+	"\bsessions\x18\x04 \x03(\v2\x18.centralRPC.PresenceInfoR\bsessions\",\n" +
+	"\x11ListOnlineRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\x05R\x06gameId\"\xb5\x01\n" +
+	"\x12ListOnlineResponse\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x124\n" +
+	"\bsessions\x18\x03 \x03(\v2\x18.centralRPC.PresenceInfoR\bsessions\x12\x1d\n" +
+	"\n" +
+	"user_count\x18\x04 \x01(\x05R\tuserCount\"\x18\n" +
+	"\x16ListMaintenanceRequest\"\xa0\x01\n" +
+	"\x17ListMaintenanceResponse\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x129\n" +
+	"\tschedules\x18\x03 \x03(\v2\x1b.centralRPC.MaintenanceInfoR\tschedules2\xd8\x06\n" +
+	"\n" +
+	"CentralRPC\x12E\n" +
+	"\bRegister\x12\x1b.centralRPC.RegisterRequest\x1a\x1c.centralRPC.RegisterResponse\x12H\n" +
+	"\tHeartbeat\x12\x1c.centralRPC.HeartbeatRequest\x1a\x1d.centralRPC.HeartbeatResponse\x12K\n" +
+	"\n" +
+	"Deregister\x12\x1d.centralRPC.DeregisterRequest\x1a\x1e.centralRPC.DeregisterResponse\x12N\n" +
+	"\vSetDraining\x12\x1e.centralRPC.SetDrainingRequest\x1a\x1f.centralRPC.SetDrainingResponse\x12<\n" +
+	"\x05Login\x12\x18.centralRPC.LoginRequest\x1a\x19.centralRPC.LoginResponse\x12E\n" +
+	"\bGetRoute\x12\x1b.centralRPC.GetRouteRequest\x1a\x1c.centralRPC.GetRouteResponse\x12K\n" +
+	"\n" +
+	"PushToUser\x12\x1d.centralRPC.PushToUserRequest\x1a\x1e.centralRPC.PushToUserResponse\x12Q\n" +
+	"\fBroadcastAll\x12\x1f.centralRPC.BroadcastAllRequest\x1a .centralRPC.BroadcastAllResponse\x12N\n" +
+	"\vGetPresence\x12\x1e.centralRPC.GetPresenceRequest\x1a\x1f.centralRPC.GetPresenceResponse\x12K\n" +
+	"\n" +
+	"ListOnline\x12\x1d.centralRPC.ListOnlineRequest\x1a\x1e.centralRPC.ListOnlineResponse\x12Z\n" +
+	"\x0fListMaintenance\x12\".centralRPC.ListMaintenanceRequest\x1a#.centralRPC.ListMaintenanceResponseBJZHgithub.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC;centralRPCb\x06proto3"
 
 var (
 	file_api_proto_centralRPC_central_proto_rawDescOnce sync.Once
@@ -600,38 +1620,79 @@ func file_api_proto_centralRPC_central_proto_rawDescGZIP() []byte {
 	return file_api_proto_centralRPC_central_proto_rawDescData
 }
 
-var file_api_proto_centralRPC_central_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_proto_centralRPC_central_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_proto_centralRPC_central_proto_goTypes = []any{
-	(*RegisterRequest)(nil),    // 0: centralRPC.RegisterRequest
-	(*RegisterResponse)(nil),   // 1: centralRPC.RegisterResponse
-	(*HeartbeatRequest)(nil),   // 2: centralRPC.HeartbeatRequest
-	(*HeartbeatResponse)(nil),  // 3: centralRPC.HeartbeatResponse
-	(*DeregisterRequest)(nil),  // 4: centralRPC.DeregisterRequest
-	(*DeregisterResponse)(nil), // 5: centralRPC.DeregisterResponse
-	(*LoginRequest)(nil),       // 6: centralRPC.LoginRequest
-	(*LoginResponse)(nil),      // 7: centralRPC.LoginResponse
-	(*GetRouteRequest)(nil),    // 8: centralRPC.GetRouteRequest
-	(*GetRouteResponse)(nil),   // 9: centralRPC.GetRouteResponse
-	(proto.ServiceType)(0),     // 10: common.ServiceType
+	(*RegisterRequest)(nil),         // 0: centralRPC.RegisterRequest
+	(*RegisterResponse)(nil),        // 1: centralRPC.RegisterResponse
+	(*HeartbeatRequest)(nil),        // 2: centralRPC.HeartbeatRequest
+	(*HeartbeatResponse)(nil),       // 3: centralRPC.HeartbeatResponse
+	(*DeregisterRequest)(nil),       // 4: centralRPC.DeregisterRequest
+	(*DeregisterResponse)(nil),      // 5: centralRPC.DeregisterResponse
+	(*SetDrainingRequest)(nil),      // 6: centralRPC.SetDrainingRequest
+	(*SetDrainingResponse)(nil),     // 7: centralRPC.SetDrainingResponse
+	(*LoginRequest)(nil),            // 8: centralRPC.LoginRequest
+	(*LoginResponse)(nil),           // 9: centralRPC.LoginResponse
+	(*GetRouteRequest)(nil),         // 10: centralRPC.GetRouteRequest
+	(*GetRouteResponse)(nil),        // 11: centralRPC.GetRouteResponse
+	(*MaintenanceInfo)(nil),         // 12: centralRPC.MaintenanceInfo
+	(*PushToUserRequest)(nil),       // 13: centralRPC.PushToUserRequest
+	(*PushToUserResponse)(nil),      // 14: centralRPC.PushToUserResponse
+	(*BroadcastAllRequest)(nil),     // 15: centralRPC.BroadcastAllRequest
+	(*BroadcastAllResponse)(nil),    // 16: centralRPC.BroadcastAllResponse
+	(*PresenceInfo)(nil),            // 17: centralRPC.PresenceInfo
+	(*GetPresenceRequest)(nil),      // 18: centralRPC.GetPresenceRequest
+	(*GetPresenceResponse)(nil),     // 19: centralRPC.GetPresenceResponse
+	(*ListOnlineRequest)(nil),       // 20: centralRPC.ListOnlineRequest
+	(*ListOnlineResponse)(nil),      // 21: centralRPC.ListOnlineResponse
+	(*ListMaintenanceRequest)(nil),  // 22: centralRPC.ListMaintenanceRequest
+	(*ListMaintenanceResponse)(nil), // 23: centralRPC.ListMaintenanceResponse
+	nil,                             // 24: centralRPC.RegisterRequest.LabelsEntry
+	(proto.ServiceType)(0),          // 25: common.ServiceType
+	(proto.ErrorCode)(0),            // 26: common.ErrorCode
 }
 var file_api_proto_centralRPC_central_proto_depIdxs = []int32{
-	10, // 0: centralRPC.RegisterRequest.type:type_name -> common.ServiceType
-	10, // 1: centralRPC.GetRouteResponse.type:type_name -> common.ServiceType
-	0,  // 2: centralRPC.CentralRPC.Register:input_type -> centralRPC.RegisterRequest
-	2,  // 3: centralRPC.CentralRPC.Heartbeat:input_type -> centralRPC.HeartbeatRequest
-	4,  // 4: centralRPC.CentralRPC.Deregister:input_type -> centralRPC.DeregisterRequest
-	6,  // 5: centralRPC.CentralRPC.Login:input_type -> centralRPC.LoginRequest
-	8,  // 6: centralRPC.CentralRPC.GetRoute:input_type -> centralRPC.GetRouteRequest
-	1,  // 7: centralRPC.CentralRPC.Register:output_type -> centralRPC.RegisterResponse
-	3,  // 8: centralRPC.CentralRPC.Heartbeat:output_type -> centralRPC.HeartbeatResponse
-	5,  // 9: centralRPC.CentralRPC.Deregister:output_type -> centralRPC.DeregisterResponse
-	7,  // 10: centralRPC.CentralRPC.Login:output_type -> centralRPC.LoginResponse
-	9,  // 11: centralRPC.CentralRPC.GetRoute:output_type -> centralRPC.GetRouteResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	25, // 0: centralRPC.RegisterRequest.type:type_name -> common.ServiceType
+	24, // 1: centralRPC.RegisterRequest.labels:type_name -> centralRPC.RegisterRequest.LabelsEntry
+	26, // 2: centralRPC.LoginResponse.code:type_name -> common.ErrorCode
+	12, // 3: centralRPC.LoginResponse.maintenance:type_name -> centralRPC.MaintenanceInfo
+	25, // 4: centralRPC.GetRouteResponse.type:type_name -> common.ServiceType
+	26, // 5: centralRPC.GetRouteResponse.code:type_name -> common.ErrorCode
+	12, // 6: centralRPC.GetRouteResponse.maintenance:type_name -> centralRPC.MaintenanceInfo
+	26, // 7: centralRPC.PushToUserResponse.code:type_name -> common.ErrorCode
+	26, // 8: centralRPC.BroadcastAllResponse.code:type_name -> common.ErrorCode
+	26, // 9: centralRPC.GetPresenceResponse.code:type_name -> common.ErrorCode
+	17, // 10: centralRPC.GetPresenceResponse.sessions:type_name -> centralRPC.PresenceInfo
+	26, // 11: centralRPC.ListOnlineResponse.code:type_name -> common.ErrorCode
+	17, // 12: centralRPC.ListOnlineResponse.sessions:type_name -> centralRPC.PresenceInfo
+	26, // 13: centralRPC.ListMaintenanceResponse.code:type_name -> common.ErrorCode
+	12, // 14: centralRPC.ListMaintenanceResponse.schedules:type_name -> centralRPC.MaintenanceInfo
+	0,  // 15: centralRPC.CentralRPC.Register:input_type -> centralRPC.RegisterRequest
+	2,  // 16: centralRPC.CentralRPC.Heartbeat:input_type -> centralRPC.HeartbeatRequest
+	4,  // 17: centralRPC.CentralRPC.Deregister:input_type -> centralRPC.DeregisterRequest
+	6,  // 18: centralRPC.CentralRPC.SetDraining:input_type -> centralRPC.SetDrainingRequest
+	8,  // 19: centralRPC.CentralRPC.Login:input_type -> centralRPC.LoginRequest
+	10, // 20: centralRPC.CentralRPC.GetRoute:input_type -> centralRPC.GetRouteRequest
+	13, // 21: centralRPC.CentralRPC.PushToUser:input_type -> centralRPC.PushToUserRequest
+	15, // 22: centralRPC.CentralRPC.BroadcastAll:input_type -> centralRPC.BroadcastAllRequest
+	18, // 23: centralRPC.CentralRPC.GetPresence:input_type -> centralRPC.GetPresenceRequest
+	20, // 24: centralRPC.CentralRPC.ListOnline:input_type -> centralRPC.ListOnlineRequest
+	22, // 25: centralRPC.CentralRPC.ListMaintenance:input_type -> centralRPC.ListMaintenanceRequest
+	1,  // 26: centralRPC.CentralRPC.Register:output_type -> centralRPC.RegisterResponse
+	3,  // 27: centralRPC.CentralRPC.Heartbeat:output_type -> centralRPC.HeartbeatResponse
+	5,  // 28: centralRPC.CentralRPC.Deregister:output_type -> centralRPC.DeregisterResponse
+	7,  // 29: centralRPC.CentralRPC.SetDraining:output_type -> centralRPC.SetDrainingResponse
+	9,  // 30: centralRPC.CentralRPC.Login:output_type -> centralRPC.LoginResponse
+	11, // 31: centralRPC.CentralRPC.GetRoute:output_type -> centralRPC.GetRouteResponse
+	14, // 32: centralRPC.CentralRPC.PushToUser:output_type -> centralRPC.PushToUserResponse
+	16, // 33: centralRPC.CentralRPC.BroadcastAll:output_type -> centralRPC.BroadcastAllResponse
+	19, // 34: centralRPC.CentralRPC.GetPresence:output_type -> centralRPC.GetPresenceResponse
+	21, // 35: centralRPC.CentralRPC.ListOnline:output_type -> centralRPC.ListOnlineResponse
+	23, // 36: centralRPC.CentralRPC.ListMaintenance:output_type -> centralRPC.ListMaintenanceResponse
+	26, // [26:37] is the sub-list for method output_type
+	15, // [15:26] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_centralRPC_central_proto_init() }
@@ -645,7 +1706,7 @@ func file_api_proto_centralRPC_central_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_centralRPC_central_proto_rawDesc), len(file_api_proto_centralRPC_central_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
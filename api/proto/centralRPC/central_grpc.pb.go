@@ -19,11 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CentralRPC_Register_FullMethodName   = "/centralRPC.CentralRPC/Register"
-	CentralRPC_Heartbeat_FullMethodName  = "/centralRPC.CentralRPC/Heartbeat"
-	CentralRPC_Deregister_FullMethodName = "/centralRPC.CentralRPC/Deregister"
-	CentralRPC_Login_FullMethodName      = "/centralRPC.CentralRPC/Login"
-	CentralRPC_GetRoute_FullMethodName   = "/centralRPC.CentralRPC/GetRoute"
+	CentralRPC_Register_FullMethodName        = "/centralRPC.CentralRPC/Register"
+	CentralRPC_Heartbeat_FullMethodName       = "/centralRPC.CentralRPC/Heartbeat"
+	CentralRPC_Deregister_FullMethodName      = "/centralRPC.CentralRPC/Deregister"
+	CentralRPC_SetDraining_FullMethodName     = "/centralRPC.CentralRPC/SetDraining"
+	CentralRPC_Login_FullMethodName           = "/centralRPC.CentralRPC/Login"
+	CentralRPC_GetRoute_FullMethodName        = "/centralRPC.CentralRPC/GetRoute"
+	CentralRPC_PushToUser_FullMethodName      = "/centralRPC.CentralRPC/PushToUser"
+	CentralRPC_BroadcastAll_FullMethodName    = "/centralRPC.CentralRPC/BroadcastAll"
+	CentralRPC_GetPresence_FullMethodName     = "/centralRPC.CentralRPC/GetPresence"
+	CentralRPC_ListOnline_FullMethodName      = "/centralRPC.CentralRPC/ListOnline"
+	CentralRPC_ListMaintenance_FullMethodName = "/centralRPC.CentralRPC/ListMaintenance"
 )
 
 // CentralRPCClient is the client API for CentralRPC service.
@@ -38,10 +44,22 @@ type CentralRPCClient interface {
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
 	// Deregister: 服務關閉時主動呼叫，立即移除服務 (Graceful Shutdown)
 	Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error)
-	// Login: 玩家連線後第一件事，驗證 Token (尚未實作詳細邏輯)
+	// SetDraining: 服務排空時呼叫，標記租約為排空中 (不再分配新玩家，既有玩家不受影響)
+	SetDraining(ctx context.Context, in *SetDrainingRequest, opts ...grpc.CallOption) (*SetDrainingResponse, error)
+	// Login: 玩家連線後第一件事，驗證 Token (JWT 或訪客模式)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// GetRoute: 玩家請求進入遊戲時呼叫，取得目標服務地址
 	GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error)
+	// PushToUser: 推播訊息給使用者的所有在線會話 (不需知道 Session ID 與所在 Connector)
+	PushToUser(ctx context.Context, in *PushToUserRequest, opts ...grpc.CallOption) (*PushToUserResponse, error)
+	// BroadcastAll: 全服廣播 (維護公告、彩金等)，可依遊戲或 Connector 篩選
+	BroadcastAll(ctx context.Context, in *BroadcastAllRequest, opts ...grpc.CallOption) (*BroadcastAllResponse, error)
+	// GetPresence: 查詢使用者的在線狀態 (所在 Connector、會話與遊戲)
+	GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...grpc.CallOption) (*GetPresenceResponse, error)
+	// ListOnline: 列出在線會話，可依遊戲篩選
+	ListOnline(ctx context.Context, in *ListOnlineRequest, opts ...grpc.CallOption) (*ListOnlineResponse, error)
+	// ListMaintenance: 列出所有維護排程 (含尚未開始的排程)，Connector 據此通知玩家並於開始時踢除遊戲中的玩家
+	ListMaintenance(ctx context.Context, in *ListMaintenanceRequest, opts ...grpc.CallOption) (*ListMaintenanceResponse, error)
 }
 
 type centralRPCClient struct {
@@ -82,6 +100,16 @@ func (c *centralRPCClient) Deregister(ctx context.Context, in *DeregisterRequest
 	return out, nil
 }
 
+func (c *centralRPCClient) SetDraining(ctx context.Context, in *SetDrainingRequest, opts ...grpc.CallOption) (*SetDrainingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetDrainingResponse)
+	err := c.cc.Invoke(ctx, CentralRPC_SetDraining_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *centralRPCClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LoginResponse)
@@ -102,6 +130,56 @@ func (c *centralRPCClient) GetRoute(ctx context.Context, in *GetRouteRequest, op
 	return out, nil
 }
 
+func (c *centralRPCClient) PushToUser(ctx context.Context, in *PushToUserRequest, opts ...grpc.CallOption) (*PushToUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushToUserResponse)
+	err := c.cc.Invoke(ctx, CentralRPC_PushToUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralRPCClient) BroadcastAll(ctx context.Context, in *BroadcastAllRequest, opts ...grpc.CallOption) (*BroadcastAllResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BroadcastAllResponse)
+	err := c.cc.Invoke(ctx, CentralRPC_BroadcastAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralRPCClient) GetPresence(ctx context.Context, in *GetPresenceRequest, opts ...grpc.CallOption) (*GetPresenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPresenceResponse)
+	err := c.cc.Invoke(ctx, CentralRPC_GetPresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralRPCClient) ListOnline(ctx context.Context, in *ListOnlineRequest, opts ...grpc.CallOption) (*ListOnlineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOnlineResponse)
+	err := c.cc.Invoke(ctx, CentralRPC_ListOnline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centralRPCClient) ListMaintenance(ctx context.Context, in *ListMaintenanceRequest, opts ...grpc.CallOption) (*ListMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMaintenanceResponse)
+	err := c.cc.Invoke(ctx, CentralRPC_ListMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CentralRPCServer is the server API for CentralRPC service.
 // All implementations must embed UnimplementedCentralRPCServer
 // for forward compatibility.
@@ -114,10 +192,22 @@ type CentralRPCServer interface {
 	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
 	// Deregister: 服務關閉時主動呼叫，立即移除服務 (Graceful Shutdown)
 	Deregister(context.Context, *DeregisterRequest) (*DeregisterResponse, error)
-	// Login: 玩家連線後第一件事，驗證 Token (尚未實作詳細邏輯)
+	// SetDraining: 服務排空時呼叫，標記租約為排空中 (不再分配新玩家，既有玩家不受影響)
+	SetDraining(context.Context, *SetDrainingRequest) (*SetDrainingResponse, error)
+	// Login: 玩家連線後第一件事，驗證 Token (JWT 或訪客模式)
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	// GetRoute: 玩家請求進入遊戲時呼叫，取得目標服務地址
 	GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error)
+	// PushToUser: 推播訊息給使用者的所有在線會話 (不需知道 Session ID 與所在 Connector)
+	PushToUser(context.Context, *PushToUserRequest) (*PushToUserResponse, error)
+	// BroadcastAll: 全服廣播 (維護公告、彩金等)，可依遊戲或 Connector 篩選
+	BroadcastAll(context.Context, *BroadcastAllRequest) (*BroadcastAllResponse, error)
+	// GetPresence: 查詢使用者的在線狀態 (所在 Connector、會話與遊戲)
+	GetPresence(context.Context, *GetPresenceRequest) (*GetPresenceResponse, error)
+	// ListOnline: 列出在線會話，可依遊戲篩選
+	ListOnline(context.Context, *ListOnlineRequest) (*ListOnlineResponse, error)
+	// ListMaintenance: 列出所有維護排程 (含尚未開始的排程)，Connector 據此通知玩家並於開始時踢除遊戲中的玩家
+	ListMaintenance(context.Context, *ListMaintenanceRequest) (*ListMaintenanceResponse, error)
 	mustEmbedUnimplementedCentralRPCServer()
 }
 
@@ -137,12 +227,30 @@ func (UnimplementedCentralRPCServer) Heartbeat(context.Context, *HeartbeatReques
 func (UnimplementedCentralRPCServer) Deregister(context.Context, *DeregisterRequest) (*DeregisterResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Deregister not implemented")
 }
+func (UnimplementedCentralRPCServer) SetDraining(context.Context, *SetDrainingRequest) (*SetDrainingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetDraining not implemented")
+}
 func (UnimplementedCentralRPCServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Login not implemented")
 }
 func (UnimplementedCentralRPCServer) GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetRoute not implemented")
 }
+func (UnimplementedCentralRPCServer) PushToUser(context.Context, *PushToUserRequest) (*PushToUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PushToUser not implemented")
+}
+func (UnimplementedCentralRPCServer) BroadcastAll(context.Context, *BroadcastAllRequest) (*BroadcastAllResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BroadcastAll not implemented")
+}
+func (UnimplementedCentralRPCServer) GetPresence(context.Context, *GetPresenceRequest) (*GetPresenceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPresence not implemented")
+}
+func (UnimplementedCentralRPCServer) ListOnline(context.Context, *ListOnlineRequest) (*ListOnlineResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListOnline not implemented")
+}
+func (UnimplementedCentralRPCServer) ListMaintenance(context.Context, *ListMaintenanceRequest) (*ListMaintenanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMaintenance not implemented")
+}
 func (UnimplementedCentralRPCServer) mustEmbedUnimplementedCentralRPCServer() {}
 func (UnimplementedCentralRPCServer) testEmbeddedByValue()                    {}
 
@@ -218,6 +326,24 @@ func _CentralRPC_Deregister_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CentralRPC_SetDraining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDrainingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralRPCServer).SetDraining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralRPC_SetDraining_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralRPCServer).SetDraining(ctx, req.(*SetDrainingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CentralRPC_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LoginRequest)
 	if err := dec(in); err != nil {
@@ -254,6 +380,96 @@ func _CentralRPC_GetRoute_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CentralRPC_PushToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushToUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralRPCServer).PushToUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralRPC_PushToUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralRPCServer).PushToUser(ctx, req.(*PushToUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralRPC_BroadcastAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BroadcastAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralRPCServer).BroadcastAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralRPC_BroadcastAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralRPCServer).BroadcastAll(ctx, req.(*BroadcastAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralRPC_GetPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralRPCServer).GetPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralRPC_GetPresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralRPCServer).GetPresence(ctx, req.(*GetPresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralRPC_ListOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOnlineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralRPCServer).ListOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralRPC_ListOnline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralRPCServer).ListOnline(ctx, req.(*ListOnlineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CentralRPC_ListMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentralRPCServer).ListMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CentralRPC_ListMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentralRPCServer).ListMaintenance(ctx, req.(*ListMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CentralRPC_ServiceDesc is the grpc.ServiceDesc for CentralRPC service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -273,6 +489,10 @@ var CentralRPC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Deregister",
 			Handler:    _CentralRPC_Deregister_Handler,
 		},
+		{
+			MethodName: "SetDraining",
+			Handler:    _CentralRPC_SetDraining_Handler,
+		},
 		{
 			MethodName: "Login",
 			Handler:    _CentralRPC_Login_Handler,
@@ -281,6 +501,26 @@ var CentralRPC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRoute",
 			Handler:    _CentralRPC_GetRoute_Handler,
 		},
+		{
+			MethodName: "PushToUser",
+			Handler:    _CentralRPC_PushToUser_Handler,
+		},
+		{
+			MethodName: "BroadcastAll",
+			Handler:    _CentralRPC_BroadcastAll_Handler,
+		},
+		{
+			MethodName: "GetPresence",
+			Handler:    _CentralRPC_GetPresence_Handler,
+		},
+		{
+			MethodName: "ListOnline",
+			Handler:    _CentralRPC_ListOnline_Handler,
+		},
+		{
+			MethodName: "ListMaintenance",
+			Handler:    _CentralRPC_ListMaintenance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/centralRPC/central.proto",
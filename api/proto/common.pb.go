@@ -75,31 +75,49 @@ func (ServiceType) EnumDescriptor() ([]byte, []int) {
 type ErrorCode int32
 
 const (
-	ErrorCode_SUCCESS        ErrorCode = 0 // 成功，無錯誤
-	ErrorCode_UNKNOWN_ERROR  ErrorCode = 1 // 未知錯誤
-	ErrorCode_INVALID_PARAMS ErrorCode = 2 // 參數無效
-	ErrorCode_AUTH_FAILED    ErrorCode = 3 // 驗證失敗
-	ErrorCode_SERVER_ERROR   ErrorCode = 4 // 伺服器內部錯誤
-	ErrorCode_MAINTENANCE    ErrorCode = 5 // 系統維護中
+	ErrorCode_SUCCESS          ErrorCode = 0  // 成功，無錯誤
+	ErrorCode_UNKNOWN_ERROR    ErrorCode = 1  // 未知錯誤
+	ErrorCode_INVALID_PARAMS   ErrorCode = 2  // 參數無效
+	ErrorCode_AUTH_FAILED      ErrorCode = 3  // 驗證失敗
+	ErrorCode_SERVER_ERROR     ErrorCode = 4  // 伺服器內部錯誤
+	ErrorCode_MAINTENANCE      ErrorCode = 5  // 系統維護中
+	ErrorCode_SESSION_LIMIT    ErrorCode = 6  // 超過同時在線會話數上限 (重複登入被拒)
+	ErrorCode_RATE_LIMITED     ErrorCode = 7  // 超過流量限制 (訊息速率、連線數、登入次數)
+	ErrorCode_NOT_LOGGED_IN    ErrorCode = 8  // 尚未登入
+	ErrorCode_INVALID_STATE    ErrorCode = 9  // 指令不適用於目前狀態 (ex: 重複登入、已在遊戲中、未在遊戲中、未知指令)
+	ErrorCode_GAME_UNAVAILABLE ErrorCode = 10 // 遊戲不存在或沒有可用的 Game Server
+	ErrorCode_DRAINING         ErrorCode = 11 // Game Server 排空中，不接受新玩家 (Connector 會重新取得路由)
 )
 
 // Enum value maps for ErrorCode.
 var (
 	ErrorCode_name = map[int32]string{
-		0: "SUCCESS",
-		1: "UNKNOWN_ERROR",
-		2: "INVALID_PARAMS",
-		3: "AUTH_FAILED",
-		4: "SERVER_ERROR",
-		5: "MAINTENANCE",
+		0:  "SUCCESS",
+		1:  "UNKNOWN_ERROR",
+		2:  "INVALID_PARAMS",
+		3:  "AUTH_FAILED",
+		4:  "SERVER_ERROR",
+		5:  "MAINTENANCE",
+		6:  "SESSION_LIMIT",
+		7:  "RATE_LIMITED",
+		8:  "NOT_LOGGED_IN",
+		9:  "INVALID_STATE",
+		10: "GAME_UNAVAILABLE",
+		11: "DRAINING",
 	}
 	ErrorCode_value = map[string]int32{
-		"SUCCESS":        0,
-		"UNKNOWN_ERROR":  1,
-		"INVALID_PARAMS": 2,
-		"AUTH_FAILED":    3,
-		"SERVER_ERROR":   4,
-		"MAINTENANCE":    5,
+		"SUCCESS":          0,
+		"UNKNOWN_ERROR":    1,
+		"INVALID_PARAMS":   2,
+		"AUTH_FAILED":      3,
+		"SERVER_ERROR":     4,
+		"MAINTENANCE":      5,
+		"SESSION_LIMIT":    6,
+		"RATE_LIMITED":     7,
+		"NOT_LOGGED_IN":    8,
+		"INVALID_STATE":    9,
+		"GAME_UNAVAILABLE": 10,
+		"DRAINING":         11,
 	}
 )
 
@@ -138,6 +156,8 @@ type PacketHeader struct {
 	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                      // 使用者 ID (若已登入)
 	SessionId     string                 `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`             // 會話 Token (用於驗證)
 	ClientVersion string                 `protobuf:"bytes,5,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"` // 客戶端版本號 (用於相容性檢查)
+	// 追蹤上下文 (W3C traceparent / tracestate)，串流訊框沒有逐筆的 gRPC Metadata，由此傳遞
+	TraceContext  map[string]string `protobuf:"bytes,6,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -207,29 +227,47 @@ func (x *PacketHeader) GetClientVersion() string {
 	return ""
 }
 
+func (x *PacketHeader) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
 var File_api_proto_common_proto protoreflect.FileDescriptor
 
 const file_api_proto_common_proto_rawDesc = "" +
 	"\n" +
-	"\x16api/proto/common.proto\x12\x06common\"\xa2\x01\n" +
+	"\x16api/proto/common.proto\x12\x06common\"\xb0\x02\n" +
 	"\fPacketHeader\x12\x15\n" +
 	"\x06req_id\x18\x01 \x01(\tR\x05reqId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x17\n" +
 	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x04 \x01(\tR\tsessionId\x12%\n" +
-	"\x0eclient_version\x18\x05 \x01(\tR\rclientVersion*?\n" +
+	"\x0eclient_version\x18\x05 \x01(\tR\rclientVersion\x12K\n" +
+	"\rtrace_context\x18\x06 \x03(\v2&.common.PacketHeader.TraceContextEntryR\ftraceContext\x1a?\n" +
+	"\x11TraceContextEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*?\n" +
 	"\vServiceType\x12\x13\n" +
 	"\x0fUNKNOWN_SERVICE\x10\x00\x12\r\n" +
 	"\tSTATELESS\x10\x01\x12\f\n" +
-	"\bSTATEFUL\x10\x02*s\n" +
+	"\bSTATEFUL\x10\x02*\xe2\x01\n" +
 	"\tErrorCode\x12\v\n" +
 	"\aSUCCESS\x10\x00\x12\x11\n" +
 	"\rUNKNOWN_ERROR\x10\x01\x12\x12\n" +
 	"\x0eINVALID_PARAMS\x10\x02\x12\x0f\n" +
 	"\vAUTH_FAILED\x10\x03\x12\x10\n" +
 	"\fSERVER_ERROR\x10\x04\x12\x0f\n" +
-	"\vMAINTENANCE\x10\x05B:Z8github.com/JoeShih716/go-k8s-game-server/api/proto;protob\x06proto3"
+	"\vMAINTENANCE\x10\x05\x12\x11\n" +
+	"\rSESSION_LIMIT\x10\x06\x12\x10\n" +
+	"\fRATE_LIMITED\x10\a\x12\x11\n" +
+	"\rNOT_LOGGED_IN\x10\b\x12\x11\n" +
+	"\rINVALID_STATE\x10\t\x12\x14\n" +
+	"\x10GAME_UNAVAILABLE\x10\n" +
+	"\x12\f\n" +
+	"\bDRAINING\x10\vB:Z8github.com/JoeShih716/go-k8s-game-server/api/proto;protob\x06proto3"
 
 var (
 	file_api_proto_common_proto_rawDescOnce sync.Once
@@ -244,18 +282,20 @@ func file_api_proto_common_proto_rawDescGZIP() []byte {
 }
 
 var file_api_proto_common_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proto_common_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_proto_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_api_proto_common_proto_goTypes = []any{
 	(ServiceType)(0),     // 0: common.ServiceType
 	(ErrorCode)(0),       // 1: common.ErrorCode
 	(*PacketHeader)(nil), // 2: common.PacketHeader
+	nil,                  // 3: common.PacketHeader.TraceContextEntry
 }
 var file_api_proto_common_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: common.PacketHeader.trace_context:type_name -> common.PacketHeader.TraceContextEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_common_proto_init() }
@@ -269,7 +309,7 @@ func file_api_proto_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_common_proto_rawDesc), len(file_api_proto_common_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
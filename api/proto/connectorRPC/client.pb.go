@@ -0,0 +1,237 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.3
+// source: api/proto/connectorRPC/client.proto
+
+package connectorRPC
+
+import (
+	proto "github.com/JoeShih716/go-k8s-game-server/api/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ClientEnvelope Client -> Connector 的封包外層
+type ClientEnvelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`            // 指令代碼 (ex: "login", "enter")
+	ReqId         string                 `protobuf:"bytes,2,opt,name=req_id,json=reqId,proto3" json:"req_id,omitempty"` // 客戶端請求 ID (選填，回應時原樣帶回)
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`          // 具體請求內容 (Connector 本地指令使用 JSON 編碼)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientEnvelope) Reset() {
+	*x = ClientEnvelope{}
+	mi := &file_api_proto_connectorRPC_client_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientEnvelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientEnvelope) ProtoMessage() {}
+
+func (x *ClientEnvelope) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_connectorRPC_client_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientEnvelope.ProtoReflect.Descriptor instead.
+func (*ClientEnvelope) Descriptor() ([]byte, []int) {
+	return file_api_proto_connectorRPC_client_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ClientEnvelope) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ClientEnvelope) GetReqId() string {
+	if x != nil {
+		return x.ReqId
+	}
+	return ""
+}
+
+func (x *ClientEnvelope) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// ClientResponse Connector -> Client 的封包外層 (請求的回應與 Server 主動推送)
+type ClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`                    // 對應的指令代碼 (Game Server 推送時為空)
+	ReqId         string                 `protobuf:"bytes,2,opt,name=req_id,json=reqId,proto3" json:"req_id,omitempty"`         // 對應請求的 req_id (推送時為空)
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                        // 資料 (Connector 本地指令為 JSON 編碼，Game Server 的資料原樣帶入)
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                  // 錯誤說明 (僅供除錯顯示，判斷請以 code 為準)
+	Code          proto.ErrorCode        `protobuf:"varint,5,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"` // 錯誤碼 (SUCCESS 代表成功)
+	Type          string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`                        // 封包類型: "response" (請求的回應) / "push" (Server 主動推送)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientResponse) Reset() {
+	*x = ClientResponse{}
+	mi := &file_api_proto_connectorRPC_client_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientResponse) ProtoMessage() {}
+
+func (x *ClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_connectorRPC_client_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientResponse.ProtoReflect.Descriptor instead.
+func (*ClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_connectorRPC_client_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ClientResponse) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ClientResponse) GetReqId() string {
+	if x != nil {
+		return x.ReqId
+	}
+	return ""
+}
+
+func (x *ClientResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ClientResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ClientResponse) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *ClientResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+var File_api_proto_connectorRPC_client_proto protoreflect.FileDescriptor
+
+const file_api_proto_connectorRPC_client_proto_rawDesc = "" +
+	"\n" +
+	"#api/proto/connectorRPC/client.proto\x12\fconnectorRPC\x1a\x16api/proto/common.proto\"Y\n" +
+	"\x0eClientEnvelope\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x15\n" +
+	"\x06req_id\x18\x02 \x01(\tR\x05reqId\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\"\xa8\x01\n" +
+	"\x0eClientResponse\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x15\n" +
+	"\x06req_id\x18\x02 \x01(\tR\x05reqId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12%\n" +
+	"\x04code\x18\x05 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04typeBNZLgithub.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC;connectorRPCb\x06proto3"
+
+var (
+	file_api_proto_connectorRPC_client_proto_rawDescOnce sync.Once
+	file_api_proto_connectorRPC_client_proto_rawDescData []byte
+)
+
+func file_api_proto_connectorRPC_client_proto_rawDescGZIP() []byte {
+	file_api_proto_connectorRPC_client_proto_rawDescOnce.Do(func() {
+		file_api_proto_connectorRPC_client_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_connectorRPC_client_proto_rawDesc), len(file_api_proto_connectorRPC_client_proto_rawDesc)))
+	})
+	return file_api_proto_connectorRPC_client_proto_rawDescData
+}
+
+var file_api_proto_connectorRPC_client_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_proto_connectorRPC_client_proto_goTypes = []any{
+	(*ClientEnvelope)(nil), // 0: connectorRPC.ClientEnvelope
+	(*ClientResponse)(nil), // 1: connectorRPC.ClientResponse
+	(proto.ErrorCode)(0),   // 2: common.ErrorCode
+}
+var file_api_proto_connectorRPC_client_proto_depIdxs = []int32{
+	2, // 0: connectorRPC.ClientResponse.code:type_name -> common.ErrorCode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_connectorRPC_client_proto_init() }
+func file_api_proto_connectorRPC_client_proto_init() {
+	if File_api_proto_connectorRPC_client_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_connectorRPC_client_proto_rawDesc), len(file_api_proto_connectorRPC_client_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proto_connectorRPC_client_proto_goTypes,
+		DependencyIndexes: file_api_proto_connectorRPC_client_proto_depIdxs,
+		MessageInfos:      file_api_proto_connectorRPC_client_proto_msgTypes,
+	}.Build()
+	File_api_proto_connectorRPC_client_proto = out.File
+	file_api_proto_connectorRPC_client_proto_goTypes = nil
+	file_api_proto_connectorRPC_client_proto_depIdxs = nil
+}
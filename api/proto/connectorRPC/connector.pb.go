@@ -22,6 +22,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DeliveryStatus 單一 Session 的推播投遞結果
+// 零值 UNSPECIFIED 代表未回報結果 (e.g. 回應中缺少該 Session)，不可視為投遞成功
+type DeliveryStatus int32
+
+const (
+	DeliveryStatus_UNSPECIFIED DeliveryStatus = 0 // 未回報投遞結果
+	DeliveryStatus_DELIVERED   DeliveryStatus = 1 // 已放入該連線的發送佇列
+	DeliveryStatus_NOT_FOUND   DeliveryStatus = 2 // 找不到 Session (已斷線或不在此 Connector)
+	DeliveryStatus_QUEUE_FULL  DeliveryStatus = 3 // 發送佇列已滿 (慢速消費者)，訊息未送出；僅在 overflow_policy 為 kick 時回報 (drop_oldest 會丟棄最舊訊息並回報 DELIVERED)
+	DeliveryStatus_FAILED      DeliveryStatus = 4 // 編碼或寫入失敗，訊息未送出
+)
+
+// Enum value maps for DeliveryStatus.
+var (
+	DeliveryStatus_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "DELIVERED",
+		2: "NOT_FOUND",
+		3: "QUEUE_FULL",
+		4: "FAILED",
+	}
+	DeliveryStatus_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"DELIVERED":   1,
+		"NOT_FOUND":   2,
+		"QUEUE_FULL":  3,
+		"FAILED":      4,
+	}
+)
+
+func (x DeliveryStatus) Enum() *DeliveryStatus {
+	p := new(DeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x DeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_connectorRPC_connector_proto_enumTypes[0].Descriptor()
+}
+
+func (DeliveryStatus) Type() protoreflect.EnumType {
+	return &file_api_proto_connectorRPC_connector_proto_enumTypes[0]
+}
+
+func (x DeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeliveryStatus.Descriptor instead.
+func (DeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{0}
+}
+
 type SendMessageReq struct {
 	state  protoimpl.MessageState `protogen:"open.v1"`
 	Header *proto.PacketHeader    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -84,16 +141,71 @@ func (x *SendMessageReq) GetPayload() []byte {
 	return nil
 }
 
-type SendMessageResp struct {
+// DeliveryResult 記錄單一 Session 的投遞結果
+type DeliveryResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Status        DeliveryStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=connectorRPC.DeliveryStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryResult) Reset() {
+	*x = DeliveryResult{}
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryResult) ProtoMessage() {}
+
+func (x *DeliveryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryResult.ProtoReflect.Descriptor instead.
+func (*DeliveryResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DeliveryResult) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *DeliveryResult) GetStatus() DeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeliveryStatus_UNSPECIFIED
+}
+
+type SendMessageResp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	// 依 SendMessageReq.session_ids 順序回傳每個 Session 的投遞結果
+	Results       []*DeliveryResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SendMessageResp) Reset() {
 	*x = SendMessageResp{}
-	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[1]
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +217,7 @@ func (x *SendMessageResp) String() string {
 func (*SendMessageResp) ProtoMessage() {}
 
 func (x *SendMessageResp) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[1]
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +230,7 @@ func (x *SendMessageResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageResp.ProtoReflect.Descriptor instead.
 func (*SendMessageResp) Descriptor() ([]byte, []int) {
-	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SendMessageResp) GetCode() proto.ErrorCode {
@@ -128,6 +240,13 @@ func (x *SendMessageResp) GetCode() proto.ErrorCode {
 	return proto.ErrorCode(0)
 }
 
+func (x *SendMessageResp) GetResults() []*DeliveryResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 type KickReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *proto.PacketHeader    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -139,7 +258,7 @@ type KickReq struct {
 
 func (x *KickReq) Reset() {
 	*x = KickReq{}
-	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[2]
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -151,7 +270,7 @@ func (x *KickReq) String() string {
 func (*KickReq) ProtoMessage() {}
 
 func (x *KickReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[2]
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -164,7 +283,7 @@ func (x *KickReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickReq.ProtoReflect.Descriptor instead.
 func (*KickReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *KickReq) GetHeader() *proto.PacketHeader {
@@ -197,7 +316,7 @@ type KickResp struct {
 
 func (x *KickResp) Reset() {
 	*x = KickResp{}
-	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[3]
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -209,7 +328,7 @@ func (x *KickResp) String() string {
 func (*KickResp) ProtoMessage() {}
 
 func (x *KickResp) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[3]
+	mi := &file_api_proto_connectorRPC_connector_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +341,7 @@ func (x *KickResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickResp.ProtoReflect.Descriptor instead.
 func (*KickResp) Descriptor() ([]byte, []int) {
-	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_connectorRPC_connector_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *KickResp) GetCode() proto.ErrorCode {
@@ -241,16 +360,29 @@ const file_api_proto_connectorRPC_connector_proto_rawDesc = "" +
 	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12\x1f\n" +
 	"\vsession_ids\x18\x02 \x03(\tR\n" +
 	"sessionIds\x12\x18\n" +
-	"\apayload\x18\x03 \x01(\fR\apayload\"8\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\"e\n" +
+	"\x0eDeliveryResult\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x124\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1c.connectorRPC.DeliveryStatusR\x06status\"p\n" +
 	"\x0fSendMessageResp\x12%\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\"n\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x126\n" +
+	"\aresults\x18\x02 \x03(\v2\x1c.connectorRPC.DeliveryResultR\aresults\"n\n" +
 	"\aKickReq\x12,\n" +
 	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x16\n" +
 	"\x06reason\x18\x03 \x01(\tR\x06reason\"1\n" +
 	"\bKickResp\x12%\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code2\x91\x01\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code*[\n" +
+	"\x0eDeliveryStatus\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\r\n" +
+	"\tDELIVERED\x10\x01\x12\r\n" +
+	"\tNOT_FOUND\x10\x02\x12\x0e\n" +
+	"\n" +
+	"QUEUE_FULL\x10\x03\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x042\x91\x01\n" +
 	"\fConnectorRPC\x12J\n" +
 	"\vSendMessage\x12\x1c.connectorRPC.SendMessageReq\x1a\x1d.connectorRPC.SendMessageResp\x125\n" +
 	"\x04Kick\x12\x15.connectorRPC.KickReq\x1a\x16.connectorRPC.KickRespBNZLgithub.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC;connectorRPCb\x06proto3"
@@ -267,29 +399,34 @@ func file_api_proto_connectorRPC_connector_proto_rawDescGZIP() []byte {
 	return file_api_proto_connectorRPC_connector_proto_rawDescData
 }
 
-var file_api_proto_connectorRPC_connector_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_proto_connectorRPC_connector_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_proto_connectorRPC_connector_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_api_proto_connectorRPC_connector_proto_goTypes = []any{
-	(*SendMessageReq)(nil),     // 0: connectorRPC.SendMessageReq
-	(*SendMessageResp)(nil),    // 1: connectorRPC.SendMessageResp
-	(*KickReq)(nil),            // 2: connectorRPC.KickReq
-	(*KickResp)(nil),           // 3: connectorRPC.KickResp
-	(*proto.PacketHeader)(nil), // 4: common.PacketHeader
-	(proto.ErrorCode)(0),       // 5: common.ErrorCode
+	(DeliveryStatus)(0),        // 0: connectorRPC.DeliveryStatus
+	(*SendMessageReq)(nil),     // 1: connectorRPC.SendMessageReq
+	(*DeliveryResult)(nil),     // 2: connectorRPC.DeliveryResult
+	(*SendMessageResp)(nil),    // 3: connectorRPC.SendMessageResp
+	(*KickReq)(nil),            // 4: connectorRPC.KickReq
+	(*KickResp)(nil),           // 5: connectorRPC.KickResp
+	(*proto.PacketHeader)(nil), // 6: common.PacketHeader
+	(proto.ErrorCode)(0),       // 7: common.ErrorCode
 }
 var file_api_proto_connectorRPC_connector_proto_depIdxs = []int32{
-	4, // 0: connectorRPC.SendMessageReq.header:type_name -> common.PacketHeader
-	5, // 1: connectorRPC.SendMessageResp.code:type_name -> common.ErrorCode
-	4, // 2: connectorRPC.KickReq.header:type_name -> common.PacketHeader
-	5, // 3: connectorRPC.KickResp.code:type_name -> common.ErrorCode
-	0, // 4: connectorRPC.ConnectorRPC.SendMessage:input_type -> connectorRPC.SendMessageReq
-	2, // 5: connectorRPC.ConnectorRPC.Kick:input_type -> connectorRPC.KickReq
-	1, // 6: connectorRPC.ConnectorRPC.SendMessage:output_type -> connectorRPC.SendMessageResp
-	3, // 7: connectorRPC.ConnectorRPC.Kick:output_type -> connectorRPC.KickResp
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 0: connectorRPC.SendMessageReq.header:type_name -> common.PacketHeader
+	0, // 1: connectorRPC.DeliveryResult.status:type_name -> connectorRPC.DeliveryStatus
+	7, // 2: connectorRPC.SendMessageResp.code:type_name -> common.ErrorCode
+	2, // 3: connectorRPC.SendMessageResp.results:type_name -> connectorRPC.DeliveryResult
+	6, // 4: connectorRPC.KickReq.header:type_name -> common.PacketHeader
+	7, // 5: connectorRPC.KickResp.code:type_name -> common.ErrorCode
+	1, // 6: connectorRPC.ConnectorRPC.SendMessage:input_type -> connectorRPC.SendMessageReq
+	4, // 7: connectorRPC.ConnectorRPC.Kick:input_type -> connectorRPC.KickReq
+	3, // 8: connectorRPC.ConnectorRPC.SendMessage:output_type -> connectorRPC.SendMessageResp
+	5, // 9: connectorRPC.ConnectorRPC.Kick:output_type -> connectorRPC.KickResp
+	8, // [8:10] is the sub-list for method output_type
+	6, // [6:8] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_connectorRPC_connector_proto_init() }
@@ -302,13 +439,14 @@ func file_api_proto_connectorRPC_connector_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_connectorRPC_connector_proto_rawDesc), len(file_api_proto_connectorRPC_connector_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_proto_connectorRPC_connector_proto_goTypes,
 		DependencyIndexes: file_api_proto_connectorRPC_connector_proto_depIdxs,
+		EnumInfos:         file_api_proto_connectorRPC_connector_proto_enumTypes,
 		MessageInfos:      file_api_proto_connectorRPC_connector_proto_msgTypes,
 	}.Build()
 	File_api_proto_connectorRPC_connector_proto = out.File
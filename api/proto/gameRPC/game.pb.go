@@ -8,6 +8,7 @@ package gameRPC
 
 import (
 	proto "github.com/JoeShih716/go-k8s-game-server/api/proto"
+	connectorRPC "github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -126,6 +127,248 @@ func (x *JoinResp) GetErrorMessage() string {
 	return ""
 }
 
+type ReconnectReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *proto.PacketHeader    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`                                    // Header.session_id 為新的 SessionID
+	ConnectorHost string                 `protobuf:"bytes,2,opt,name=connector_host,json=connectorHost,proto3" json:"connector_host,omitempty"` // 新的 Connector Pod IP (grpc host)
+	OldSessionId  string                 `protobuf:"bytes,3,opt,name=old_session_id,json=oldSessionId,proto3" json:"old_session_id,omitempty"`  // 斷線前的 SessionID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconnectReq) Reset() {
+	*x = ReconnectReq{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconnectReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconnectReq) ProtoMessage() {}
+
+func (x *ReconnectReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconnectReq.ProtoReflect.Descriptor instead.
+func (*ReconnectReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReconnectReq) GetHeader() *proto.PacketHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *ReconnectReq) GetConnectorHost() string {
+	if x != nil {
+		return x.ConnectorHost
+	}
+	return ""
+}
+
+func (x *ReconnectReq) GetOldSessionId() string {
+	if x != nil {
+		return x.OldSessionId
+	}
+	return ""
+}
+
+type ReconnectResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconnectResp) Reset() {
+	*x = ReconnectResp{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconnectResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconnectResp) ProtoMessage() {}
+
+func (x *ReconnectResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconnectResp.ProtoReflect.Descriptor instead.
+func (*ReconnectResp) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReconnectResp) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *ReconnectResp) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type DrainReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 排空期限秒數 (0 代表使用伺服器預設值)
+	// 已在排空中時期限只會提前、不會延後: 晚於既有期限的要求不生效 (DrainResp.deadline_clamped 為 true)
+	DeadlineSec   int32 `protobuf:"varint,1,opt,name=deadline_sec,json=deadlineSec,proto3" json:"deadline_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainReq) Reset() {
+	*x = DrainReq{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainReq) ProtoMessage() {}
+
+func (x *DrainReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainReq.ProtoReflect.Descriptor instead.
+func (*DrainReq) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DrainReq) GetDeadlineSec() int32 {
+	if x != nil {
+		return x.DeadlineSec
+	}
+	return 0
+}
+
+type DrainResp struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Code            proto.ErrorCode        `protobuf:"varint,1,opt,name=code,proto3,enum=common.ErrorCode" json:"code,omitempty"`
+	ErrorMessage    string                 `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	PeerCount       int32                  `protobuf:"varint,3,opt,name=peer_count,json=peerCount,proto3" json:"peer_count,omitempty"`                   // 尚在線的玩家數
+	RoomCount       int32                  `protobuf:"varint,4,opt,name=room_count,json=roomCount,proto3" json:"room_count,omitempty"`                   // 尚未結束的房間數
+	Deadline        int64                  `protobuf:"varint,5,opt,name=deadline,proto3" json:"deadline,omitempty"`                                      // 實際生效的排空期限 (Unix Timestamp)
+	DeadlineClamped bool                   `protobuf:"varint,6,opt,name=deadline_clamped,json=deadlineClamped,proto3" json:"deadline_clamped,omitempty"` // 已在排空中且要求的期限晚於既有期限: 沿用既有 (較早的) 期限
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DrainResp) Reset() {
+	*x = DrainResp{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainResp) ProtoMessage() {}
+
+func (x *DrainResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainResp.ProtoReflect.Descriptor instead.
+func (*DrainResp) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DrainResp) GetCode() proto.ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return proto.ErrorCode(0)
+}
+
+func (x *DrainResp) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *DrainResp) GetPeerCount() int32 {
+	if x != nil {
+		return x.PeerCount
+	}
+	return 0
+}
+
+func (x *DrainResp) GetRoomCount() int32 {
+	if x != nil {
+		return x.RoomCount
+	}
+	return 0
+}
+
+func (x *DrainResp) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *DrainResp) GetDeadlineClamped() bool {
+	if x != nil {
+		return x.DeadlineClamped
+	}
+	return false
+}
+
 type QuitReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *proto.PacketHeader    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
@@ -135,7 +378,7 @@ type QuitReq struct {
 
 func (x *QuitReq) Reset() {
 	*x = QuitReq{}
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[2]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +390,7 @@ func (x *QuitReq) String() string {
 func (*QuitReq) ProtoMessage() {}
 
 func (x *QuitReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[2]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +403,7 @@ func (x *QuitReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuitReq.ProtoReflect.Descriptor instead.
 func (*QuitReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *QuitReq) GetHeader() *proto.PacketHeader {
@@ -179,7 +422,7 @@ type QuitResp struct {
 
 func (x *QuitResp) Reset() {
 	*x = QuitResp{}
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[3]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -191,7 +434,7 @@ func (x *QuitResp) String() string {
 func (*QuitResp) ProtoMessage() {}
 
 func (x *QuitResp) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[3]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +447,7 @@ func (x *QuitResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuitResp.ProtoReflect.Descriptor instead.
 func (*QuitResp) Descriptor() ([]byte, []int) {
-	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *QuitResp) GetCode() proto.ErrorCode {
@@ -218,14 +461,15 @@ func (x *QuitResp) GetCode() proto.ErrorCode {
 type MsgReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Header        *proto.PacketHeader    `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`   // 標準標頭
-	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"` // 業務邏輯 payload
+	Payload       []byte                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"` // 業務邏輯 payload (客戶端封包的 payload，不含外層 Envelope)
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`   // 客戶端封包的指令代碼 (ex: "spin")
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MsgReq) Reset() {
 	*x = MsgReq{}
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[4]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -237,7 +481,7 @@ func (x *MsgReq) String() string {
 func (*MsgReq) ProtoMessage() {}
 
 func (x *MsgReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[4]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,7 +494,7 @@ func (x *MsgReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgReq.ProtoReflect.Descriptor instead.
 func (*MsgReq) Descriptor() ([]byte, []int) {
-	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MsgReq) GetHeader() *proto.PacketHeader {
@@ -267,6 +511,13 @@ func (x *MsgReq) GetPayload() []byte {
 	return nil
 }
 
+func (x *MsgReq) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
 // MsgResp 包含回應結果
 type MsgResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -279,7 +530,7 @@ type MsgResp struct {
 
 func (x *MsgResp) Reset() {
 	*x = MsgResp{}
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[5]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +542,7 @@ func (x *MsgResp) String() string {
 func (*MsgResp) ProtoMessage() {}
 
 func (x *MsgResp) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_gameRPC_game_proto_msgTypes[5]
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +555,7 @@ func (x *MsgResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MsgResp.ProtoReflect.Descriptor instead.
 func (*MsgResp) Descriptor() ([]byte, []int) {
-	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *MsgResp) GetCode() proto.ErrorCode {
@@ -328,80 +579,678 @@ func (x *MsgResp) GetErrorMessage() string {
 	return ""
 }
 
-var File_api_proto_gameRPC_game_proto protoreflect.FileDescriptor
+// ChannelUp Connector -> Game 的串流訊框
+type ChannelUp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Frame:
+	//
+	//	*ChannelUp_Hello
+	//	*ChannelUp_Message
+	//	*ChannelUp_PushAck
+	Frame         isChannelUp_Frame `protobuf_oneof:"frame"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_gameRPC_game_proto_rawDesc = "" +
-	"\n" +
-	"\x1capi/proto/gameRPC/game.proto\x12\agameRPC\x1a\x16api/proto/common.proto\"^\n" +
-	"\aJoinReq\x12,\n" +
-	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12%\n" +
-	"\x0econnector_host\x18\x02 \x01(\tR\rconnectorHost\"V\n" +
-	"\bJoinResp\x12%\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
-	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"7\n" +
-	"\aQuitReq\x12,\n" +
-	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\"1\n" +
-	"\bQuitResp\x12%\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\"P\n" +
-	"\x06MsgReq\x12,\n" +
-	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\fR\apayload\"o\n" +
-	"\aMsgResp\x12%\n" +
-	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12\x18\n" +
-	"\apayload\x18\x02 \x01(\fR\apayload\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage2\xa3\x01\n" +
-	"\aGameRPC\x123\n" +
-	"\fOnPlayerJoin\x12\x10.gameRPC.JoinReq\x1a\x11.gameRPC.JoinResp\x123\n" +
-	"\fOnPlayerQuit\x12\x10.gameRPC.QuitReq\x1a\x11.gameRPC.QuitResp\x12.\n" +
-	"\tOnMessage\x12\x0f.gameRPC.MsgReq\x1a\x10.gameRPC.MsgRespBDZBgithub.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC;gameRPCb\x06proto3"
+func (x *ChannelUp) Reset() {
+	*x = ChannelUp{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_api_proto_gameRPC_game_proto_rawDescOnce sync.Once
-	file_api_proto_gameRPC_game_proto_rawDescData []byte
-)
+func (x *ChannelUp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_api_proto_gameRPC_game_proto_rawDescGZIP() []byte {
-	file_api_proto_gameRPC_game_proto_rawDescOnce.Do(func() {
-		file_api_proto_gameRPC_game_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_gameRPC_game_proto_rawDesc), len(file_api_proto_gameRPC_game_proto_rawDesc)))
-	})
-	return file_api_proto_gameRPC_game_proto_rawDescData
+func (*ChannelUp) ProtoMessage() {}
+
+func (x *ChannelUp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_api_proto_gameRPC_game_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_api_proto_gameRPC_game_proto_goTypes = []any{
-	(*JoinReq)(nil),            // 0: gameRPC.JoinReq
-	(*JoinResp)(nil),           // 1: gameRPC.JoinResp
-	(*QuitReq)(nil),            // 2: gameRPC.QuitReq
-	(*QuitResp)(nil),           // 3: gameRPC.QuitResp
-	(*MsgReq)(nil),             // 4: gameRPC.MsgReq
-	(*MsgResp)(nil),            // 5: gameRPC.MsgResp
-	(*proto.PacketHeader)(nil), // 6: common.PacketHeader
-	(proto.ErrorCode)(0),       // 7: common.ErrorCode
+// Deprecated: Use ChannelUp.ProtoReflect.Descriptor instead.
+func (*ChannelUp) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{10}
 }
-var file_api_proto_gameRPC_game_proto_depIdxs = []int32{
-	6, // 0: gameRPC.JoinReq.header:type_name -> common.PacketHeader
-	7, // 1: gameRPC.JoinResp.code:type_name -> common.ErrorCode
-	6, // 2: gameRPC.QuitReq.header:type_name -> common.PacketHeader
-	7, // 3: gameRPC.QuitResp.code:type_name -> common.ErrorCode
-	6, // 4: gameRPC.MsgReq.header:type_name -> common.PacketHeader
-	7, // 5: gameRPC.MsgResp.code:type_name -> common.ErrorCode
-	0, // 6: gameRPC.GameRPC.OnPlayerJoin:input_type -> gameRPC.JoinReq
-	2, // 7: gameRPC.GameRPC.OnPlayerQuit:input_type -> gameRPC.QuitReq
-	4, // 8: gameRPC.GameRPC.OnMessage:input_type -> gameRPC.MsgReq
-	1, // 9: gameRPC.GameRPC.OnPlayerJoin:output_type -> gameRPC.JoinResp
-	3, // 10: gameRPC.GameRPC.OnPlayerQuit:output_type -> gameRPC.QuitResp
-	5, // 11: gameRPC.GameRPC.OnMessage:output_type -> gameRPC.MsgResp
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+
+func (x *ChannelUp) GetFrame() isChannelUp_Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
 }
 
-func init() { file_api_proto_gameRPC_game_proto_init() }
-func file_api_proto_gameRPC_game_proto_init() {
-	if File_api_proto_gameRPC_game_proto != nil {
-		return
+func (x *ChannelUp) GetHello() *ChannelHello {
+	if x != nil {
+		if x, ok := x.Frame.(*ChannelUp_Hello); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *ChannelUp) GetMessage() *ChannelMessage {
+	if x != nil {
+		if x, ok := x.Frame.(*ChannelUp_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *ChannelUp) GetPushAck() *ChannelPushAck {
+	if x != nil {
+		if x, ok := x.Frame.(*ChannelUp_PushAck); ok {
+			return x.PushAck
+		}
+	}
+	return nil
+}
+
+type isChannelUp_Frame interface {
+	isChannelUp_Frame()
+}
+
+type ChannelUp_Hello struct {
+	Hello *ChannelHello `protobuf:"bytes,1,opt,name=hello,proto3,oneof"` // 首個訊框: 宣告 Connector 身分
+}
+
+type ChannelUp_Message struct {
+	Message *ChannelMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"` // 玩家訊息
+}
+
+type ChannelUp_PushAck struct {
+	PushAck *ChannelPushAck `protobuf:"bytes,3,opt,name=push_ack,json=pushAck,proto3,oneof"` // 推播投遞結果
+}
+
+func (*ChannelUp_Hello) isChannelUp_Frame() {}
+
+func (*ChannelUp_Message) isChannelUp_Frame() {}
+
+func (*ChannelUp_PushAck) isChannelUp_Frame() {}
+
+// ChannelDown Game -> Connector 的串流訊框
+type ChannelDown struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Frame:
+	//
+	//	*ChannelDown_Reply
+	//	*ChannelDown_Push
+	//	*ChannelDown_Ready
+	Frame         isChannelDown_Frame `protobuf_oneof:"frame"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelDown) Reset() {
+	*x = ChannelDown{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelDown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelDown) ProtoMessage() {}
+
+func (x *ChannelDown) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelDown.ProtoReflect.Descriptor instead.
+func (*ChannelDown) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChannelDown) GetFrame() isChannelDown_Frame {
+	if x != nil {
+		return x.Frame
+	}
+	return nil
+}
+
+func (x *ChannelDown) GetReply() *ChannelReply {
+	if x != nil {
+		if x, ok := x.Frame.(*ChannelDown_Reply); ok {
+			return x.Reply
+		}
+	}
+	return nil
+}
+
+func (x *ChannelDown) GetPush() *ChannelPush {
+	if x != nil {
+		if x, ok := x.Frame.(*ChannelDown_Push); ok {
+			return x.Push
+		}
+	}
+	return nil
+}
+
+func (x *ChannelDown) GetReady() *ChannelReady {
+	if x != nil {
+		if x, ok := x.Frame.(*ChannelDown_Ready); ok {
+			return x.Ready
+		}
+	}
+	return nil
+}
+
+type isChannelDown_Frame interface {
+	isChannelDown_Frame()
+}
+
+type ChannelDown_Reply struct {
+	Reply *ChannelReply `protobuf:"bytes,1,opt,name=reply,proto3,oneof"` // 對應 ChannelMessage 的回應
+}
+
+type ChannelDown_Push struct {
+	Push *ChannelPush `protobuf:"bytes,2,opt,name=push,proto3,oneof"` // Game 主動推播
+}
+
+type ChannelDown_Ready struct {
+	Ready *ChannelReady `protobuf:"bytes,3,opt,name=ready,proto3,oneof"` // 首個訊框: 回應 Hello，代表串流可用
+}
+
+func (*ChannelDown_Reply) isChannelDown_Frame() {}
+
+func (*ChannelDown_Push) isChannelDown_Frame() {}
+
+func (*ChannelDown_Ready) isChannelDown_Frame() {}
+
+type ChannelHello struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConnectorHost string                 `protobuf:"bytes,1,opt,name=connector_host,json=connectorHost,proto3" json:"connector_host,omitempty"` // Connector 的 Pod IP (grpc host)，與 JoinReq.connector_host 相同
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelHello) Reset() {
+	*x = ChannelHello{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelHello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelHello) ProtoMessage() {}
+
+func (x *ChannelHello) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelHello.ProtoReflect.Descriptor instead.
+func (*ChannelHello) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChannelHello) GetConnectorHost() string {
+	if x != nil {
+		return x.ConnectorHost
+	}
+	return ""
+}
+
+type ChannelReady struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelReady) Reset() {
+	*x = ChannelReady{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelReady) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelReady) ProtoMessage() {}
+
+func (x *ChannelReady) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelReady.ProtoReflect.Descriptor instead.
+func (*ChannelReady) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{13}
+}
+
+type ChannelMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // 串流內唯一序號，回應以此對應
+	Req           *MsgReq                `protobuf:"bytes,2,opt,name=req,proto3" json:"req,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelMessage) Reset() {
+	*x = ChannelMessage{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelMessage) ProtoMessage() {}
+
+func (x *ChannelMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelMessage.ProtoReflect.Descriptor instead.
+func (*ChannelMessage) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChannelMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChannelMessage) GetReq() *MsgReq {
+	if x != nil {
+		return x.Req
+	}
+	return nil
+}
+
+type ChannelReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seq           uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Resp          *MsgResp               `protobuf:"bytes,2,opt,name=resp,proto3" json:"resp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelReply) Reset() {
+	*x = ChannelReply{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelReply) ProtoMessage() {}
+
+func (x *ChannelReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelReply.ProtoReflect.Descriptor instead.
+func (*ChannelReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ChannelReply) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChannelReply) GetResp() *MsgResp {
+	if x != nil {
+		return x.Resp
+	}
+	return nil
+}
+
+type ChannelPush struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PushId        uint64                 `protobuf:"varint,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"` // 串流內唯一推播序號，回執以此對應
+	SessionIds    []string               `protobuf:"bytes,2,rep,name=session_ids,json=sessionIds,proto3" json:"session_ids,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	TraceContext  map[string]string      `protobuf:"bytes,4,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 推播發起端的追蹤上下文 (W3C traceparent / tracestate)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelPush) Reset() {
+	*x = ChannelPush{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelPush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelPush) ProtoMessage() {}
+
+func (x *ChannelPush) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelPush.ProtoReflect.Descriptor instead.
+func (*ChannelPush) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ChannelPush) GetPushId() uint64 {
+	if x != nil {
+		return x.PushId
+	}
+	return 0
+}
+
+func (x *ChannelPush) GetSessionIds() []string {
+	if x != nil {
+		return x.SessionIds
+	}
+	return nil
+}
+
+func (x *ChannelPush) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChannelPush) GetTraceContext() map[string]string {
+	if x != nil {
+		return x.TraceContext
+	}
+	return nil
+}
+
+type ChannelPushAck struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	PushId        uint64                         `protobuf:"varint,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+	Results       []*connectorRPC.DeliveryResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChannelPushAck) Reset() {
+	*x = ChannelPushAck{}
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelPushAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelPushAck) ProtoMessage() {}
+
+func (x *ChannelPushAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_gameRPC_game_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelPushAck.ProtoReflect.Descriptor instead.
+func (*ChannelPushAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_gameRPC_game_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ChannelPushAck) GetPushId() uint64 {
+	if x != nil {
+		return x.PushId
+	}
+	return 0
+}
+
+func (x *ChannelPushAck) GetResults() []*connectorRPC.DeliveryResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_api_proto_gameRPC_game_proto protoreflect.FileDescriptor
+
+const file_api_proto_gameRPC_game_proto_rawDesc = "" +
+	"\n" +
+	"\x1capi/proto/gameRPC/game.proto\x12\agameRPC\x1a\x16api/proto/common.proto\x1a&api/proto/connectorRPC/connector.proto\"^\n" +
+	"\aJoinReq\x12,\n" +
+	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12%\n" +
+	"\x0econnector_host\x18\x02 \x01(\tR\rconnectorHost\"V\n" +
+	"\bJoinResp\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"\x89\x01\n" +
+	"\fReconnectReq\x12,\n" +
+	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12%\n" +
+	"\x0econnector_host\x18\x02 \x01(\tR\rconnectorHost\x12$\n" +
+	"\x0eold_session_id\x18\x03 \x01(\tR\foldSessionId\"[\n" +
+	"\rReconnectResp\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\"-\n" +
+	"\bDrainReq\x12!\n" +
+	"\fdeadline_sec\x18\x01 \x01(\x05R\vdeadlineSec\"\xdc\x01\n" +
+	"\tDrainResp\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12#\n" +
+	"\rerror_message\x18\x02 \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"peer_count\x18\x03 \x01(\x05R\tpeerCount\x12\x1d\n" +
+	"\n" +
+	"room_count\x18\x04 \x01(\x05R\troomCount\x12\x1a\n" +
+	"\bdeadline\x18\x05 \x01(\x03R\bdeadline\x12)\n" +
+	"\x10deadline_clamped\x18\x06 \x01(\bR\x0fdeadlineClamped\"7\n" +
+	"\aQuitReq\x12,\n" +
+	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\"1\n" +
+	"\bQuitResp\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\"h\n" +
+	"\x06MsgReq\x12,\n" +
+	"\x06header\x18\x01 \x01(\v2\x14.common.PacketHeaderR\x06header\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\"o\n" +
+	"\aMsgResp\x12%\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x11.common.ErrorCodeR\x04code\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\xae\x01\n" +
+	"\tChannelUp\x12-\n" +
+	"\x05hello\x18\x01 \x01(\v2\x15.gameRPC.ChannelHelloH\x00R\x05hello\x123\n" +
+	"\amessage\x18\x02 \x01(\v2\x17.gameRPC.ChannelMessageH\x00R\amessage\x124\n" +
+	"\bpush_ack\x18\x03 \x01(\v2\x17.gameRPC.ChannelPushAckH\x00R\apushAckB\a\n" +
+	"\x05frame\"\xa0\x01\n" +
+	"\vChannelDown\x12-\n" +
+	"\x05reply\x18\x01 \x01(\v2\x15.gameRPC.ChannelReplyH\x00R\x05reply\x12*\n" +
+	"\x04push\x18\x02 \x01(\v2\x14.gameRPC.ChannelPushH\x00R\x04push\x12-\n" +
+	"\x05ready\x18\x03 \x01(\v2\x15.gameRPC.ChannelReadyH\x00R\x05readyB\a\n" +
+	"\x05frame\"5\n" +
+	"\fChannelHello\x12%\n" +
+	"\x0econnector_host\x18\x01 \x01(\tR\rconnectorHost\"\x0e\n" +
+	"\fChannelReady\"E\n" +
+	"\x0eChannelMessage\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12!\n" +
+	"\x03req\x18\x02 \x01(\v2\x0f.gameRPC.MsgReqR\x03req\"F\n" +
+	"\fChannelReply\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12$\n" +
+	"\x04resp\x18\x02 \x01(\v2\x10.gameRPC.MsgRespR\x04resp\"\xef\x01\n" +
+	"\vChannelPush\x12\x17\n" +
+	"\apush_id\x18\x01 \x01(\x04R\x06pushId\x12\x1f\n" +
+	"\vsession_ids\x18\x02 \x03(\tR\n" +
+	"sessionIds\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12K\n" +
+	"\rtrace_context\x18\x04 \x03(\v2&.gameRPC.ChannelPush.TraceContextEntryR\ftraceContext\x1a?\n" +
+	"\x11TraceContextEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"a\n" +
+	"\x0eChannelPushAck\x12\x17\n" +
+	"\apush_id\x18\x01 \x01(\x04R\x06pushId\x126\n" +
+	"\aresults\x18\x02 \x03(\v2\x1c.connectorRPC.DeliveryResultR\aresults2\xd0\x02\n" +
+	"\aGameRPC\x123\n" +
+	"\fOnPlayerJoin\x12\x10.gameRPC.JoinReq\x1a\x11.gameRPC.JoinResp\x123\n" +
+	"\fOnPlayerQuit\x12\x10.gameRPC.QuitReq\x1a\x11.gameRPC.QuitResp\x12.\n" +
+	"\tOnMessage\x12\x0f.gameRPC.MsgReq\x1a\x10.gameRPC.MsgResp\x12B\n" +
+	"\x11OnPlayerReconnect\x12\x15.gameRPC.ReconnectReq\x1a\x16.gameRPC.ReconnectResp\x127\n" +
+	"\aChannel\x12\x12.gameRPC.ChannelUp\x1a\x14.gameRPC.ChannelDown(\x010\x01\x12.\n" +
+	"\x05Drain\x12\x11.gameRPC.DrainReq\x1a\x12.gameRPC.DrainRespBDZBgithub.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC;gameRPCb\x06proto3"
+
+var (
+	file_api_proto_gameRPC_game_proto_rawDescOnce sync.Once
+	file_api_proto_gameRPC_game_proto_rawDescData []byte
+)
+
+func file_api_proto_gameRPC_game_proto_rawDescGZIP() []byte {
+	file_api_proto_gameRPC_game_proto_rawDescOnce.Do(func() {
+		file_api_proto_gameRPC_game_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_gameRPC_game_proto_rawDesc), len(file_api_proto_gameRPC_game_proto_rawDesc)))
+	})
+	return file_api_proto_gameRPC_game_proto_rawDescData
+}
+
+var file_api_proto_gameRPC_game_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_api_proto_gameRPC_game_proto_goTypes = []any{
+	(*JoinReq)(nil),                     // 0: gameRPC.JoinReq
+	(*JoinResp)(nil),                    // 1: gameRPC.JoinResp
+	(*ReconnectReq)(nil),                // 2: gameRPC.ReconnectReq
+	(*ReconnectResp)(nil),               // 3: gameRPC.ReconnectResp
+	(*DrainReq)(nil),                    // 4: gameRPC.DrainReq
+	(*DrainResp)(nil),                   // 5: gameRPC.DrainResp
+	(*QuitReq)(nil),                     // 6: gameRPC.QuitReq
+	(*QuitResp)(nil),                    // 7: gameRPC.QuitResp
+	(*MsgReq)(nil),                      // 8: gameRPC.MsgReq
+	(*MsgResp)(nil),                     // 9: gameRPC.MsgResp
+	(*ChannelUp)(nil),                   // 10: gameRPC.ChannelUp
+	(*ChannelDown)(nil),                 // 11: gameRPC.ChannelDown
+	(*ChannelHello)(nil),                // 12: gameRPC.ChannelHello
+	(*ChannelReady)(nil),                // 13: gameRPC.ChannelReady
+	(*ChannelMessage)(nil),              // 14: gameRPC.ChannelMessage
+	(*ChannelReply)(nil),                // 15: gameRPC.ChannelReply
+	(*ChannelPush)(nil),                 // 16: gameRPC.ChannelPush
+	(*ChannelPushAck)(nil),              // 17: gameRPC.ChannelPushAck
+	nil,                                 // 18: gameRPC.ChannelPush.TraceContextEntry
+	(*proto.PacketHeader)(nil),          // 19: common.PacketHeader
+	(proto.ErrorCode)(0),                // 20: common.ErrorCode
+	(*connectorRPC.DeliveryResult)(nil), // 21: connectorRPC.DeliveryResult
+}
+var file_api_proto_gameRPC_game_proto_depIdxs = []int32{
+	19, // 0: gameRPC.JoinReq.header:type_name -> common.PacketHeader
+	20, // 1: gameRPC.JoinResp.code:type_name -> common.ErrorCode
+	19, // 2: gameRPC.ReconnectReq.header:type_name -> common.PacketHeader
+	20, // 3: gameRPC.ReconnectResp.code:type_name -> common.ErrorCode
+	20, // 4: gameRPC.DrainResp.code:type_name -> common.ErrorCode
+	19, // 5: gameRPC.QuitReq.header:type_name -> common.PacketHeader
+	20, // 6: gameRPC.QuitResp.code:type_name -> common.ErrorCode
+	19, // 7: gameRPC.MsgReq.header:type_name -> common.PacketHeader
+	20, // 8: gameRPC.MsgResp.code:type_name -> common.ErrorCode
+	12, // 9: gameRPC.ChannelUp.hello:type_name -> gameRPC.ChannelHello
+	14, // 10: gameRPC.ChannelUp.message:type_name -> gameRPC.ChannelMessage
+	17, // 11: gameRPC.ChannelUp.push_ack:type_name -> gameRPC.ChannelPushAck
+	15, // 12: gameRPC.ChannelDown.reply:type_name -> gameRPC.ChannelReply
+	16, // 13: gameRPC.ChannelDown.push:type_name -> gameRPC.ChannelPush
+	13, // 14: gameRPC.ChannelDown.ready:type_name -> gameRPC.ChannelReady
+	8,  // 15: gameRPC.ChannelMessage.req:type_name -> gameRPC.MsgReq
+	9,  // 16: gameRPC.ChannelReply.resp:type_name -> gameRPC.MsgResp
+	18, // 17: gameRPC.ChannelPush.trace_context:type_name -> gameRPC.ChannelPush.TraceContextEntry
+	21, // 18: gameRPC.ChannelPushAck.results:type_name -> connectorRPC.DeliveryResult
+	0,  // 19: gameRPC.GameRPC.OnPlayerJoin:input_type -> gameRPC.JoinReq
+	6,  // 20: gameRPC.GameRPC.OnPlayerQuit:input_type -> gameRPC.QuitReq
+	8,  // 21: gameRPC.GameRPC.OnMessage:input_type -> gameRPC.MsgReq
+	2,  // 22: gameRPC.GameRPC.OnPlayerReconnect:input_type -> gameRPC.ReconnectReq
+	10, // 23: gameRPC.GameRPC.Channel:input_type -> gameRPC.ChannelUp
+	4,  // 24: gameRPC.GameRPC.Drain:input_type -> gameRPC.DrainReq
+	1,  // 25: gameRPC.GameRPC.OnPlayerJoin:output_type -> gameRPC.JoinResp
+	7,  // 26: gameRPC.GameRPC.OnPlayerQuit:output_type -> gameRPC.QuitResp
+	9,  // 27: gameRPC.GameRPC.OnMessage:output_type -> gameRPC.MsgResp
+	3,  // 28: gameRPC.GameRPC.OnPlayerReconnect:output_type -> gameRPC.ReconnectResp
+	11, // 29: gameRPC.GameRPC.Channel:output_type -> gameRPC.ChannelDown
+	5,  // 30: gameRPC.GameRPC.Drain:output_type -> gameRPC.DrainResp
+	25, // [25:31] is the sub-list for method output_type
+	19, // [19:25] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_gameRPC_game_proto_init() }
+func file_api_proto_gameRPC_game_proto_init() {
+	if File_api_proto_gameRPC_game_proto != nil {
+		return
+	}
+	file_api_proto_gameRPC_game_proto_msgTypes[10].OneofWrappers = []any{
+		(*ChannelUp_Hello)(nil),
+		(*ChannelUp_Message)(nil),
+		(*ChannelUp_PushAck)(nil),
+	}
+	file_api_proto_gameRPC_game_proto_msgTypes[11].OneofWrappers = []any{
+		(*ChannelDown_Reply)(nil),
+		(*ChannelDown_Push)(nil),
+		(*ChannelDown_Ready)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -409,7 +1258,7 @@ func file_api_proto_gameRPC_game_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_gameRPC_game_proto_rawDesc), len(file_api_proto_gameRPC_game_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GameRPC_OnPlayerJoin_FullMethodName = "/gameRPC.GameRPC/OnPlayerJoin"
-	GameRPC_OnPlayerQuit_FullMethodName = "/gameRPC.GameRPC/OnPlayerQuit"
-	GameRPC_OnMessage_FullMethodName    = "/gameRPC.GameRPC/OnMessage"
+	GameRPC_OnPlayerJoin_FullMethodName      = "/gameRPC.GameRPC/OnPlayerJoin"
+	GameRPC_OnPlayerQuit_FullMethodName      = "/gameRPC.GameRPC/OnPlayerQuit"
+	GameRPC_OnMessage_FullMethodName         = "/gameRPC.GameRPC/OnMessage"
+	GameRPC_OnPlayerReconnect_FullMethodName = "/gameRPC.GameRPC/OnPlayerReconnect"
+	GameRPC_Channel_FullMethodName           = "/gameRPC.GameRPC/Channel"
+	GameRPC_Drain_FullMethodName             = "/gameRPC.GameRPC/Drain"
 )
 
 // GameRPCClient is the client API for GameRPC service.
@@ -37,6 +40,16 @@ type GameRPCClient interface {
 	OnPlayerQuit(ctx context.Context, in *QuitReq, opts ...grpc.CallOption) (*QuitResp, error)
 	// OnMessage 處理來自 Connector 的通用遊戲訊息
 	OnMessage(ctx context.Context, in *MsgReq, opts ...grpc.CallOption) (*MsgResp, error)
+	// OnPlayerReconnect 通知 Game Server 玩家已斷線重連 (Connector -> Game)
+	// 玩家在保留期限內以 Resume Token 恢復會話，可能來自不同的 Connector 與新的 SessionID
+	OnPlayerReconnect(ctx context.Context, in *ReconnectReq, opts ...grpc.CallOption) (*ReconnectResp, error)
+	// Channel 每組 Connector <-> Game 共用一條雙向串流 (多工所有 Session)
+	// 上行: 玩家訊息與推播回執；下行: 訊息回應與 Game 主動推播
+	// 同一 Session 的訊息依序處理，不同 Session 之間並行；串流不可用時 Connector 退回 Unary OnMessage
+	Channel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChannelUp, ChannelDown], error)
+	// Drain 進入排空模式 (Mgmt / 運維工具 -> Game)
+	// 租約標記為排空中 (Central 不再分配新玩家)，已在線的玩家可繼續遊戲直到離開、房間結束或期限到達
+	Drain(ctx context.Context, in *DrainReq, opts ...grpc.CallOption) (*DrainResp, error)
 }
 
 type gameRPCClient struct {
@@ -77,6 +90,39 @@ func (c *gameRPCClient) OnMessage(ctx context.Context, in *MsgReq, opts ...grpc.
 	return out, nil
 }
 
+func (c *gameRPCClient) OnPlayerReconnect(ctx context.Context, in *ReconnectReq, opts ...grpc.CallOption) (*ReconnectResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconnectResp)
+	err := c.cc.Invoke(ctx, GameRPC_OnPlayerReconnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameRPCClient) Channel(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ChannelUp, ChannelDown], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameRPC_ServiceDesc.Streams[0], GameRPC_Channel_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChannelUp, ChannelDown]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameRPC_ChannelClient = grpc.BidiStreamingClient[ChannelUp, ChannelDown]
+
+func (c *gameRPCClient) Drain(ctx context.Context, in *DrainReq, opts ...grpc.CallOption) (*DrainResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DrainResp)
+	err := c.cc.Invoke(ctx, GameRPC_Drain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameRPCServer is the server API for GameRPC service.
 // All implementations must embed UnimplementedGameRPCServer
 // for forward compatibility.
@@ -90,6 +136,16 @@ type GameRPCServer interface {
 	OnPlayerQuit(context.Context, *QuitReq) (*QuitResp, error)
 	// OnMessage 處理來自 Connector 的通用遊戲訊息
 	OnMessage(context.Context, *MsgReq) (*MsgResp, error)
+	// OnPlayerReconnect 通知 Game Server 玩家已斷線重連 (Connector -> Game)
+	// 玩家在保留期限內以 Resume Token 恢復會話，可能來自不同的 Connector 與新的 SessionID
+	OnPlayerReconnect(context.Context, *ReconnectReq) (*ReconnectResp, error)
+	// Channel 每組 Connector <-> Game 共用一條雙向串流 (多工所有 Session)
+	// 上行: 玩家訊息與推播回執；下行: 訊息回應與 Game 主動推播
+	// 同一 Session 的訊息依序處理，不同 Session 之間並行；串流不可用時 Connector 退回 Unary OnMessage
+	Channel(grpc.BidiStreamingServer[ChannelUp, ChannelDown]) error
+	// Drain 進入排空模式 (Mgmt / 運維工具 -> Game)
+	// 租約標記為排空中 (Central 不再分配新玩家)，已在線的玩家可繼續遊戲直到離開、房間結束或期限到達
+	Drain(context.Context, *DrainReq) (*DrainResp, error)
 	mustEmbedUnimplementedGameRPCServer()
 }
 
@@ -109,6 +165,15 @@ func (UnimplementedGameRPCServer) OnPlayerQuit(context.Context, *QuitReq) (*Quit
 func (UnimplementedGameRPCServer) OnMessage(context.Context, *MsgReq) (*MsgResp, error) {
 	return nil, status.Error(codes.Unimplemented, "method OnMessage not implemented")
 }
+func (UnimplementedGameRPCServer) OnPlayerReconnect(context.Context, *ReconnectReq) (*ReconnectResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method OnPlayerReconnect not implemented")
+}
+func (UnimplementedGameRPCServer) Channel(grpc.BidiStreamingServer[ChannelUp, ChannelDown]) error {
+	return status.Error(codes.Unimplemented, "method Channel not implemented")
+}
+func (UnimplementedGameRPCServer) Drain(context.Context, *DrainReq) (*DrainResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method Drain not implemented")
+}
 func (UnimplementedGameRPCServer) mustEmbedUnimplementedGameRPCServer() {}
 func (UnimplementedGameRPCServer) testEmbeddedByValue()                 {}
 
@@ -184,6 +249,49 @@ func _GameRPC_OnMessage_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameRPC_OnPlayerReconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconnectReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameRPCServer).OnPlayerReconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameRPC_OnPlayerReconnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameRPCServer).OnPlayerReconnect(ctx, req.(*ReconnectReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameRPC_Channel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GameRPCServer).Channel(&grpc.GenericServerStream[ChannelUp, ChannelDown]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameRPC_ChannelServer = grpc.BidiStreamingServer[ChannelUp, ChannelDown]
+
+func _GameRPC_Drain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameRPCServer).Drain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameRPC_Drain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameRPCServer).Drain(ctx, req.(*DrainReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameRPC_ServiceDesc is the grpc.ServiceDesc for GameRPC service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -203,7 +311,22 @@ var GameRPC_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OnMessage",
 			Handler:    _GameRPC_OnMessage_Handler,
 		},
+		{
+			MethodName: "OnPlayerReconnect",
+			Handler:    _GameRPC_OnPlayerReconnect_Handler,
+		},
+		{
+			MethodName: "Drain",
+			Handler:    _GameRPC_Drain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Channel",
+			Handler:       _GameRPC_Channel_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/gameRPC/game.proto",
 }
@@ -16,8 +16,13 @@ import (
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/central/handler"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/central/service"
 	"github.com/JoeShih716/go-k8s-game-server/internal/di"
+	connector_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/connector"
 	infraRedis "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/redis"
 	"github.com/JoeShih716/go-k8s-game-server/internal/kit/bootstrap"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/mysql"
 )
 
 func main() {
@@ -25,14 +30,23 @@ func main() {
 	app := bootstrap.NewApp("central")
 	ctx := context.Background()
 
+	// 1.1 分散式追蹤 (未設定 Exporter 時僅傳遞上游的追蹤上下文)
+	tracerProvider, err := tracing.Init(ctx, app.Name, app.Config.App.Version, app.Config.Tracing)
+	if err != nil {
+		slog.Error("Failed to initialize tracing", "error", err)
+	}
+
 	slog.InfoContext(ctx, "Initializing dependencies concurrently...")
 
 	// 2. 並行初始化資源 (Redis, DB...)
 	// 參考 slot-go 的 Concurrent Init 模式
 	redisChan := make(chan *infraRedis.Provider, 1)
+	mysqlChan := make(chan *mysql.Client, 1)
 	errChan := make(chan error, 2) // Buffer size = number of concurrent tasks
+	numTasks := 0
 
 	// Task A: Init Redis
+	numTasks++
 	go func() {
 		provider, err := di.InitializeRedisProvider(ctx, app.Config)
 		if err != nil {
@@ -42,20 +56,31 @@ func main() {
 		redisChan <- provider
 	}()
 
-	// (Future) Task B: Init MySQL
-	// go func() { ... }()
+	// Task B: Init MySQL (僅在有服務使用 MySQL 時)
+	if di.NeedsMySQL(app.Config) {
+		numTasks++
+		go func() {
+			client, err := di.InitializeMySQLClient(ctx, app.Config)
+			if err != nil {
+				errChan <- fmt.Errorf("mysql init failed: %w", err)
+				return
+			}
+			mysqlChan <- client
+		}()
+	}
 
 	// 3. 收集初始化結果
 	var redisProvider *infraRedis.Provider
+	var mysqlClient *mysql.Client
 
-	// 這裡只有 1 個任務，若是多個可用 loop + select
-	// 為了擴充性，這裡寫成 loop 形式 (雖然只有 1 iteration)
-	const numTasks = 1
 	for i := 0; i < numTasks; i++ {
 		select {
 		case provider := <-redisChan:
 			redisProvider = provider
 			slog.Info("Redis initialized")
+		case client := <-mysqlChan:
+			mysqlClient = client
+			slog.Info("MySQL initialized")
 		case err := <-errChan:
 			slog.Error("Dependency initialization failed", "error", err)
 			os.Exit(1)
@@ -67,20 +92,75 @@ func main() {
 		if redisProvider != nil {
 			redisProvider.Close()
 		}
+		if mysqlClient != nil {
+			_ = mysqlClient.Close()
+		}
 	}()
 
 	// 4. 初始化 Services (Wiring)
 	// 使用 Generic DI Providers 取得各個單一職責的 Service
-	userService := di.ProvideUserService(app.Config, redisProvider)
-	walletService := di.ProvideWalletService(app.Config, redisProvider)
+	userService := di.ProvideUserService(app.Config, redisProvider, mysqlClient)
+	walletService := di.ProvideWalletService(app.Config, redisProvider, mysqlClient)
 	svcRegistry := di.ProvideRegistry(app.Config, redisProvider)
 
+	// 4.1 登入驗證 (JWT / 訪客模式)
+	tokenVerifier, err := di.ProvideTokenVerifier(app.Config)
+	if err != nil {
+		slog.Error("Failed to initialize token verifier", "error", err)
+		os.Exit(1)
+	}
+	authOpts := []service.Option{service.WithGuestLogin(app.Config.Auth.GuestLogin)}
+	if tokenVerifier != nil {
+		authOpts = append(authOpts, service.WithTokenVerifier(tokenVerifier))
+	} else if !app.Config.Auth.GuestLogin {
+		slog.Warn("Neither JWT nor guest login is enabled, all logins will be rejected")
+	}
+	if app.Config.Auth.GuestLogin {
+		slog.Warn("Guest login enabled, do not use in production")
+	}
+
+	// 4.2 在線狀態與叢集推播 (GetPresence / ListOnline / PushToUser / BroadcastAll，需 Redis Session DB)
+	svcOpts := authOpts
+	if presenceStore := di.ProvidePresenceStore(app.Config, redisProvider); presenceStore != nil {
+		svcOpts = append(svcOpts, service.WithPresence(presenceStore))
+	}
+	if pushBus := di.ProvidePushBus(app.Config, redisProvider); pushBus != nil {
+		svcOpts = append(svcOpts, service.WithPush(pushBus))
+	}
+
+	// 4.3 遊戲維護 (由管理服務切換)
+	if maintenance := di.ProvideMaintenanceStore(app.Config, redisProvider); maintenance != nil {
+		svcOpts = append(svcOpts, service.WithMaintenance(maintenance))
+	}
+
+	// 4.4 版本路由規則 (Canary / Blue-Green)
+	if rules := di.ProvideRouteRules(app.Config); len(rules) > 0 {
+		svcOpts = append(svcOpts, service.WithRouteRules(rules))
+		slog.Info("Version routing rules enabled", "rules", len(rules))
+	}
+
+	// 4.5 同時在線會話數限制 (重複登入時經由 ConnectorRPC.Kick 踢除舊會話)
+	connectorPool := grpcpkg.NewPool(
+		grpcpkg.WithInterceptor(metrics.UnaryClientInterceptor()),
+		grpcpkg.WithDialOptions(tracing.DialOption()),
+	)
+	defer func() { _ = connectorPool.Close() }()
+	if limit := app.Config.Session.MaxPerUser; limit > 0 {
+		policy := service.SessionPolicy(app.Config.Session.OnDuplicate)
+		if policy == "" {
+			policy = service.SessionPolicyKickOld
+		}
+		svcOpts = append(svcOpts, service.WithSessionLimit(limit, policy, connector_sdk.NewKicker(connectorPool)))
+		slog.Info("Session limit enabled", "max_per_user", limit, "policy", policy)
+	}
+
 	// 5. 組裝 Central Service (Application Service)
 	centralSvc := service.NewCentralService(
 		userService,
 		walletService,
 		svcRegistry,
 		app.Logger,
+		svcOpts...,
 	)
 
 	// 任務: 定期清理 Zombie Services (每 30 秒)
@@ -97,6 +177,22 @@ func main() {
 	// Handler Layer
 	grpcHandler := handler.NewGRPCHandler(centralSvc)
 
+	// Metrics: 租約數於抓取時向 Registry 查詢
+	metrics.GaugeFunc("central", "registry_leases", "Number of registered service leases.", func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		services, err := svcRegistry.ListServices(ctx)
+		if err != nil {
+			slog.Warn("Failed to list services for metrics", "error", err)
+			return 0
+		}
+		return float64(len(services))
+	})
+
+	// 健康檢查: Redis (Registry / User / Session) 無法連線時停止接收流量
+	app.Health.AddReadiness("redis", redisProvider.Ping)
+	metricsServer := metrics.Serve(app.Config.App.MetricsPort, app.Health.Mount)
+
 	// 5. 啟動服務
 	port := app.Config.App.GrpcPort
 
@@ -111,14 +207,18 @@ func main() {
 				MinTime:             5 * time.Second,
 				PermitWithoutStream: true,
 			}),
+			tracing.ServerOption(),
 		)
 
 		centralRPC.RegisterCentralRPCServer(grpcServer, grpcHandler)
+		app.Health.RegisterGRPC(grpcServer)
 		reflection.Register(grpcServer)
 
 		slog.Info("Central Service listening", "port", port)
 		return grpcServer.Serve(lis)
 	}, func() {
 		// Cleanup handled by defer above
+		metrics.Shutdown(metricsServer)
+		tracing.Shutdown(tracerProvider)
 	})
 }
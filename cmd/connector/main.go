@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -15,9 +16,15 @@ import (
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/handler"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/di"
 	central_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/central"
+	infraRedis "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/redis"
 	"github.com/JoeShih716/go-k8s-game-server/internal/kit/bootstrap"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/health"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
 	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
 	"github.com/JoeShih716/go-k8s-game-server/pkg/wss"
 )
@@ -29,10 +36,20 @@ func main() {
 	// 2. 初始化核心組件
 	sessionMgr := session.NewManager()
 
+	// 分散式追蹤: 每個 WebSocket 訊框建立一個 Span (未設定 Exporter 時僅傳遞追蹤上下文)
+	tracerProvider, err := tracing.Init(context.Background(), app.Name, app.Config.App.Version, app.Config.Tracing)
+	if err != nil {
+		slog.Error("Failed to initialize tracing", "error", err)
+	}
+
 	// 2. Connect to Central Service
 	centralAddr := app.Config.Services["central"]
 	// 建立 gRPC 連線
-	centralConn, err := grpc.NewClient(centralAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	centralConn, err := grpc.NewClient(centralAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(metrics.UnaryClientInterceptor()),
+		tracing.DialOption(),
+	)
 	if err != nil {
 		slog.Error("Failed to connect to central", "error", err)
 		os.Exit(1)
@@ -42,7 +59,10 @@ func main() {
 	centralClient := central_sdk.NewClient(centralConn)
 
 	// 4. gRPC Pool
-	grpcPool := grpcpkg.NewPool()
+	grpcPool := grpcpkg.NewPool(
+		grpcpkg.WithInterceptor(metrics.UnaryClientInterceptor()),
+		grpcpkg.WithDialOptions(tracing.DialOption()),
+	)
 
 	// 5. WebSocket Handler
 	podIP := app.Config.App.PodIP
@@ -55,7 +75,74 @@ func main() {
 	myRPCPoint := fmt.Sprintf("%s:%d", podIP, grpcPort)
 	slog.Info("Connector.. ", "myEndpoint", myRPCPoint)
 
-	wsHandler := handler.NewWebsocketHandler(sessionMgr, grpcPool, centralClient, myRPCPoint)
+	// 5.1 Session Resume / 在線狀態 / 叢集推播 (Optional, 需 Redis Session DB)
+	var handlerOpts []handler.Option
+	var redisProvider *infraRedis.Provider
+	if app.Config.WSS.ResumeGraceSec > 0 || app.Config.WSS.Presence || app.Config.WSS.ClusterPush {
+		redisProvider, err = di.InitializeRedisProvider(context.Background(), app.Config)
+		if err != nil {
+			slog.Error("Failed to initialize Redis", "error", err)
+			os.Exit(1)
+		}
+	}
+	if app.Config.WSS.ResumeGraceSec > 0 {
+		if store := di.ProvideResumeStore(app.Config, redisProvider); store != nil {
+			grace := time.Duration(app.Config.WSS.ResumeGraceSec) * time.Second
+			handlerOpts = append(handlerOpts, handler.WithResume(store, grace))
+			slog.Info("Session resume enabled", "grace", grace)
+		}
+	}
+
+	// 5.2 Game 雙向串流 (Optional)
+	if app.Config.WSS.GameStream {
+		handlerOpts = append(handlerOpts, handler.WithGameStream())
+	}
+
+	// 5.3 在線狀態與叢集推播 (Optional，叢集推播需同時啟用在線狀態)
+	if app.Config.WSS.Presence || app.Config.WSS.ClusterPush {
+		if store := di.ProvidePresenceStore(app.Config, redisProvider); store != nil {
+			handlerOpts = append(handlerOpts, handler.WithPresence(store))
+		}
+	}
+	if app.Config.WSS.ClusterPush {
+		if bus := di.ProvidePushBus(app.Config, redisProvider); bus != nil {
+			handlerOpts = append(handlerOpts, handler.WithClusterPush(bus))
+		}
+	}
+
+	// 5.4 維護通知 (Optional，定期向 Central 查詢維護排程)
+	var maintenancePoll time.Duration
+	if app.Config.WSS.MaintenancePollSec > 0 {
+		maintenancePoll = time.Duration(app.Config.WSS.MaintenancePollSec) * time.Second
+		handlerOpts = append(handlerOpts, handler.WithMaintenanceNotice())
+	}
+
+	// 5.5 流量限制 (Optional，各項為 0 代表不限制)
+	if rl := app.Config.WSS.RateLimit; rl.MessagesPerSec > 0 || len(rl.ActionRates) > 0 || rl.MaxConnsPerIP > 0 || rl.LoginsPerMin > 0 {
+		handlerOpts = append(handlerOpts, handler.WithRateLimit(handler.RateLimit{
+			MessagesPerSec:  rl.MessagesPerSec,
+			MessageBurst:    rl.MessageBurst,
+			ActionRates:     rl.ActionRates,
+			MaxConnsPerIP:   rl.MaxConnsPerIP,
+			LoginsPerMin:    rl.LoginsPerMin,
+			LoginBurst:      rl.LoginBurst,
+			KickAfter:       rl.KickAfter,
+			ForwardedHeader: rl.ForwardedHeader,
+		}))
+		slog.Info("Rate limit enabled", "messages_per_sec", rl.MessagesPerSec, "max_conns_per_ip", rl.MaxConnsPerIP, "logins_per_min", rl.LoginsPerMin, "forwarded_header", rl.ForwardedHeader)
+	}
+
+	wsHandler := handler.NewWebsocketHandler(sessionMgr, grpcPool, centralClient, myRPCPoint, handlerOpts...)
+	if err := wsHandler.ListenPush(context.Background()); err != nil {
+		slog.Error("Failed to subscribe cluster push", "error", err)
+		os.Exit(1)
+	}
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	go wsHandler.RunResumeSweeper(sweeperCtx, time.Second)
+	go wsHandler.RunPresenceRefresher(sweeperCtx, 30*time.Second)
+	if maintenancePoll > 0 {
+		go wsHandler.RunMaintenanceWatcher(sweeperCtx, maintenancePoll)
+	}
 
 	// 6. WebSocket Server
 	wsConfig := &wss.Config{
@@ -65,10 +152,34 @@ func main() {
 		WriteWait:       time.Duration(app.Config.WSS.WriteWaitSec) * time.Second,
 		PongWait:        time.Duration(app.Config.WSS.PongWaitSec) * time.Second,
 		MaxMessageSize:  app.Config.WSS.MaxMessageSize,
+		Subprotocols:    protocol.Subprotocols(), // JSON (預設) 與 Protobuf 二進位模式並存
+		SendQueueSize:   app.Config.WSS.SendQueueSize,
+		OverflowPolicy:  wss.OverflowPolicy(app.Config.WSS.OverflowPolicy),
 	}
 	wsServer := wss.NewServer(context.Background(), wsConfig, app.Logger)
 	wsServer.Register(wsHandler)
 
+	// 6.1 Metrics: 發送佇列深度與丟棄數於抓取時向 Hub 查詢
+	metrics.GaugeFunc("wss", "send_queue_messages", "Messages waiting in WebSocket send queues.", func() float64 {
+		return float64(wsServer.Stats().QueuedMessages)
+	})
+	metrics.CounterFunc("wss", "dropped_messages_total", "Messages dropped or rejected because a send queue was full.", func() float64 {
+		return float64(wsServer.Stats().Dropped)
+	})
+
+	// 健康檢查: WebSocket Hub 停止視為無法恢復 (重啟 Pod)；Central 或 Redis 無法連線時停止接收新連線
+	app.Health.AddLiveness("wss_hub", func(context.Context) error {
+		if !wsServer.Running() {
+			return errors.New("websocket hub stopped")
+		}
+		return nil
+	})
+	app.Health.AddReadiness("central", health.GRPCCheck(centralConn))
+	if redisProvider != nil {
+		app.Health.AddReadiness("redis", redisProvider.Ping)
+	}
+	metricsServer := metrics.Serve(app.Config.App.MetricsPort, app.Health.Mount)
+
 	// 7. HTTP Route
 	path := app.Config.WSS.Path
 	if path == "" {
@@ -93,8 +204,10 @@ func main() {
 					MinTime:             5 * time.Second,
 					PermitWithoutStream: true,
 				}),
+				tracing.ServerOption(),
 			)
 			connectorRPC.RegisterConnectorRPCServer(grpcServer, handler.NewGrpcHandler(sessionMgr))
+			app.Health.RegisterGRPC(grpcServer)
 
 			slog.Info("ConnectorRPC Listening", "port", grpcPort)
 			if err := grpcServer.Serve(lis); err != nil {
@@ -122,9 +235,15 @@ func main() {
 
 		// 3. Wait for Async Handlers (e.g. OnPlayerQuit)
 		slog.Info("Waiting for async handlers...")
+		stopSweeper()
 		wsHandler.Close()
 
 		// Cleanup Resources
 		grpcPool.Close()
+		if redisProvider != nil {
+			redisProvider.Close()
+		}
+		metrics.Shutdown(metricsServer)
+		tracing.Shutdown(tracerProvider)
 	})
 }
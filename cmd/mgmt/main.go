@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/mgmt/handler"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/mgmt/service"
+	"github.com/JoeShih716/go-k8s-game-server/internal/di"
+	connector_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/connector"
+	game_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/game"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/bootstrap"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+)
+
+func main() {
+	// 1. 初始化 App (載入 Config, Logger)
+	app := bootstrap.NewApp("mgmt")
+
+	// 2. 初始化 Redis (Registry / Presence / Push / Maintenance 皆經由 Ports 存取)
+	redisProvider, err := di.InitializeRedisProvider(context.Background(), app.Config)
+	if err != nil {
+		slog.Error("Failed to initialize Redis", "error", err)
+		os.Exit(1)
+	}
+
+	// 3. gRPC Pool (經由 Connector SDK 踢除玩家、Game SDK 排空遊戲服務)
+	grpcPool := grpcpkg.NewPool(grpcpkg.WithInterceptor(metrics.UnaryClientInterceptor()))
+
+	// 4. 組裝 Mgmt Service
+	opts := []service.Option{service.WithGameDrainer(game_sdk.NewDrainer(grpcPool))}
+	if presence := di.ProvidePresenceStore(app.Config, redisProvider); presence != nil {
+		opts = append(opts, service.WithPresence(presence, connector_sdk.NewKicker(grpcPool)))
+	}
+	if bus := di.ProvidePushBus(app.Config, redisProvider); bus != nil {
+		opts = append(opts, service.WithPush(bus))
+	}
+	if maintenance := di.ProvideMaintenanceStore(app.Config, redisProvider); maintenance != nil {
+		opts = append(opts, service.WithMaintenance(maintenance))
+	}
+	mgmtSvc := service.NewMgmtService(di.ProvideRegistry(app.Config, redisProvider), app.Logger, opts...)
+
+	// 未設定管理 Token 時僅允許本機開發環境啟動
+	httpHandler, err := handler.NewHTTPHandler(mgmtSvc, app.Config.Mgmt.AdminToken, app.Config.App.Env)
+	if err != nil {
+		slog.Error("Failed to create management API, set MGMT_ADMIN_TOKEN", "env", app.Config.App.Env, "error", err)
+		os.Exit(1)
+	}
+	if app.Config.Mgmt.AdminToken == "" {
+		slog.Warn("Admin token not set, management API is unauthenticated (local only)")
+	}
+
+	// 5. HTTP Server
+	port := app.Config.App.Port
+	if port == 0 {
+		port = 8081
+	}
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           httpHandler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	// 6. 啟動服務 (Run)
+	app.Health.AddReadiness("redis", redisProvider.Ping)
+	metricsServer := metrics.Serve(app.Config.App.MetricsPort, app.Health.Mount)
+	app.Run(func() error {
+		slog.Info("Mgmt API Listening", "addr", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, func() {
+		// Cleanup Logic (Graceful Shutdown)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+
+		_ = grpcPool.Close()
+		redisProvider.Close()
+		metrics.Shutdown(metricsServer)
+	})
+}
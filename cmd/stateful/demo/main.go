@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	statefuldemo "github.com/JoeShih716/go-k8s-game-server/internal/app/game/stateful_demo"
@@ -15,6 +16,7 @@ func main() {
 		ServiceType:     proto.ServiceType_STATEFUL,
 		GameIDs:         []int32{20000},
 		DefaultGrpcPort: config.DefaultGrpcPort,
+		DrainTimeout:    2 * time.Minute, // 需小於 K8s terminationGracePeriodSeconds
 	}
 
 	host := os.Getenv("POD_IP")
@@ -2,11 +2,18 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/central/service"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
 )
 
 // GRPCHandler 負責將 gRPC 請求轉換為業務調用
@@ -60,39 +67,210 @@ func (h *GRPCHandler) Deregister(ctx context.Context, req *centralRPC.Deregister
 	return &centralRPC.DeregisterResponse{Success: true}, nil
 }
 
+// SetDraining 處理服務排空標記
+func (h *GRPCHandler) SetDraining(ctx context.Context, req *centralRPC.SetDrainingRequest) (*centralRPC.SetDrainingResponse, error) {
+	slog.Info("Service draining updated", "lease", req.LeaseId, "draining", req.Draining)
+
+	if err := h.svc.SetDraining(ctx, req.LeaseId, req.Draining); err != nil {
+		slog.Warn("SetDraining failed", "lease", req.LeaseId, "error", err)
+		return &centralRPC.SetDrainingResponse{Success: false}, nil
+	}
+	return &centralRPC.SetDrainingResponse{Success: true}, nil
+}
+
 // Login 處理玩家登入
 func (h *GRPCHandler) Login(ctx context.Context, req *centralRPC.LoginRequest) (*centralRPC.LoginResponse, error) {
-	user, err := h.svc.Login(ctx, req.Token)
+	user, err := h.svc.Login(ctx, req.Token, req.SessionId, req.ConnectorHost)
 	if err != nil {
-		return &centralRPC.LoginResponse{
+		resp := &centralRPC.LoginResponse{
 			Success:      false,
+			Code:         proto.ErrorCode_SERVER_ERROR,
 			ErrorMessage: err.Error(),
-		}, nil
+		}
+		var maintenanceErr *service.MaintenanceError
+		switch {
+		case errors.Is(err, ports.ErrInvalidToken), errors.Is(err, domain.ErrInvalidToken):
+			resp.Code = proto.ErrorCode_AUTH_FAILED
+		case errors.Is(err, ports.ErrSessionLimitExceeded):
+			resp.Code = proto.ErrorCode_SESSION_LIMIT
+		case errors.As(err, &maintenanceErr):
+			resp.Code = proto.ErrorCode_MAINTENANCE
+			resp.Maintenance = toMaintenanceNotice(maintenanceErr.Maintenance)
+		default:
+			slog.Error("Login failed", "error", err)
+		}
+		return resp, nil
 	}
 
 	return &centralRPC.LoginResponse{
 		Success:  true,
+		Code:     proto.ErrorCode_SUCCESS,
 		UserId:   user.ID,
 		Nickname: user.Name,
 		Balance:  user.Balance.String(),
 	}, nil
 }
 
-// GetRoute 取得路由
+// GetRoute 取得路由 (新加入遊戲時先檢查維護狀態)
 func (h *GRPCHandler) GetRoute(ctx context.Context, req *centralRPC.GetRouteRequest) (*centralRPC.GetRouteResponse, error) {
-	endpoint, sType, err := h.svc.GetGameServerEndpoint(ctx, req.GameId)
+	gameLabel := strconv.Itoa(int(req.GameId))
+	start := time.Now()
+	defer func() {
+		metrics.CentralRouteDuration.WithLabelValues(gameLabel).Observe(time.Since(start).Seconds())
+	}()
+
+	if req.Join {
+		var maintenanceErr *service.MaintenanceError
+		if err := h.svc.CheckMaintenance(ctx, req.UserId, req.GameId); errors.As(err, &maintenanceErr) {
+			slog.Info("Route rejected: under maintenance", "game_id", req.GameId, "user_id", req.UserId)
+			metrics.CentralRouteFailures.WithLabelValues(gameLabel, "maintenance").Inc()
+			return &centralRPC.GetRouteResponse{
+				Code:         proto.ErrorCode_MAINTENANCE,
+				ErrorMessage: err.Error(),
+				Maintenance:  toMaintenanceNotice(maintenanceErr.Maintenance),
+			}, nil
+		}
+	}
+
+	route, err := h.svc.GetGameServerEndpoint(ctx, &domain.RouteRequest{
+		GameID:        req.GameId,
+		UserID:        req.UserId,
+		ClientVersion: req.ClientVersion,
+		Version:       req.Version,
+	})
 	if err != nil {
 		slog.Error("Failed to lookup service for game", "game_id", req.GameId, "error", err)
+		metrics.CentralRouteFailures.WithLabelValues(gameLabel, "error").Inc()
 		return nil, fmt.Errorf("internal server error")
 	}
 
-	if endpoint == "" {
+	if route.Endpoint == "" {
 		slog.Warn("No service found for game", "game_id", req.GameId)
+		metrics.CentralRouteFailures.WithLabelValues(gameLabel, "not_found").Inc()
 		return nil, fmt.Errorf("service not found for game %d", req.GameId)
 	}
 
 	return &centralRPC.GetRouteResponse{
-		TargetEndpoint: endpoint,
-		Type:           sType,
+		TargetEndpoint: route.Endpoint,
+		Type:           route.ServiceType,
+		Code:           proto.ErrorCode_SUCCESS,
+		Version:        route.Version,
+	}, nil
+}
+
+// PushToUser 推播訊息給使用者
+func (h *GRPCHandler) PushToUser(ctx context.Context, req *centralRPC.PushToUserRequest) (*centralRPC.PushToUserResponse, error) {
+	if req.UserId == "" {
+		return &centralRPC.PushToUserResponse{Code: proto.ErrorCode_INVALID_PARAMS, ErrorMessage: "user_id is required"}, nil
+	}
+
+	count, err := h.svc.PushToUser(ctx, req.UserId, req.Payload, req.GameId)
+	if err != nil {
+		code := proto.ErrorCode_SERVER_ERROR
+		if errors.Is(err, ports.ErrUserOffline) {
+			code = proto.ErrorCode_INVALID_PARAMS
+		} else {
+			slog.Error("PushToUser failed", "user_id", req.UserId, "error", err)
+		}
+		return &centralRPC.PushToUserResponse{Code: code, ErrorMessage: err.Error()}, nil
+	}
+
+	return &centralRPC.PushToUserResponse{
+		Code:         proto.ErrorCode_SUCCESS,
+		SessionCount: int32(count),
+	}, nil
+}
+
+// BroadcastAll 全服廣播
+func (h *GRPCHandler) BroadcastAll(ctx context.Context, req *centralRPC.BroadcastAllRequest) (*centralRPC.BroadcastAllResponse, error) {
+	if err := h.svc.BroadcastAll(ctx, req.Payload, req.GameId, req.ConnectorHost); err != nil {
+		slog.Error("BroadcastAll failed", "error", err)
+		return &centralRPC.BroadcastAllResponse{Code: proto.ErrorCode_SERVER_ERROR, ErrorMessage: err.Error()}, nil
+	}
+	return &centralRPC.BroadcastAllResponse{Code: proto.ErrorCode_SUCCESS}, nil
+}
+
+// GetPresence 查詢使用者在線狀態
+func (h *GRPCHandler) GetPresence(ctx context.Context, req *centralRPC.GetPresenceRequest) (*centralRPC.GetPresenceResponse, error) {
+	if req.UserId == "" {
+		return &centralRPC.GetPresenceResponse{Code: proto.ErrorCode_INVALID_PARAMS, ErrorMessage: "user_id is required"}, nil
+	}
+
+	presences, err := h.svc.GetPresence(ctx, req.UserId)
+	if err != nil {
+		slog.Error("GetPresence failed", "user_id", req.UserId, "error", err)
+		return &centralRPC.GetPresenceResponse{Code: proto.ErrorCode_SERVER_ERROR, ErrorMessage: err.Error()}, nil
+	}
+
+	return &centralRPC.GetPresenceResponse{
+		Code:     proto.ErrorCode_SUCCESS,
+		Online:   len(presences) > 0,
+		Sessions: toPresenceInfos(presences),
+	}, nil
+}
+
+// ListOnline 列出在線會話
+func (h *GRPCHandler) ListOnline(ctx context.Context, req *centralRPC.ListOnlineRequest) (*centralRPC.ListOnlineResponse, error) {
+	presences, err := h.svc.ListOnline(ctx, req.GameId)
+	if err != nil {
+		slog.Error("ListOnline failed", "game_id", req.GameId, "error", err)
+		return &centralRPC.ListOnlineResponse{Code: proto.ErrorCode_SERVER_ERROR, ErrorMessage: err.Error()}, nil
+	}
+
+	users := make(map[string]struct{}, len(presences))
+	for _, p := range presences {
+		users[p.UserID] = struct{}{}
+	}
+	return &centralRPC.ListOnlineResponse{
+		Code:      proto.ErrorCode_SUCCESS,
+		Sessions:  toPresenceInfos(presences),
+		UserCount: int32(len(users)),
 	}, nil
 }
+
+// ListMaintenance 列出維護排程
+func (h *GRPCHandler) ListMaintenance(ctx context.Context, _ *centralRPC.ListMaintenanceRequest) (*centralRPC.ListMaintenanceResponse, error) {
+	schedules, err := h.svc.ListMaintenance(ctx)
+	if err != nil {
+		slog.Error("ListMaintenance failed", "error", err)
+		return &centralRPC.ListMaintenanceResponse{Code: proto.ErrorCode_SERVER_ERROR, ErrorMessage: err.Error()}, nil
+	}
+
+	infos := make([]*centralRPC.MaintenanceInfo, len(schedules))
+	for i, m := range schedules {
+		info := toMaintenanceNotice(m)
+		info.Whitelist = m.Whitelist
+		info.KickPlayers = m.KickPlayers
+		infos[i] = info
+	}
+	return &centralRPC.ListMaintenanceResponse{
+		Code:      proto.ErrorCode_SUCCESS,
+		Schedules: infos,
+	}, nil
+}
+
+// toMaintenanceNotice 轉換為回應給玩家的維護資訊 (不含白名單)
+func toMaintenanceNotice(m *domain.Maintenance) *centralRPC.MaintenanceInfo {
+	return &centralRPC.MaintenanceInfo{
+		GameId:    m.GameID,
+		Message:   m.Message,
+		StartAt:   m.StartAt,
+		EndAt:     m.EndAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func toPresenceInfos(presences []*domain.Presence) []*centralRPC.PresenceInfo {
+	infos := make([]*centralRPC.PresenceInfo, len(presences))
+	for i, p := range presences {
+		infos[i] = &centralRPC.PresenceInfo{
+			UserId:        p.UserID,
+			SessionId:     p.SessionID,
+			ConnectorHost: p.ConnectorHost,
+			GameId:        p.GameID,
+			Since:         p.Since,
+			UpdatedAt:     p.UpdatedAt,
+		}
+	}
+	return infos
+}
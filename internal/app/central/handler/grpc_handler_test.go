@@ -16,6 +16,7 @@ import (
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/central/service"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
 )
 
@@ -31,7 +32,7 @@ func setupDependencies(t *testing.T) (*GRPCHandler, *mock_ports.MockUserService,
 	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	svc := service.NewCentralService(mockUserSvc, mockWalletSvc, mockRegistry, logger)
+	svc := service.NewCentralService(mockUserSvc, mockWalletSvc, mockRegistry, logger, service.WithGuestLogin(true))
 	handler := NewGRPCHandler(svc)
 
 	return handler, mockUserSvc, mockWalletSvc, mockRegistry
@@ -61,6 +62,7 @@ func TestGRPCHandler_Login_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.True(t, resp.Success)
+	assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
 	assert.Equal(t, userID, resp.UserId)
 	assert.Equal(t, "500", resp.Balance) // Balance updated from wallet
 }
@@ -81,9 +83,23 @@ func TestGRPCHandler_Login_Failed(t *testing.T) {
 	assert.NoError(t, err) // GRPC call itself succeeded
 	assert.NotNil(t, resp)
 	assert.False(t, resp.Success)
+	assert.Equal(t, proto.ErrorCode_AUTH_FAILED, resp.Code)
 	assert.Contains(t, resp.ErrorMessage, domain.ErrInvalidToken.Error())
 }
 
+func TestGRPCHandler_Login_ServerError(t *testing.T) {
+	h, mockUserSvc, _, _ := setupDependencies(t)
+	ctx := context.Background()
+	req := &centralRPC.LoginRequest{Token: "some-token"}
+
+	mockUserSvc.EXPECT().GetUser(ctx, req.Token).Return(nil, fmt.Errorf("redis down"))
+
+	resp, err := h.Login(ctx, req)
+	assert.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, proto.ErrorCode_SERVER_ERROR, resp.Code)
+}
+
 func TestGRPCHandler_Register_Success(t *testing.T) {
 	h, _, _, mockRegistry := setupDependencies(t)
 	ctx := context.Background()
@@ -136,10 +152,74 @@ func TestGRPCHandler_GetRoute_Success(t *testing.T) {
 	ctx := context.Background()
 	req := &centralRPC.GetRouteRequest{GameId: 1001}
 
-	mockRegistry.EXPECT().SelectServiceByGame(ctx, req.GameId).Return("localhost:8081", proto.ServiceType_STATELESS, nil)
+	mockRegistry.EXPECT().SelectServiceByGame(ctx, req.GameId, domain.VersionSelector{}).Return(&ports.Route{Endpoint: "localhost:8081", ServiceType: proto.ServiceType_STATELESS}, nil)
 
 	resp, err := h.GetRoute(ctx, req)
 	assert.NoError(t, err)
 	assert.Equal(t, "localhost:8081", resp.TargetEndpoint)
 	assert.Equal(t, proto.ServiceType_STATELESS, resp.Type)
 }
+
+func TestGRPCHandler_GetRoute_Maintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
+	mockMaintenance := mock_ports.NewMockMaintenanceStore(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	h := NewGRPCHandler(service.NewCentralService(nil, nil, mockRegistry, logger, service.WithMaintenance(mockMaintenance)))
+	ctx := context.Background()
+
+	mockMaintenance.EXPECT().Get(ctx, int32(0)).Return(nil, nil).AnyTimes()
+	mockMaintenance.EXPECT().Get(ctx, int32(1001)).Return(&domain.Maintenance{GameID: 1001, Message: "upgrading", EndAt: 1900000000, Whitelist: []string{"tester"}}, nil).AnyTimes()
+
+	// 新加入遊戲: 回傳 MAINTENANCE 與維護資訊 (不含白名單)
+	resp, err := h.GetRoute(ctx, &centralRPC.GetRouteRequest{GameId: 1001, Join: true, UserId: "user-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_MAINTENANCE, resp.Code)
+	assert.Equal(t, "upgrading", resp.Maintenance.GetMessage())
+	assert.Equal(t, int64(1900000000), resp.Maintenance.GetEndAt())
+	assert.Empty(t, resp.Maintenance.GetWhitelist())
+	assert.Empty(t, resp.TargetEndpoint)
+
+	// 白名單使用者與已在遊戲中的轉發不受限制
+	mockRegistry.EXPECT().SelectServiceByGame(ctx, int32(1001), domain.VersionSelector{}).Return(&ports.Route{Endpoint: "localhost:8081", ServiceType: proto.ServiceType_STATELESS}, nil).Times(2)
+	resp, err = h.GetRoute(ctx, &centralRPC.GetRouteRequest{GameId: 1001, Join: true, UserId: "tester"})
+	assert.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+	resp, err = h.GetRoute(ctx, &centralRPC.GetRouteRequest{GameId: 1001})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:8081", resp.TargetEndpoint)
+}
+
+func TestGRPCHandler_GetRoute_Version(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	h := NewGRPCHandler(service.NewCentralService(nil, nil, mockRegistry, logger, service.WithRouteRules([]domain.RouteRule{
+		{GameID: 1001, Version: "v2", MinClientVersion: "1.4"},
+	})))
+	ctx := context.Background()
+
+	// 命中規則: 回傳目標實例的版本
+	mockRegistry.EXPECT().SelectServiceByGame(ctx, int32(1001), domain.VersionSelector{Version: "v2"}).
+		Return(&ports.Route{Endpoint: "10.0.0.2:8090", ServiceType: proto.ServiceType_STATEFUL, Version: "v2"}, nil)
+	resp, err := h.GetRoute(ctx, &centralRPC.GetRouteRequest{GameId: 1001, Join: true, UserId: "user-1", ClientVersion: "1.4.0"})
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2:8090", resp.TargetEndpoint)
+	assert.Equal(t, "v2", resp.Version)
+
+	// 已分配版本的會話沿用同一版本
+	mockRegistry.EXPECT().SelectServiceByGame(ctx, int32(1001), domain.VersionSelector{Version: "v1", Pinned: true}).
+		Return(&ports.Route{Endpoint: "10.0.0.1:8090", ServiceType: proto.ServiceType_STATEFUL, Version: "v1"}, nil)
+	resp, err = h.GetRoute(ctx, &centralRPC.GetRouteRequest{GameId: 1001, UserId: "user-1", ClientVersion: "1.4.0", Version: "v1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", resp.Version)
+
+	// 沒有可用實例
+	mockRegistry.EXPECT().SelectServiceByGame(ctx, int32(1001), domain.VersionSelector{Exclude: []string{"v2"}}).Return(&ports.Route{}, nil)
+	_, err = h.GetRoute(ctx, &centralRPC.GetRouteRequest{GameId: 1001})
+	assert.Error(t, err)
+}
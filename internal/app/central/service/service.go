@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
 
-	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
@@ -18,18 +22,136 @@ type CentralService struct {
 	walletSvc ports.WalletService
 	registry  ports.RegistryService
 	logger    *slog.Logger
-	counterID int
+
+	// Auth
+	verifier   ports.TokenVerifier // Token 驗證器 (nil 代表未啟用 JWT 驗證)
+	guestLogin bool                // 是否允許訪客自動註冊
+
+	// Presence / Push (nil 代表未啟用)
+	presence ports.PresenceStore
+	pushBus  ports.PushBus
+
+	// 維護排程 (nil 代表未啟用)
+	maintenance ports.MaintenanceStore
+
+	// 版本路由規則 (依序比對，第一條命中的規則決定目標版本)
+	routeRules []domain.RouteRule
+
+	// 同時在線會話數限制 (0 代表不限制，需啟用 WithPresence)
+	maxSessions   int
+	sessionPolicy SessionPolicy
+	kicker        ports.SessionKicker
+}
+
+// SessionPolicy 定義使用者在線會話數已達上限時，新登入的處理方式
+type SessionPolicy string
+
+const (
+	// SessionPolicyKickOld 踢除最早登入的會話，允許新登入 (預設)
+	SessionPolicyKickOld SessionPolicy = "kick_old"
+	// SessionPolicyRejectNew 拒絕新登入 (回傳 SESSION_LIMIT)
+	SessionPolicyRejectNew SessionPolicy = "reject_new"
+)
+
+// kickReasonDuplicateLogin 因重複登入被踢除時的原因
+const kickReasonDuplicateLogin = "Logged in from another session"
+
+var (
+	// ErrPresenceDisabled 未設定在線狀態登錄
+	ErrPresenceDisabled = errors.New("central: presence is not enabled")
+	// ErrPushDisabled 未設定推播匯流排
+	ErrPushDisabled = errors.New("central: push is not enabled")
+)
+
+// MaintenanceError 使用者受維護排程限制 (errors.Is(err, ports.ErrMaintenance) 成立)
+// 攜帶生效中的維護排程，供回應給 Connector 顯示維護公告
+type MaintenanceError struct {
+	Maintenance *domain.Maintenance
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.Maintenance.Global() {
+		return "server is under maintenance"
+	}
+	return fmt.Sprintf("game %d is under maintenance", e.Maintenance.GameID)
+}
+
+func (e *MaintenanceError) Unwrap() error {
+	return ports.ErrMaintenance
+}
+
+// Option 定義了 CentralService 的配置選項函數
+type Option func(*CentralService)
+
+// WithTokenVerifier 設定登入 Token 驗證器
+func WithTokenVerifier(verifier ports.TokenVerifier) Option {
+	return func(s *CentralService) {
+		s.verifier = verifier
+	}
+}
+
+// WithGuestLogin 啟用訪客登入 (開發/測試用)
+// 啟用後，無法被 TokenVerifier 解析的 Token 會被視為訪客 Token 並自動註冊。
+// 已簽發但驗證失敗 (過期、簽章錯誤) 的 Token 仍會被拒絕。
+func WithGuestLogin(enabled bool) Option {
+	return func(s *CentralService) {
+		s.guestLogin = enabled
+	}
+}
+
+// WithPresence 啟用在線狀態查詢 (GetPresence / ListOnline)
+func WithPresence(store ports.PresenceStore) Option {
+	return func(s *CentralService) {
+		s.presence = store
+	}
+}
+
+// WithPush 啟用叢集推播 (PushToUser / BroadcastAll)，bus 負責發布訊息給所有 Connector
+// PushToUser 需同時啟用 WithPresence 以查詢使用者的在線會話
+func WithPush(bus ports.PushBus) Option {
+	return func(s *CentralService) {
+		s.pushBus = bus
+	}
+}
+
+// WithMaintenance 啟用維護排程檢查
+// 全服維護期間拒絕登入，遊戲維護期間拒絕加入該遊戲 (白名單使用者除外)
+func WithMaintenance(store ports.MaintenanceStore) Option {
+	return func(s *CentralService) {
+		s.maintenance = store
+	}
+}
+
+// WithRouteRules 設定版本路由規則 (Canary / Blue-Green)
+// 依序比對，第一條命中的規則決定目標版本；未命中任何規則的使用者不會被分配到規則中的版本
+func WithRouteRules(rules []domain.RouteRule) Option {
+	return func(s *CentralService) {
+		s.routeRules = rules
+	}
+}
+
+// WithSessionLimit 限制每位使用者同時在線的會話數 (需同時啟用 WithPresence)
+// 達到上限時依 policy 踢除最早登入的會話 (經由 kicker 呼叫 ConnectorRPC.Kick) 或拒絕新登入
+func WithSessionLimit(maxPerUser int, policy SessionPolicy, kicker ports.SessionKicker) Option {
+	return func(s *CentralService) {
+		s.maxSessions = maxPerUser
+		s.sessionPolicy = policy
+		s.kicker = kicker
+	}
 }
 
 // NewCentralService 建立 Central Service
-func NewCentralService(userRepo ports.UserService, walletSvc ports.WalletService, registry ports.RegistryService, logger *slog.Logger) *CentralService {
-	return &CentralService{
+func NewCentralService(userRepo ports.UserService, walletSvc ports.WalletService, registry ports.RegistryService, logger *slog.Logger, opts ...Option) *CentralService {
+	s := &CentralService{
 		userSvc:   userRepo,
 		walletSvc: walletSvc,
 		registry:  registry,
 		logger:    logger,
-		counterID: 100000,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // ---------------------------------------------------------
@@ -48,8 +170,90 @@ func (s *CentralService) DeregisterService(ctx context.Context, leaseID string)
 	return s.registry.Deregister(ctx, leaseID)
 }
 
-func (s *CentralService) GetGameServerEndpoint(ctx context.Context, gameID int32) (string, proto.ServiceType, error) {
-	return s.registry.SelectServiceByGame(ctx, gameID)
+// SetDraining 標記租約是否排空中 (排空中的實例不再被分配新玩家)
+func (s *CentralService) SetDraining(ctx context.Context, leaseID string, draining bool) error {
+	return s.registry.SetDraining(ctx, leaseID, draining)
+}
+
+// GetGameServerEndpoint 依遊戲與版本路由規則挑選服務實例 (不檢查維護狀態，新加入遊戲前需先呼叫 CheckMaintenance)
+// 沒有可用實例時回傳的 Route.Endpoint 為空字串
+func (s *CentralService) GetGameServerEndpoint(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error) {
+	return s.registry.SelectServiceByGame(ctx, req.GameID, s.versionSelector(req))
+}
+
+// versionSelector 決定路由的目標版本
+// 已分配版本的會話沿用同一版本；否則以第一條命中的規則決定版本，未命中任何規則時排除該遊戲規則中的版本
+func (s *CentralService) versionSelector(req *domain.RouteRequest) domain.VersionSelector {
+	if req.Version != "" {
+		return domain.VersionSelector{Version: req.Version, Pinned: true}
+	}
+
+	var exclude []string
+	for i := range s.routeRules {
+		rule := &s.routeRules[i]
+		if !rule.Covers(req.GameID) {
+			continue
+		}
+		if rule.Match(req) {
+			return domain.VersionSelector{Version: rule.Version}
+		}
+		exclude = append(exclude, rule.Version)
+	}
+	return domain.VersionSelector{Exclude: exclude}
+}
+
+// ---------------------------------------------------------
+// Maintenance Logic
+// ---------------------------------------------------------
+
+// CheckMaintenance 檢查使用者是否受生效中的維護排程限制
+// gameID 為 0 時僅檢查全服維護，否則同時檢查全服與該遊戲的維護
+// 受限制時回傳 *MaintenanceError；無法查詢維護狀態時放行 (避免 Redis 異常導致所有玩家無法遊玩)
+func (s *CentralService) CheckMaintenance(ctx context.Context, userID string, gameID int32) error {
+	if s.maintenance == nil {
+		return nil
+	}
+
+	gameIDs := []int32{domain.GlobalMaintenanceGameID}
+	if gameID != domain.GlobalMaintenanceGameID {
+		gameIDs = append(gameIDs, gameID)
+	}
+	now := time.Now()
+	for _, id := range gameIDs {
+		m, err := s.maintenance.Get(ctx, id)
+		if err != nil {
+			s.logger.Warn("Failed to get maintenance status", "game_id", id, "error", err)
+			continue
+		}
+		if m == nil || !m.Active(now) {
+			continue
+		}
+		if m.Allows(userID) {
+			s.logger.Info("Whitelisted user bypasses maintenance", "user_id", userID, "game_id", id)
+			continue
+		}
+		return &MaintenanceError{Maintenance: m}
+	}
+	return nil
+}
+
+// ListMaintenance 列出尚未結束的維護排程 (未啟用時回傳空列表)
+func (s *CentralService) ListMaintenance(ctx context.Context) ([]*domain.Maintenance, error) {
+	if s.maintenance == nil {
+		return nil, nil
+	}
+	list, err := s.maintenance.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	schedules := make([]*domain.Maintenance, 0, len(list))
+	for _, m := range list {
+		if !m.Ended(now) {
+			schedules = append(schedules, m)
+		}
+	}
+	return schedules, nil
 }
 
 // ---------------------------------------------------------
@@ -57,34 +261,33 @@ func (s *CentralService) GetGameServerEndpoint(ctx context.Context, gameID int32
 // ---------------------------------------------------------
 
 // Login 處理玩家登入邏輯
-// 回傳 User 實體與可能發生的錯誤
-func (s *CentralService) Login(ctx context.Context, token string) (*domain.User, error) {
-	// 1. 驗證 Token (這裡暫時模擬，實際應呼叫 Auth Service 或 JWT verify)
+// sessionID 與 connectorHost 為新會話的 ID 與所在的 Connector，用於同時在線會話數限制 (可為空)
+// 回傳 User 實體與可能發生的錯誤 (驗證失敗可用 errors.Is(err, ports.ErrInvalidToken) 判斷，全服維護中為 *MaintenanceError)
+func (s *CentralService) Login(ctx context.Context, token, sessionID, connectorHost string) (*domain.User, error) {
+	// 1. 驗證 Token
 	if token == "" {
 		return nil, domain.ErrInvalidToken
 	}
-	var user *domain.User
-	// 2. 查找使用者
-	user, err := s.userSvc.GetUser(ctx, token)
+
+	// 2. 驗證身分並查找使用者
+	user, err := s.authenticate(ctx, token)
 	if err != nil {
-		// 3. 如果使用者不存在，自動註冊 (Auto-Register)
-		if err == ports.ErrUserNotFound {
-			// 3.1. 建立使用者
-			userID := fmt.Sprintf("%d", s.counterID)
-			userName := fmt.Sprintf("guest-%d", s.counterID)
-			s.counterID++
-			user = domain.NewUser(userID, userName)
-			// 3.2. 建立使用者
-			err = s.userSvc.CreateGuestUser(ctx, token, user)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			return nil, err
-		}
+		return nil, err
+	}
+
+	// 3. 檢查全服維護 (白名單使用者除外)
+	if err := s.CheckMaintenance(ctx, user.ID, domain.GlobalMaintenanceGameID); err != nil {
+		s.logger.Info("Login rejected: under maintenance", "user_id", user.ID)
+		return nil, err
+	}
+
+	// 4. 檢查同時在線會話數並保留名額
+	reserved, err := s.admitSession(ctx, user.ID, sessionID, connectorHost)
+	if err != nil {
+		return nil, err
 	}
 
-	// 4. 更新餘額快照 (Wallet Service -> User Entity)
+	// 5. 更新餘額快照 (Wallet Service -> User Entity)
 	// 這是一個 "Anti-Corruption Layer" 的行為，將 Wallet 的狀態同步到 User Cache
 	balance, err := s.walletSvc.GetBalance(ctx, user.ID)
 	if err == nil {
@@ -93,7 +296,207 @@ func (s *CentralService) Login(ctx context.Context, token string) (*domain.User,
 		s.logger.Warn("Failed to fetch balance", "user_id", user.ID, "error", err)
 	}
 
-	// 5. 登入成功
+	// Connector 已放棄此次登入 (逾時或斷線)，釋放保留的名額
+	if err := ctx.Err(); err != nil {
+		if reserved {
+			s.releaseSession(user.ID, sessionID)
+		}
+		return nil, err
+	}
+
+	// 6. 登入成功
 	s.logger.Info("User logged in", "user_id", user.ID, "balance", user.Balance)
 	return user, nil
 }
+
+// admitSession 檢查使用者的在線會話數是否已達上限，未達上限時於 PresenceStore 保留新會話的名額
+// 計數與保留為原子操作 (PresenceStore.Reserve)，多個 Central 同時處理同一使用者的登入也不會超過上限
+// kick_old 會踢除最早登入的會話以騰出名額後重新保留，reject_new 則回傳 ErrSessionLimitExceeded
+// 無法查詢在線狀態時放行登入 (避免 Redis 異常導致所有玩家無法登入)
+// 回傳是否已保留名額
+func (s *CentralService) admitSession(ctx context.Context, userID, sessionID, connectorHost string) (bool, error) {
+	if s.maxSessions <= 0 || s.presence == nil || sessionID == "" {
+		return false, nil
+	}
+
+	presence := &domain.Presence{
+		UserID:        userID,
+		SessionID:     sessionID,
+		ConnectorHost: connectorHost,
+		Since:         time.Now().Unix(),
+	}
+	existing, err := s.presence.Reserve(ctx, presence, s.maxSessions)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, ports.ErrSessionLimitExceeded) {
+		s.logger.Warn("Failed to reserve session, skip session limit", "user_id", userID, "error", err)
+		return false, nil
+	}
+
+	if s.sessionPolicy == SessionPolicyRejectNew || s.kicker == nil {
+		s.logger.Info("Login rejected: session limit exceeded", "user_id", userID, "sessions", len(existing), "limit", s.maxSessions)
+		return false, ports.ErrSessionLimitExceeded
+	}
+
+	// 踢除最早登入的會話，並立即移除其在線狀態以騰出名額 (不等待 Connector 斷線後移除)
+	excess := len(existing) - s.maxSessions + 1
+	sort.Slice(existing, func(i, j int) bool { return existing[i].Since < existing[j].Since })
+	for _, p := range existing[:max(excess, 0)] {
+		if err := s.kicker.Kick(ctx, p.ConnectorHost, p.SessionID, kickReasonDuplicateLogin); err != nil {
+			// Connector 可能已失效，仍移除殘留的在線狀態 (會話若仍存活，下次 Refresh 會重新寫入)
+			s.logger.Warn("Failed to kick previous session", "user_id", userID, "session_id", p.SessionID, "connector", p.ConnectorHost, "error", err)
+		} else {
+			s.logger.Info("Previous session kicked (duplicate login)", "user_id", userID, "session_id", p.SessionID, "connector", p.ConnectorHost)
+		}
+		if err := s.presence.Remove(ctx, userID, p.SessionID); err != nil {
+			s.logger.Warn("Failed to remove kicked presence", "user_id", userID, "session_id", p.SessionID, "error", err)
+		}
+	}
+
+	// 重新保留: 期間若有其他登入搶先取得名額，則拒絕此次登入
+	if _, err := s.presence.Reserve(ctx, presence, s.maxSessions); err != nil {
+		if errors.Is(err, ports.ErrSessionLimitExceeded) {
+			s.logger.Info("Login rejected: session limit exceeded after kick", "user_id", userID, "limit", s.maxSessions)
+			return false, err
+		}
+		s.logger.Warn("Failed to reserve session, skip session limit", "user_id", userID, "error", err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// releaseSession 釋放登入時保留的名額 (登入未完成時呼叫)
+func (s *CentralService) releaseSession(userID, sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.presence.Remove(ctx, userID, sessionID); err != nil {
+		s.logger.Warn("Failed to release reserved session", "user_id", userID, "session_id", sessionID, "error", err)
+	}
+}
+
+// authenticate 依設定的驗證方式取得使用者
+func (s *CentralService) authenticate(ctx context.Context, token string) (*domain.User, error) {
+	if s.verifier != nil {
+		identity, err := s.verifier.Verify(ctx, token)
+		if err == nil {
+			return s.resolveUser(ctx, identity)
+		}
+		// 訪客模式下，非 JWT 格式的 Token 改走訪客流程
+		if !s.guestLogin || !errors.Is(err, ports.ErrTokenMalformed) {
+			s.logger.Info("Token verification failed", "error", err)
+			return nil, err
+		}
+	}
+
+	if !s.guestLogin {
+		return nil, domain.ErrInvalidToken
+	}
+	return s.guestUser(ctx, token)
+}
+
+// resolveUser 以 Token 內的身分查找使用者資料
+// 首次登入 (使用者資料尚未建立) 時以 Claims 中的身分資訊建立，遊戲服務才能依 ID 查詢
+func (s *CentralService) resolveUser(ctx context.Context, identity *domain.User) (*domain.User, error) {
+	user, err := s.userSvc.GetUserByID(ctx, identity.ID)
+	if err != nil {
+		if !errors.Is(err, ports.ErrUserNotFound) {
+			return nil, err
+		}
+		if err := s.userSvc.EnsureUser(ctx, identity); err != nil {
+			s.logger.Error("Failed to create user from token", "user_id", identity.ID, "error", err)
+			return nil, err
+		}
+		return identity, nil
+	}
+	if user.Name == "" {
+		user.Name = identity.Name
+	}
+	return user, nil
+}
+
+// guestUser 訪客登入: 以 Token 查找使用者，不存在則自動註冊 (Auto-Register)
+func (s *CentralService) guestUser(ctx context.Context, token string) (*domain.User, error) {
+	user, err := s.userSvc.GetUser(ctx, token)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, ports.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// 建立訪客使用者
+	// 使用者資料可能持久化 (MySQL)，ID 不可因 Central 重啟而重複
+	userID := uuid.NewString()
+	userName := "guest-" + userID[:8]
+	user = domain.NewUser(userID, userName)
+	if err := s.userSvc.CreateGuestUser(ctx, token, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ---------------------------------------------------------
+// Push Logic
+// ---------------------------------------------------------
+
+// PushToUser 推播訊息給使用者的所有在線會話 (gameID 不為 0 時僅限在該遊戲中的會話)
+// 回傳符合條件的會話數；使用者不在線回傳 ports.ErrUserOffline
+func (s *CentralService) PushToUser(ctx context.Context, userID string, payload []byte, gameID int32) (int, error) {
+	if s.pushBus == nil {
+		return 0, ErrPushDisabled
+	}
+
+	sessions, err := s.GetPresence(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	msg := &domain.PushMessage{UserID: userID, GameID: gameID, Payload: payload}
+	count := 0
+	for _, sess := range sessions {
+		if msg.Match(sess.ConnectorHost, sess.GameID) {
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, ports.ErrUserOffline
+	}
+
+	if err := s.pushBus.Publish(ctx, msg); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// BroadcastAll 全服廣播 (gameID / connectorHost 為可選的篩選條件)
+func (s *CentralService) BroadcastAll(ctx context.Context, payload []byte, gameID int32, connectorHost string) error {
+	if s.pushBus == nil {
+		return ErrPushDisabled
+	}
+	s.logger.Info("Broadcast to all", "game_id", gameID, "connector", connectorHost, "size", len(payload))
+	return s.pushBus.Publish(ctx, &domain.PushMessage{
+		GameID:        gameID,
+		ConnectorHost: connectorHost,
+		Payload:       payload,
+	})
+}
+
+// ---------------------------------------------------------
+// Presence Logic
+// ---------------------------------------------------------
+
+// GetPresence 查詢使用者所有在線會話 (不在線時回傳空列表)
+func (s *CentralService) GetPresence(ctx context.Context, userID string) ([]*domain.Presence, error) {
+	if s.presence == nil {
+		return nil, ErrPresenceDisabled
+	}
+	return s.presence.ListByUser(ctx, userID)
+}
+
+// ListOnline 列出在線會話 (gameID 為 0 代表所有在線會話)
+func (s *CentralService) ListOnline(ctx context.Context, gameID int32) ([]*domain.Presence, error) {
+	if s.presence == nil {
+		return nil, ErrPresenceDisabled
+	}
+	return s.presence.ListByGame(ctx, gameID)
+}
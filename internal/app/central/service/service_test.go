@@ -5,16 +5,26 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
+	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/shopspring/decimal"
 
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	"github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/auth/jwt"
+	mockwallet "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/mock"
 	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
 )
 
 func TestCentralService_Login_Success(t *testing.T) {
@@ -26,7 +36,7 @@ func TestCentralService_Login_Success(t *testing.T) {
 	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	svc := NewCentralService(mockUserSvc, mockWalletSvc, mockRegistry, logger)
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, mockRegistry, logger, WithGuestLogin(true))
 
 	ctx := context.Background()
 	token := "valid-token"
@@ -43,7 +53,7 @@ func TestCentralService_Login_Success(t *testing.T) {
 	// Mock WalletSvc.GetBalance -> Return balance
 	mockWalletSvc.EXPECT().GetBalance(ctx, userID).Return(decimal.NewFromInt(1000), nil)
 
-	user, err := svc.Login(ctx, token)
+	user, err := svc.Login(ctx, token, "", "")
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, userID, user.ID)
@@ -60,7 +70,7 @@ func TestCentralService_Login_InvalidToken(t *testing.T) {
 
 	svc := NewCentralService(mockUserSvc, nil, nil, logger)
 
-	_, err := svc.Login(context.Background(), "")
+	_, err := svc.Login(context.Background(), "", "", "")
 	assert.ErrorIs(t, err, domain.ErrInvalidToken)
 }
 
@@ -72,7 +82,7 @@ func TestCentralService_Login_AutoRegister_Success(t *testing.T) {
 	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger)
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger, WithGuestLogin(true))
 	ctx := context.Background()
 	token := "new-user-token"
 
@@ -90,7 +100,7 @@ func TestCentralService_Login_AutoRegister_Success(t *testing.T) {
 	// 3. GetBalance (called after registration) - assuming new user has 0 balance or whatever mocked
 	mockWalletSvc.EXPECT().GetBalance(ctx, gomock.Any()).Return(decimal.Zero, nil)
 
-	user, err := svc.Login(ctx, token)
+	user, err := svc.Login(ctx, token, "", "")
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 }
@@ -102,13 +112,429 @@ func TestCentralService_Login_RepoError(t *testing.T) {
 	mockUserSvc := mock_ports.NewMockUserService(ctrl)
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
-	svc := NewCentralService(mockUserSvc, nil, nil, logger)
+	svc := NewCentralService(mockUserSvc, nil, nil, logger, WithGuestLogin(true))
 	ctx := context.Background()
 	token := "error-token"
 
 	expectedErr := errors.New("db error")
 	mockUserSvc.EXPECT().GetUser(ctx, token).Return(nil, expectedErr)
 
-	_, err := svc.Login(ctx, token)
+	_, err := svc.Login(ctx, token, "", "")
 	assert.ErrorIs(t, err, expectedErr)
 }
+
+func TestCentralService_Login_GuestDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	// No verifier and guest login disabled: every token is rejected without touching UserService
+	svc := NewCentralService(mockUserSvc, nil, nil, logger)
+
+	_, err := svc.Login(context.Background(), "any-token", "", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+}
+
+func TestCentralService_Login_Verifier_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockVerifier := mock_ports.NewMockTokenVerifier(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger, WithTokenVerifier(mockVerifier))
+	ctx := context.Background()
+	token := "header.payload.signature"
+
+	mockVerifier.EXPECT().Verify(ctx, token).Return(domain.NewUser("user-123", "Alice"), nil)
+	// First login: no stored profile yet, identity comes from claims and is saved
+	mockUserSvc.EXPECT().GetUserByID(ctx, "user-123").Return(nil, ports.ErrUserNotFound)
+	mockUserSvc.EXPECT().EnsureUser(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.User) error {
+		assert.Equal(t, "user-123", u.ID)
+		assert.Equal(t, "Alice", u.Name)
+		return nil
+	})
+	mockWalletSvc.EXPECT().GetBalance(ctx, "user-123").Return(decimal.NewFromInt(500), nil)
+
+	user, err := svc.Login(ctx, token, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-123", user.ID)
+	assert.Equal(t, "Alice", user.Name)
+	assert.Equal(t, decimal.NewFromInt(500), user.Balance)
+}
+
+// memUserStore 以記憶體實作 ports.UserService，讓 Central 與遊戲服務共用同一份使用者資料
+type memUserStore struct {
+	mu    sync.Mutex
+	users map[string]*domain.User
+}
+
+func (m *memUserStore) GetUser(context.Context, string) (*domain.User, error) {
+	return nil, ports.ErrUserNotFound
+}
+
+func (m *memUserStore) CreateGuestUser(context.Context, string, *domain.User) error {
+	return errors.New("not supported")
+}
+
+func (m *memUserStore) GetUserByID(_ context.Context, id string) (*domain.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	user, ok := m.users[id]
+	if !ok {
+		return nil, ports.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (m *memUserStore) EnsureUser(_ context.Context, user *domain.User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.users[user.ID]; !ok {
+		copied := *user
+		m.users[user.ID] = &copied
+	}
+	return nil
+}
+
+// TestCentralService_Login_Verifier_FirstLoginJoin JWT 使用者首次登入後可以進入遊戲
+func TestCentralService_Login_Verifier_FirstLoginJoin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret := []byte("test-secret")
+	verifier, err := jwt.NewHS256Verifier(secret)
+	require.NoError(t, err)
+	token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, gojwt.MapClaims{
+		"sub":  "user-new",
+		"name": "Alice",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString(secret)
+	require.NoError(t, err)
+
+	users := &memUserStore{users: make(map[string]*domain.User)}
+	wallet := mockwallet.NewMockWallet()
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewCentralService(users, wallet, nil, logger, WithTokenVerifier(verifier))
+	ctx := context.Background()
+
+	user, err := svc.Login(ctx, token, "sess-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "user-new", user.ID)
+
+	mockHandler := mock_engine.NewMockGameHandler(ctrl)
+	mockHandler.EXPECT().OnJoin(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, peer *engine.Peer) error {
+		assert.Equal(t, "Alice", peer.User.Name)
+		return nil
+	})
+	server := engine.NewServer(mockHandler, nil, false, "test-service", users, wallet)
+
+	resp, err := server.OnPlayerJoin(ctx, &gameRPC.JoinReq{
+		Header:        &proto.PacketHeader{UserId: user.ID, SessionId: "sess-1"},
+		ConnectorHost: "connector-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+
+	// 再次登入沿用已建立的資料
+	user, err = svc.Login(ctx, token, "sess-2", "")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", user.Name)
+}
+
+func TestCentralService_Login_Verifier_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockVerifier := mock_ports.NewMockTokenVerifier(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	// Guest login must not accept a signed token that failed verification
+	svc := NewCentralService(mockUserSvc, nil, nil, logger, WithTokenVerifier(mockVerifier), WithGuestLogin(true))
+	ctx := context.Background()
+
+	mockVerifier.EXPECT().Verify(ctx, "expired-jwt").Return(nil, ports.ErrTokenExpired)
+
+	_, err := svc.Login(ctx, "expired-jwt", "", "")
+	assert.ErrorIs(t, err, ports.ErrTokenExpired)
+	assert.ErrorIs(t, err, ports.ErrInvalidToken)
+}
+
+func TestCentralService_Login_Verifier_GuestFallback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockVerifier := mock_ports.NewMockTokenVerifier(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger, WithTokenVerifier(mockVerifier), WithGuestLogin(true))
+	ctx := context.Background()
+	token := "guest-token"
+
+	// Not a JWT: falls back to the guest flow
+	mockVerifier.EXPECT().Verify(ctx, token).Return(nil, ports.ErrTokenMalformed)
+	expectedUser := domain.NewUser("100000", "guest-100000")
+	mockUserSvc.EXPECT().GetUser(ctx, token).Return(expectedUser, nil)
+	mockWalletSvc.EXPECT().GetBalance(ctx, "100000").Return(decimal.Zero, nil)
+
+	user, err := svc.Login(ctx, token, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "100000", user.ID)
+}
+
+func TestCentralService_PushToUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBus := mock_ports.NewMockPushBus(ctrl)
+	mockPresence := mock_ports.NewMockPresenceStore(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewCentralService(nil, nil, nil, logger, WithPresence(mockPresence), WithPush(mockBus))
+	ctx := context.Background()
+
+	mockPresence.EXPECT().ListByUser(ctx, "user-1").Return([]*domain.Presence{
+		{SessionID: "s1", UserID: "user-1", ConnectorHost: "c1", GameID: 1001},
+		{SessionID: "s2", UserID: "user-1", ConnectorHost: "c2"},
+	}, nil).Times(2)
+
+	// 符合條件的會話才計入，並發布到使用者頻道
+	mockBus.EXPECT().Publish(ctx, &domain.PushMessage{UserID: "user-1", GameID: 1001, Payload: []byte("hi")}).Return(nil)
+	count, err := svc.PushToUser(ctx, "user-1", []byte("hi"), 1001)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// 沒有符合條件的會話，不發布
+	_, err = svc.PushToUser(ctx, "user-1", []byte("hi"), 2002)
+	assert.ErrorIs(t, err, ports.ErrUserOffline)
+
+	// 未啟用推播
+	_, err = NewCentralService(nil, nil, nil, logger).PushToUser(ctx, "user-1", []byte("hi"), 0)
+	assert.ErrorIs(t, err, ErrPushDisabled)
+}
+
+func TestCentralService_Presence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPresence := mock_ports.NewMockPresenceStore(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewCentralService(nil, nil, nil, logger, WithPresence(mockPresence))
+	ctx := context.Background()
+
+	sessions := []*domain.Presence{
+		{SessionID: "s1", UserID: "user-1", ConnectorHost: "c1", GameID: 1001},
+	}
+	mockPresence.EXPECT().ListByUser(ctx, "user-1").Return(sessions, nil)
+	got, err := svc.GetPresence(ctx, "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, sessions, got)
+
+	mockPresence.EXPECT().ListByGame(ctx, int32(1001)).Return(sessions, nil)
+	got, err = svc.ListOnline(ctx, 1001)
+	assert.NoError(t, err)
+	assert.Equal(t, sessions, got)
+
+	// 未啟用在線狀態
+	_, err = NewCentralService(nil, nil, nil, logger).GetPresence(ctx, "user-1")
+	assert.ErrorIs(t, err, ErrPresenceDisabled)
+	_, err = NewCentralService(nil, nil, nil, logger).ListOnline(ctx, 0)
+	assert.ErrorIs(t, err, ErrPresenceDisabled)
+
+	// 啟用推播但未啟用在線狀態時無法推播給使用者
+	_, err = NewCentralService(nil, nil, nil, logger, WithPush(mock_ports.NewMockPushBus(ctrl))).PushToUser(ctx, "user-1", []byte("hi"), 0)
+	assert.ErrorIs(t, err, ErrPresenceDisabled)
+}
+
+func TestCentralService_Login_SessionLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockPresence := mock_ports.NewMockPresenceStore(ctrl)
+	mockKicker := mock_ports.NewMockSessionKicker(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+
+	user := &domain.User{ID: "user-1"}
+	mockUserSvc.EXPECT().GetUser(ctx, "token").Return(user, nil).AnyTimes()
+	mockWalletSvc.EXPECT().GetBalance(ctx, "user-1").Return(decimal.NewFromInt(100), nil).AnyTimes()
+	existing := func() []*domain.Presence {
+		return []*domain.Presence{
+			{SessionID: "s-2", UserID: "user-1", ConnectorHost: "c2", Since: 200},
+			{SessionID: "s-1", UserID: "user-1", ConnectorHost: "c1", Since: 100},
+		}
+	}
+	isNew := gomock.Cond(func(p *domain.Presence) bool {
+		return p.UserID == "user-1" && p.SessionID == "s-new" && p.ConnectorHost == "c1"
+	})
+
+	// 未達上限: 保留名額後放行
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger,
+		WithGuestLogin(true), WithPresence(mockPresence), WithSessionLimit(3, SessionPolicyRejectNew, mockKicker))
+	mockPresence.EXPECT().Reserve(ctx, isNew, 3).Return(nil, nil)
+	_, err := svc.Login(ctx, "token", "s-new", "c1")
+	assert.NoError(t, err)
+
+	// kick_old: 上限 2，踢除最早登入的會話並移除其在線狀態後重新保留
+	svc = NewCentralService(mockUserSvc, mockWalletSvc, nil, logger,
+		WithGuestLogin(true), WithPresence(mockPresence), WithSessionLimit(2, SessionPolicyKickOld, mockKicker))
+	gomock.InOrder(
+		mockPresence.EXPECT().Reserve(ctx, isNew, 2).Return(existing(), ports.ErrSessionLimitExceeded),
+		mockKicker.EXPECT().Kick(ctx, "c1", "s-1", gomock.Any()).Return(nil),
+		mockPresence.EXPECT().Remove(ctx, "user-1", "s-1").Return(nil),
+		mockPresence.EXPECT().Reserve(ctx, isNew, 2).Return(nil, nil),
+	)
+	_, err = svc.Login(ctx, "token", "s-new", "c1")
+	assert.NoError(t, err)
+
+	// kick_old: 踢除失敗時同樣移除殘留的在線狀態
+	svc = NewCentralService(mockUserSvc, mockWalletSvc, nil, logger,
+		WithGuestLogin(true), WithPresence(mockPresence), WithSessionLimit(1, SessionPolicyKickOld, mockKicker))
+	mockPresence.EXPECT().Reserve(ctx, isNew, 1).Return(existing(), ports.ErrSessionLimitExceeded)
+	mockKicker.EXPECT().Kick(ctx, "c1", "s-1", gomock.Any()).Return(nil)
+	mockKicker.EXPECT().Kick(ctx, "c2", "s-2", gomock.Any()).Return(errors.New("connector down"))
+	mockPresence.EXPECT().Remove(ctx, "user-1", "s-1").Return(nil)
+	mockPresence.EXPECT().Remove(ctx, "user-1", "s-2").Return(nil)
+	mockPresence.EXPECT().Reserve(ctx, isNew, 1).Return(nil, nil)
+	_, err = svc.Login(ctx, "token", "s-new", "c1")
+	assert.NoError(t, err)
+
+	// kick_old: 踢除後名額已被同時進行的其他登入取得，拒絕此次登入
+	mockPresence.EXPECT().Reserve(ctx, isNew, 1).Return(existing()[1:], ports.ErrSessionLimitExceeded)
+	mockKicker.EXPECT().Kick(ctx, "c1", "s-1", gomock.Any()).Return(nil)
+	mockPresence.EXPECT().Remove(ctx, "user-1", "s-1").Return(nil)
+	mockPresence.EXPECT().Reserve(ctx, isNew, 1).Return(existing()[:1], ports.ErrSessionLimitExceeded)
+	_, err = svc.Login(ctx, "token", "s-new", "c1")
+	assert.ErrorIs(t, err, ports.ErrSessionLimitExceeded)
+
+	// reject_new: 拒絕新登入 (未保留名額)
+	svc = NewCentralService(mockUserSvc, mockWalletSvc, nil, logger,
+		WithGuestLogin(true), WithPresence(mockPresence), WithSessionLimit(2, SessionPolicyRejectNew, mockKicker))
+	mockPresence.EXPECT().Reserve(ctx, isNew, 2).Return(existing(), ports.ErrSessionLimitExceeded)
+	_, err = svc.Login(ctx, "token", "s-new", "c1")
+	assert.ErrorIs(t, err, ports.ErrSessionLimitExceeded)
+
+	// 無法存取在線狀態時放行登入
+	mockPresence.EXPECT().Reserve(ctx, isNew, 2).Return(nil, errors.New("redis down"))
+	_, err = svc.Login(ctx, "token", "s-new", "c1")
+	assert.NoError(t, err)
+}
+
+func TestCentralService_Login_ReleaseOnCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockPresence := mock_ports.NewMockPresenceStore(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger,
+		WithGuestLogin(true), WithPresence(mockPresence), WithSessionLimit(1, SessionPolicyRejectNew, nil))
+	mockUserSvc.EXPECT().GetUser(ctx, "token").Return(&domain.User{ID: "user-1"}, nil)
+	mockPresence.EXPECT().Reserve(ctx, gomock.Any(), 1).Return(nil, nil)
+	// Connector 在保留名額後放棄登入 (逾時)
+	mockWalletSvc.EXPECT().GetBalance(ctx, "user-1").DoAndReturn(func(context.Context, string) (decimal.Decimal, error) {
+		cancel()
+		return decimal.Zero, context.Canceled
+	})
+	mockPresence.EXPECT().Remove(gomock.Any(), "user-1", "s-new").Return(nil)
+
+	_, err := svc.Login(ctx, "token", "s-new", "c1")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCentralService_Login_Maintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockMaintenance := mock_ports.NewMockMaintenanceStore(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	svc := NewCentralService(mockUserSvc, mockWalletSvc, nil, logger, WithGuestLogin(true), WithMaintenance(mockMaintenance))
+	mockUserSvc.EXPECT().GetUser(ctx, "player").Return(&domain.User{ID: "user-1"}, nil).AnyTimes()
+	mockUserSvc.EXPECT().GetUser(ctx, "tester").Return(&domain.User{ID: "tester-1"}, nil).AnyTimes()
+	mockWalletSvc.EXPECT().GetBalance(ctx, gomock.Any()).Return(decimal.NewFromInt(100), nil).AnyTimes()
+
+	global := &domain.Maintenance{GameID: 0, Message: "upgrading", Whitelist: []string{"tester-1"}}
+	mockMaintenance.EXPECT().Get(ctx, int32(0)).DoAndReturn(func(context.Context, int32) (*domain.Maintenance, error) {
+		return global, nil
+	}).AnyTimes()
+
+	// 全服維護生效中: 拒絕登入並攜帶維護排程
+	_, err := svc.Login(ctx, "player", "s-1", "c1")
+	assert.ErrorIs(t, err, ports.ErrMaintenance)
+	var maintenanceErr *MaintenanceError
+	assert.ErrorAs(t, err, &maintenanceErr)
+	assert.Equal(t, "upgrading", maintenanceErr.Maintenance.Message)
+
+	// 白名單使用者可登入
+	_, err = svc.Login(ctx, "tester", "s-2", "c1")
+	assert.NoError(t, err)
+
+	// 尚未開始或已結束的排程不影響登入
+	global = &domain.Maintenance{GameID: 0, StartAt: now + 600}
+	_, err = svc.Login(ctx, "player", "s-1", "c1")
+	assert.NoError(t, err)
+	global = &domain.Maintenance{GameID: 0, StartAt: now - 600, EndAt: now - 60}
+	_, err = svc.Login(ctx, "player", "s-1", "c1")
+	assert.NoError(t, err)
+
+	// 遊戲維護不影響登入，但拒絕加入該遊戲
+	global = nil
+	mockMaintenance.EXPECT().Get(ctx, int32(1001)).Return(&domain.Maintenance{GameID: 1001}, nil).AnyTimes()
+	mockMaintenance.EXPECT().Get(ctx, int32(2002)).Return(nil, errors.New("redis down")).AnyTimes()
+	assert.ErrorIs(t, svc.CheckMaintenance(ctx, "user-1", 1001), ports.ErrMaintenance)
+	assert.NoError(t, svc.CheckMaintenance(ctx, "user-1", 2002)) // 無法查詢時放行
+}
+
+func TestCentralService_GetGameServerEndpoint_VersionRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := NewCentralService(nil, nil, mockRegistry, logger, WithRouteRules([]domain.RouteRule{
+		{GameID: 1001, Version: "v2", UserIDs: []string{"qa"}},
+		{GameID: 1001, Version: "v2", Percent: 5},
+		{Version: "v3", MinClientVersion: "1.4"},
+	}))
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		req      *domain.RouteRequest
+		selector domain.VersionSelector
+	}{
+		{"user list", &domain.RouteRequest{GameID: 1001, UserID: "qa"}, domain.VersionSelector{Version: "v2"}},
+		{"percent hit", &domain.RouteRequest{GameID: 1001, UserID: "user-1"}, domain.VersionSelector{Version: "v2"}}, // bucket 0
+		{"min client version", &domain.RouteRequest{GameID: 1001, UserID: "user-2", ClientVersion: "1.10"}, domain.VersionSelector{Version: "v3"}},
+		{"no rule matched", &domain.RouteRequest{GameID: 1001, UserID: "user-2", ClientVersion: "1.3.9"}, domain.VersionSelector{Exclude: []string{"v2", "v2", "v3"}}},
+		{"other game", &domain.RouteRequest{GameID: 2002, UserID: "user-1"}, domain.VersionSelector{Exclude: []string{"v3"}}},
+		{"sticky version", &domain.RouteRequest{GameID: 1001, UserID: "qa", Version: "v1"}, domain.VersionSelector{Version: "v1", Pinned: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := &ports.Route{Endpoint: "10.0.0.1:9000", Version: tt.selector.Version}
+			mockRegistry.EXPECT().SelectServiceByGame(ctx, tt.req.GameID, tt.selector).Return(route, nil)
+
+			got, err := svc.GetGameServerEndpoint(ctx, tt.req)
+			assert.NoError(t, err)
+			assert.Equal(t, route, got)
+		})
+	}
+}
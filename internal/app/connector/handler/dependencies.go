@@ -5,16 +5,19 @@ import (
 
 	"google.golang.org/grpc"
 
-	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 )
 
 // CentralClient 定義了與 Central Service 互動的介面
 //
 //go:generate mockgen -destination=../../../../test/mocks/handlers/mock_central_client.go -package=mock_handlers . CentralClient
 type CentralClient interface {
-	Login(ctx context.Context, token string) (*centralRPC.LoginResponse, error)
-	GetRoute(ctx context.Context, gameID int32) (string, proto.ServiceType, error)
+	Login(ctx context.Context, token, sessionID, connectorHost string) (*centralRPC.LoginResponse, error)
+	GetRoute(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error)
+	JoinRoute(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error)
+	ListMaintenance(ctx context.Context) ([]*centralRPC.MaintenanceInfo, error)
 }
 
 // GRPCPool 定義了取得 gRPC 連線的介面
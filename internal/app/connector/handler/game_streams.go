@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	game_client "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/game" // Client
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+)
+
+const (
+	// streamOpenTimeout 建立串流 (等待 Game Server Ready) 的逾時
+	streamOpenTimeout = 2 * time.Second
+	// streamRetryInterval 串流建立失敗後，在此期間內直接使用 Unary 而不重試
+	streamRetryInterval = 5 * time.Second
+)
+
+// gameStreams 管理本 Connector 與各 Game Server 之間的雙向串流 (每個 Endpoint 一條)
+// 串流於第一次轉發訊息時建立，中斷後於下一次轉發時重建
+type gameStreams struct {
+	pool       GRPCPool
+	host       string // 本 Connector 的 gRPC Endpoint
+	sessionMgr *session.Manager
+
+	mu       sync.Mutex
+	streams  map[string]*game_client.Stream
+	failedAt map[string]time.Time
+	opening  map[string]struct{} // 正在建立串流的 Endpoint (每個 Endpoint 同時只有一個建立中)
+	closed   bool
+}
+
+func newGameStreams(pool GRPCPool, host string, mgr *session.Manager) *gameStreams {
+	return &gameStreams{
+		pool:       pool,
+		host:       host,
+		sessionMgr: mgr,
+		streams:    make(map[string]*game_client.Stream),
+		failedAt:   make(map[string]time.Time),
+		opening:    make(map[string]struct{}),
+	}
+}
+
+// get 取得 endpoint 的串流，不可用時回傳 nil (呼叫端退回 Unary)
+// 建立串流 (最久 streamOpenTimeout) 時不持有鎖，其他 Endpoint 的轉發不受影響；
+// 同一 Endpoint 建立中時，其他呼叫直接退回 Unary 而不等待
+func (g *gameStreams) get(endpoint string) *game_client.Stream {
+	g.mu.Lock()
+	if st, ok := g.streams[endpoint]; ok {
+		select {
+		case <-st.Done():
+			delete(g.streams, endpoint)
+		default:
+			g.mu.Unlock()
+			return st
+		}
+	}
+	if t, ok := g.failedAt[endpoint]; ok && time.Since(t) < streamRetryInterval {
+		g.mu.Unlock()
+		return nil
+	}
+	if _, ok := g.opening[endpoint]; ok || g.closed {
+		g.mu.Unlock()
+		return nil
+	}
+	g.opening[endpoint] = struct{}{}
+	g.mu.Unlock()
+
+	st, err := g.open(endpoint)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.opening, endpoint)
+	if err != nil {
+		slog.Warn("Failed to open game stream, falling back to unary", "endpoint", endpoint, "error", err)
+		g.failedAt[endpoint] = time.Now()
+		return nil
+	}
+	if g.closed {
+		// 建立期間已呼叫 closeAll
+		st.Close()
+		return nil
+	}
+
+	delete(g.failedAt, endpoint)
+	g.streams[endpoint] = st
+	slog.Info("Game stream opened", "endpoint", endpoint)
+	return st
+}
+
+// open 建立與 endpoint 的串流 (不持有 g.mu)
+func (g *gameStreams) open(endpoint string) (*game_client.Stream, error) {
+	rpcConn, err := g.pool.GetConnection(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), streamOpenTimeout)
+	defer cancel()
+	return game_client.OpenStream(ctx, rpcConn, g.host, g.onPush)
+}
+
+// onPush 將 Game Server 經由串流送來的推播投遞給本地 Session
+// ctx 帶有 Game Server 端 (Peer.Send) 的追蹤上下文
+func (g *gameStreams) onPush(ctx context.Context, sessionIDs []string, payload []byte) []*connectorRPC.DeliveryResult {
+	_, span := tracing.Start(ctx, "Connector.Push", attribute.Int("sessions", len(sessionIDs)))
+	defer span.End()
+
+	push := protocol.NewPush("", protocol.Raw(payload))
+	results := make([]*connectorRPC.DeliveryResult, 0, len(sessionIDs))
+	for _, sessID := range sessionIDs {
+		results = append(results, &connectorRPC.DeliveryResult{
+			SessionId: sessID,
+			Status:    deliver(g.sessionMgr, sessID, push),
+		})
+	}
+	return results
+}
+
+// closeAll 關閉所有串流
+func (g *gameStreams) closeAll() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.closed = true
+	for endpoint, st := range g.streams {
+		st.Close()
+		delete(g.streams, endpoint)
+	}
+}
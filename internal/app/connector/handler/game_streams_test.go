@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	mock_handlers "github.com/JoeShih716/go-k8s-game-server/test/mocks/handlers"
+)
+
+// TestGameStreams_OpenDoesNotBlockOthers 測試建立串流期間不阻塞其他 Endpoint，且同一 Endpoint 只建立一次
+func TestGameStreams_OpenDoesNotBlockOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	streams := newGameStreams(mockPool, "connector-1", session.NewManager())
+
+	// node-1 無回應 (建立中)
+	release := make(chan struct{})
+	opening := make(chan struct{})
+	mockPool.EXPECT().GetConnection("node-1:8090").DoAndReturn(func(string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+		close(opening)
+		<-release
+		return nil, fmt.Errorf("unreachable")
+	}).Times(1)
+	mockPool.EXPECT().GetConnection("node-2:8090").Return(nil, fmt.Errorf("unreachable")).Times(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Nil(t, streams.get("node-1:8090"))
+	}()
+	<-opening
+
+	// 其他 Endpoint 與同一 Endpoint 的後續呼叫皆立即退回 Unary
+	start := time.Now()
+	assert.Nil(t, streams.get("node-2:8090"))
+	assert.Nil(t, streams.get("node-1:8090"))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	close(release)
+	<-done
+	// 失敗後於重試間隔內不再建立
+	assert.Nil(t, streams.get("node-1:8090"))
+}
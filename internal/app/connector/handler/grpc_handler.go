@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/wss"
 )
 
 // GrpcHandler 實作 connectorRPC.ConnectorRPCServer 介面
@@ -23,29 +26,63 @@ func NewGrpcHandler(mgr *session.Manager) *GrpcHandler {
 }
 
 // SendMessage 發送訊息給指定玩家 (單播/多播)
+// 每個 Session 的投遞結果依請求順序回傳於 Results (DELIVERED / NOT_FOUND / QUEUE_FULL)
 func (h *GrpcHandler) SendMessage(ctx context.Context, req *connectorRPC.SendMessageReq) (*connectorRPC.SendMessageResp, error) {
 	slog.Info("ConnectorRPC Receive SendMessage", "count", len(req.SessionIds), "payload_len", len(req.Payload))
 
+	push := protocol.NewPush("", protocol.Raw(req.Payload))
+	results := make([]*connectorRPC.DeliveryResult, 0, len(req.SessionIds))
 	// 遍歷所有目標 SessionID
 	for _, sessID := range req.SessionIds {
-		client, ok := h.sessionMgr.Get(sessID)
-		if !ok {
-			// 若找不到玩家，紀錄 Warn 但不中斷其他發送
-			slog.Warn("SendMessage: Session not found", "session_id", sessID)
-			continue
-		}
-
-		// 直接發送原始 Bytes
-		if err := client.Send(string(req.Payload)); err != nil {
-			slog.Error("SendMessage: Failed to write", "session_id", sessID, "error", err)
-		}
+		results = append(results, &connectorRPC.DeliveryResult{
+			SessionId: sessID,
+			Status:    deliver(h.sessionMgr, sessID, push),
+		})
 	}
 
 	return &connectorRPC.SendMessageResp{
-		Code: proto.ErrorCode_SUCCESS,
+		Code:    proto.ErrorCode_SUCCESS,
+		Results: results,
 	}, nil
 }
 
+// deliver 將推送封包 (type: push) 依 Session 的連線模式編碼後放入發送佇列，並回傳投遞結果
+// Unary SendMessage、Game 串流推播、叢集推播與維護通知共用
+func deliver(mgr *session.Manager, sessID string, push *protocol.Response) connectorRPC.DeliveryStatus {
+	client, ok := mgr.Get(sessID)
+	if !ok {
+		// 若找不到玩家，紀錄 Warn 但不中斷其他發送
+		slog.Warn("SendMessage: Session not found", "session_id", sessID)
+		return connectorRPC.DeliveryStatus_NOT_FOUND
+	}
+
+	// 依連線模式選擇編碼與 Text / Binary 訊框
+	codec := protocol.CodecFor(client.Subprotocol())
+	frame, err := codec.Encode(push)
+	if err != nil {
+		slog.Error("SendMessage: Failed to encode push", "session_id", sessID, "error", err)
+		return connectorRPC.DeliveryStatus_FAILED
+	}
+	if codec.Binary() {
+		err = client.SendBinary(frame)
+	} else {
+		err = client.Send(string(frame))
+	}
+	switch {
+	case err == nil:
+		return connectorRPC.DeliveryStatus_DELIVERED
+	case errors.Is(err, wss.ErrSendQueueFull):
+		slog.Warn("SendMessage: Send queue full", "session_id", sessID)
+		return connectorRPC.DeliveryStatus_QUEUE_FULL
+	case errors.Is(err, wss.ErrConnectionClosed):
+		// 連線已關閉但尚未從 Manager 移除，視同找不到
+		return connectorRPC.DeliveryStatus_NOT_FOUND
+	default:
+		slog.Error("SendMessage: Failed to write", "session_id", sessID, "error", err)
+		return connectorRPC.DeliveryStatus_FAILED
+	}
+}
+
 // Kick 強制踢除玩家
 func (h *GrpcHandler) Kick(ctx context.Context, req *connectorRPC.KickReq) (*connectorRPC.KickResp, error) {
 	slog.Info("ConnectorRPC Receive Kick", "session_id", req.SessionId, "reason", req.Reason)
@@ -58,6 +95,9 @@ func (h *GrpcHandler) Kick(ctx context.Context, req *connectorRPC.KickReq) (*con
 		}, nil
 	}
 
+	// 被主動踢除的會話不可再 Resume (e.g. 重複登入)
+	client.SetTag("resume_token", "")
+
 	// 執行踢除
 	if err := client.Kick(req.Reason); err != nil {
 		slog.Error("Kick: Failed to close connection", "session_id", req.SessionId, "error", err)
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/wss"
+	mock_wss "github.com/JoeShih716/go-k8s-game-server/test/mocks/pkg/wss"
+)
+
+func TestGrpcHandler_SendMessage_Results(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr := session.NewManager()
+
+	okClient := mock_wss.NewMockClient(ctrl)
+	okClient.EXPECT().ID().Return("sess-ok").AnyTimes()
+	okClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	okClient.EXPECT().SendMessage(`{"type":"push","data":"hello"}`).Return(nil)
+	mgr.Add(domain.NewSession(okClient))
+
+	slowClient := mock_wss.NewMockClient(ctrl)
+	slowClient.EXPECT().ID().Return("sess-slow").AnyTimes()
+	slowClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	slowClient.EXPECT().SendMessage(`{"type":"push","data":"hello"}`).Return(wss.ErrSendQueueFull)
+	mgr.Add(domain.NewSession(slowClient))
+
+	brokenClient := mock_wss.NewMockClient(ctrl)
+	brokenClient.EXPECT().ID().Return("sess-broken").AnyTimes()
+	brokenClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	brokenClient.EXPECT().SendMessage(`{"type":"push","data":"hello"}`).Return(errors.New("write failed"))
+	mgr.Add(domain.NewSession(brokenClient))
+
+	h := NewGrpcHandler(mgr)
+	resp, err := h.SendMessage(context.Background(), &connectorRPC.SendMessageReq{
+		SessionIds: []string{"sess-ok", "sess-missing", "sess-slow", "sess-broken"},
+		Payload:    []byte("hello"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+	if assert.Len(t, resp.Results, 4) {
+		assert.Equal(t, "sess-ok", resp.Results[0].SessionId)
+		assert.Equal(t, connectorRPC.DeliveryStatus_DELIVERED, resp.Results[0].Status)
+		assert.Equal(t, "sess-missing", resp.Results[1].SessionId)
+		assert.Equal(t, connectorRPC.DeliveryStatus_NOT_FOUND, resp.Results[1].Status)
+		assert.Equal(t, "sess-slow", resp.Results[2].SessionId)
+		assert.Equal(t, connectorRPC.DeliveryStatus_QUEUE_FULL, resp.Results[2].Status)
+		assert.Equal(t, "sess-broken", resp.Results[3].SessionId)
+		assert.Equal(t, connectorRPC.DeliveryStatus_FAILED, resp.Results[3].Status)
+	}
+}
+
+// TestGrpcHandler_PeerSend_QueueFull Game 端的 peer.Send 可以收到慢速消費者的佇列已滿
+// wss 連線僅在 OverflowKick 時回傳 ErrSendQueueFull；OverflowDropOldest 會丟棄舊訊息並成功放入新訊息
+func TestGrpcHandler_PeerSend_QueueFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mgr := session.NewManager()
+
+	kickClient := mock_wss.NewMockClient(ctrl)
+	kickClient.EXPECT().ID().Return("sess-kick").AnyTimes()
+	kickClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	kickClient.EXPECT().SendMessage(gomock.Any()).Return(wss.ErrSendQueueFull)
+	mgr.Add(domain.NewSession(kickClient))
+
+	dropClient := mock_wss.NewMockClient(ctrl)
+	dropClient.EXPECT().ID().Return("sess-drop").AnyTimes()
+	dropClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	dropClient.EXPECT().SendMessage(gomock.Any()).Return(nil)
+	mgr.Add(domain.NewSession(dropClient))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	connectorRPC.RegisterConnectorRPCServer(server, NewGrpcHandler(mgr))
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	pool := grpcpkg.NewPool()
+	defer pool.Close()
+	host := lis.Addr().String()
+
+	kickPeer := engine.NewPeer(&domain.User{ID: "user-1"}, "sess-kick", host, pool)
+	assert.ErrorIs(t, kickPeer.Send(context.Background(), []byte("hello")), engine.ErrSendQueueFull)
+
+	dropPeer := engine.NewPeer(&domain.User{ID: "user-2"}, "sess-drop", host, pool)
+	assert.NoError(t, dropPeer.Send(context.Background(), []byte("hello")))
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// kickReasonMaintenance 維護開始時踢除玩家的原因
+const kickReasonMaintenance = "Under Maintenance"
+
+// maintenanceWatcher 定期向 Central 查詢維護排程並通知本機受影響的玩家
+// 排程建立 (或更新) 與開始時各推送一次維護通知，排程設定 kick_players 時於開始時踢除遊戲中的玩家 (白名單除外)
+type maintenanceWatcher struct {
+	central   CentralClient
+	mgr       *session.Manager
+	kickDelay time.Duration // 推送通知後延遲踢除，讓通知先送達客戶端
+
+	known map[int32]maintenanceState // GameID -> 已通知的排程 (僅由 run 的 goroutine 存取)
+}
+
+// maintenanceState 記錄已通知過的排程版本與是否已開始
+type maintenanceState struct {
+	updatedAt int64
+	started   bool
+}
+
+func newMaintenanceWatcher(central CentralClient, mgr *session.Manager) *maintenanceWatcher {
+	return &maintenanceWatcher{
+		central:   central,
+		mgr:       mgr,
+		kickDelay: 100 * time.Millisecond,
+		known:     make(map[int32]maintenanceState),
+	}
+}
+
+// run 定期查詢維護排程 (Blocking，直到 ctx 結束)
+func (w *maintenanceWatcher) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx, time.Now())
+		}
+	}
+}
+
+// poll 查詢維護排程，對新建立、已更新或剛開始的排程通知玩家
+func (w *maintenanceWatcher) poll(ctx context.Context, now time.Time) {
+	pollCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	schedules, err := w.central.ListMaintenance(pollCtx)
+	cancel()
+	if err != nil {
+		slog.Warn("Failed to list maintenance schedules", "error", err)
+		return
+	}
+
+	current := make(map[int32]struct{}, len(schedules))
+	for _, info := range schedules {
+		m := toMaintenance(info)
+		current[m.GameID] = struct{}{}
+
+		started := m.Active(now)
+		state, ok := w.known[m.GameID]
+		if ok && state.updatedAt == m.UpdatedAt && state.started == started {
+			continue
+		}
+		w.known[m.GameID] = maintenanceState{updatedAt: m.UpdatedAt, started: started}
+		w.apply(m, started)
+	}
+
+	// 已結束或被取消的排程
+	for gameID := range w.known {
+		if _, ok := current[gameID]; !ok {
+			delete(w.known, gameID)
+			slog.Info("Maintenance finished", "game_id", gameID)
+		}
+	}
+}
+
+// apply 推送維護通知給受影響的玩家 (全服維護為所有已登入玩家，遊戲維護為該遊戲中的玩家)
+// 排程已開始且設定 kick_players 時，踢除在受影響遊戲中的玩家
+func (w *maintenanceWatcher) apply(m *domain.Maintenance, started bool) {
+	push := protocol.NewPush(protocol.ActionMaintenance, toMaintenanceNotice(m, started))
+	kick := started && m.KickPlayers
+
+	notified, kicked := 0, 0
+	w.mgr.Range(func(s *domain.Session) bool {
+		userID := sessionTag(s, "user_id")
+		if userID == "" || m.Allows(userID) {
+			return true
+		}
+		var gameID int32
+		_, _ = fmt.Sscanf(sessionTag(s, "current_game_id"), "%d", &gameID)
+		if !m.Global() && gameID != m.GameID {
+			return true
+		}
+
+		deliver(w.mgr, s.ID, push)
+		notified++
+
+		if kick && gameID != 0 {
+			w.kick(s)
+			kicked++
+		}
+		return true
+	})
+	slog.Info("Maintenance notice sent", "game_id", m.GameID, "started", started, "notified", notified, "kicked", kicked)
+}
+
+// kick 踢除會話 (維護踢除的會話不可再 Resume)
+func (w *maintenanceWatcher) kick(s *domain.Session) {
+	s.SetTag("resume_token", "")
+	kick := func() { _ = s.Kick(kickReasonMaintenance) }
+	if w.kickDelay <= 0 {
+		kick()
+		return
+	}
+	time.AfterFunc(w.kickDelay, kick)
+}
+
+func sessionTag(s *domain.Session, key string) string {
+	if v, ok := s.GetTag(key); ok {
+		if str, ok := v.(string); ok {
+			return str
+		}
+	}
+	return ""
+}
+
+func toMaintenance(info *centralRPC.MaintenanceInfo) *domain.Maintenance {
+	return &domain.Maintenance{
+		GameID:      info.GetGameId(),
+		Message:     info.GetMessage(),
+		StartAt:     info.GetStartAt(),
+		EndAt:       info.GetEndAt(),
+		Whitelist:   info.GetWhitelist(),
+		KickPlayers: info.GetKickPlayers(),
+		UpdatedAt:   info.GetUpdatedAt(),
+	}
+}
+
+func toMaintenanceNotice(m *domain.Maintenance, started bool) protocol.MaintenanceNotice {
+	return protocol.MaintenanceNotice{
+		GameID:  m.GameID,
+		Message: m.Message,
+		StartAt: m.StartAt,
+		EndAt:   m.EndAt,
+		Started: started,
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	mock_handlers "github.com/JoeShih716/go-k8s-game-server/test/mocks/handlers"
+	mock_wss "github.com/JoeShih716/go-k8s-game-server/test/mocks/pkg/wss"
+)
+
+func TestMaintenanceWatcher_NotifyAndKick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mgr := session.NewManager()
+
+	// s1: user-1 在遊戲 1001 中，s2: tester 在遊戲 1001 中 (白名單)，s3: user-3 在遊戲 2002 中，s4: 尚未登入
+	sessions := []struct {
+		id, userID, gameID string
+	}{
+		{"s1", "user-1", "1001"},
+		{"s2", "tester", "1001"},
+		{"s3", "user-3", "2002"},
+		{"s4", "", ""},
+	}
+	clients := make(map[string]*mock_wss.MockClient)
+	for _, sess := range sessions {
+		c := mock_wss.NewMockClient(ctrl)
+		c.EXPECT().ID().Return(sess.id).AnyTimes()
+		c.EXPECT().Subprotocol().Return("").AnyTimes()
+		c.EXPECT().GetTag("user_id").Return(sess.userID, sess.userID != "").AnyTimes()
+		c.EXPECT().GetTag("current_game_id").Return(sess.gameID, sess.gameID != "").AnyTimes()
+		clients[sess.id] = c
+		mgr.Add(domain.NewSession(c))
+	}
+
+	watcher := newMaintenanceWatcher(mockCentral, mgr)
+	watcher.kickDelay = 0
+	ctx := context.Background()
+	now := time.Unix(1800000000, 0)
+	schedule := &centralRPC.MaintenanceInfo{
+		GameId:      1001,
+		Message:     "upgrading",
+		StartAt:     now.Unix() + 60,
+		Whitelist:   []string{"tester"},
+		KickPlayers: true,
+		UpdatedAt:   1,
+	}
+	mockCentral.EXPECT().ListMaintenance(gomock.Any()).Return([]*centralRPC.MaintenanceInfo{schedule}, nil).Times(3)
+
+	expectNotice := func(started bool) {
+		clients["s1"].EXPECT().SendMessage(gomock.Any()).DoAndReturn(func(msg string) error {
+			var resp struct {
+				Action protocol.ConnectorProtocol `json:"action"`
+				Data   protocol.MaintenanceNotice `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal([]byte(msg), &resp))
+			assert.Equal(t, protocol.ActionMaintenance, resp.Action)
+			assert.Equal(t, int32(1001), resp.Data.GameID)
+			assert.Equal(t, "upgrading", resp.Data.Message)
+			assert.Equal(t, started, resp.Data.Started)
+			return nil
+		})
+	}
+
+	// 1. 排程建立: 只通知遊戲 1001 中非白名單的玩家
+	expectNotice(false)
+	watcher.poll(ctx, now)
+
+	// 2. 尚未開始且未更新: 不重複通知
+	watcher.poll(ctx, now.Add(30*time.Second))
+
+	// 3. 排程開始: 再次通知並踢除 (不可 Resume)
+	expectNotice(true)
+	clients["s1"].EXPECT().SetTag("resume_token", "")
+	clients["s1"].EXPECT().Kick(kickReasonMaintenance).Return(nil)
+	watcher.poll(ctx, now.Add(time.Minute))
+
+	// 4. 排程結束後被移除
+	mockCentral.EXPECT().ListMaintenance(gomock.Any()).Return(nil, nil)
+	watcher.poll(ctx, now.Add(time.Hour))
+	assert.Empty(t, watcher.known)
+}
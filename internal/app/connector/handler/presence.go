@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+// presenceTracker 記錄本機已登入的會話並同步到 PresenceStore (在線狀態)
+// 啟用叢集推播時，經由 pushRelay 訂閱本機使用者的個人頻道，並將推播轉發給本機會話
+type presenceTracker struct {
+	store    ports.PresenceStore
+	relay    *pushRelay // 可為 nil (未啟用叢集推播)
+	endpoint string     // 本機 Connector 的 grpc host
+
+	mu       sync.Mutex
+	sessions map[string]*domain.Presence    // SessionID -> 已登入的會話
+	users    map[string]map[string]struct{} // UserID -> 本機 SessionIDs
+}
+
+func newPresenceTracker(store ports.PresenceStore, bus ports.PushBus, mgr *session.Manager, endpoint string) *presenceTracker {
+	t := &presenceTracker{
+		store:    store,
+		endpoint: endpoint,
+		sessions: make(map[string]*domain.Presence),
+		users:    make(map[string]map[string]struct{}),
+	}
+	if bus != nil {
+		t.relay = newPushRelay(bus, mgr, endpoint, t.targets)
+	}
+	return t
+}
+
+// bind 登入或進入遊戲後更新在線狀態 (gameID 為 0 代表未在遊戲中)
+// 使用者在本機的第一個會話會訂閱其個人推播頻道
+func (t *presenceTracker) bind(sessionID, userID string, gameID int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mu.Lock()
+	presence, ok := t.sessions[sessionID]
+	if !ok {
+		presence = &domain.Presence{
+			UserID:        userID,
+			SessionID:     sessionID,
+			ConnectorHost: t.endpoint,
+			Since:         time.Now().Unix(),
+		}
+		t.sessions[sessionID] = presence
+		sessions, exists := t.users[userID]
+		if !exists {
+			sessions = make(map[string]struct{})
+			t.users[userID] = sessions
+		}
+		sessions[sessionID] = struct{}{}
+	}
+	presence.GameID = gameID
+	snapshot := *presence
+	t.mu.Unlock()
+
+	if !ok && t.relay != nil {
+		t.relay.acquire(userID)
+	}
+	if err := t.store.Set(ctx, &snapshot); err != nil {
+		slog.Warn("Failed to set presence", "session_id", sessionID, "user_id", userID, "error", err)
+	}
+}
+
+// unbind 會話離線時移除在線狀態，使用者在本機已無會話時取消訂閱其個人推播頻道
+func (t *presenceTracker) unbind(sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	t.mu.Lock()
+	presence, ok := t.sessions[sessionID]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.sessions, sessionID)
+	userID := presence.UserID
+	sessions := t.users[userID]
+	delete(sessions, sessionID)
+	if len(sessions) == 0 {
+		delete(t.users, userID)
+	}
+	t.mu.Unlock()
+
+	if t.relay != nil {
+		t.relay.release(userID)
+	}
+	if err := t.store.Remove(ctx, userID, sessionID); err != nil {
+		slog.Warn("Failed to remove presence", "session_id", sessionID, "user_id", userID, "error", err)
+	}
+}
+
+// refresh 延長本機所有會話的在線狀態存活時間
+func (t *presenceTracker) refresh(ctx context.Context) {
+	t.mu.Lock()
+	presences := make([]*domain.Presence, 0, len(t.sessions))
+	for _, p := range t.sessions {
+		snapshot := *p
+		presences = append(presences, &snapshot)
+	}
+	t.mu.Unlock()
+
+	if len(presences) == 0 {
+		return
+	}
+	if err := t.store.Refresh(ctx, presences); err != nil {
+		slog.Warn("Failed to refresh presence", "count", len(presences), "error", err)
+	}
+}
+
+// run 定期刷新在線狀態 (Blocking，直到 ctx 結束)
+func (t *presenceTracker) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.refresh(ctx)
+		}
+	}
+}
+
+// listen 開始訂閱全服推播頻道 (未啟用叢集推播時不做任何事)
+func (t *presenceTracker) listen(ctx context.Context) error {
+	if t.relay == nil {
+		return nil
+	}
+	return t.relay.listen(ctx)
+}
+
+// close 關閉推播訂閱
+func (t *presenceTracker) close() {
+	if t.relay != nil {
+		t.relay.close()
+	}
+}
+
+// targets 列出本機符合推播條件的會話 (UserID 不為空時僅限該使用者的會話)
+func (t *presenceTracker) targets(msg *domain.PushMessage) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var targets []string
+	if msg.UserID != "" {
+		for sessionID := range t.users[msg.UserID] {
+			if msg.Match(t.endpoint, t.sessions[sessionID].GameID) {
+				targets = append(targets, sessionID)
+			}
+		}
+	} else {
+		for sessionID, p := range t.sessions {
+			if msg.Match(t.endpoint, p.GameID) {
+				targets = append(targets, sessionID)
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_wss "github.com/JoeShih716/go-k8s-game-server/test/mocks/pkg/wss"
+)
+
+func TestPresenceTracker_BindDeliverUnbind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBus := mock_ports.NewMockPushBus(ctrl)
+	mockStore := mock_ports.NewMockPresenceStore(ctrl)
+	mgr := session.NewManager()
+
+	// 三個會話: user-1 兩個 (一個在遊戲 1001 中)，user-2 一個
+	clients := make(map[string]*mock_wss.MockClient)
+	for _, id := range []string{"s1", "s2", "s3"} {
+		c := mock_wss.NewMockClient(ctrl)
+		c.EXPECT().ID().Return(id).AnyTimes()
+		c.EXPECT().Subprotocol().Return("").AnyTimes()
+		clients[id] = c
+		mgr.Add(domain.NewSession(c))
+	}
+
+	tracker := newPresenceTracker(mockStore, mockBus, mgr, "connector-1")
+
+	// 每位使用者只訂閱一次個人頻道
+	mockBus.EXPECT().Watch(gomock.Any(), "user-1").Return(nil).Times(1)
+	mockBus.EXPECT().Watch(gomock.Any(), "user-2").Return(nil).Times(1)
+	mockStore.EXPECT().Set(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+	tracker.bind("s1", "user-1", 0)
+	tracker.bind("s2", "user-1", 0)
+	tracker.bind("s2", "user-1", 1001) // 進入遊戲
+	tracker.bind("s3", "user-2", 0)
+
+	// 定期刷新所有會話，帶入最新的遊戲 ID
+	mockStore.EXPECT().Refresh(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, presences []*domain.Presence) error {
+		assert.Len(t, presences, 3)
+		for _, p := range presences {
+			assert.Equal(t, "connector-1", p.ConnectorHost)
+			if p.SessionID == "s2" {
+				assert.Equal(t, int32(1001), p.GameID)
+			}
+		}
+		return nil
+	})
+	tracker.refresh(context.Background())
+
+	// 推播給 user-1 的所有會話
+	clients["s1"].EXPECT().SendMessage(`{"type":"push","data":"hi"}`).Return(nil)
+	clients["s2"].EXPECT().SendMessage(`{"type":"push","data":"hi"}`).Return(nil)
+	tracker.relay.deliver(&domain.PushMessage{UserID: "user-1", Payload: []byte("hi")})
+
+	// 依遊戲篩選的全服廣播
+	clients["s2"].EXPECT().SendMessage(`{"type":"push","data":"jackpot"}`).Return(nil)
+	tracker.relay.deliver(&domain.PushMessage{GameID: 1001, Payload: []byte("jackpot")})
+
+	// 指定其他 Connector 的廣播不處理
+	tracker.relay.deliver(&domain.PushMessage{ConnectorHost: "connector-2", Payload: []byte("other")})
+
+	// 全服廣播
+	for _, id := range []string{"s1", "s2", "s3"} {
+		clients[id].EXPECT().SendMessage(`{"type":"push","data":"notice"}`).Return(nil)
+	}
+	tracker.relay.deliver(&domain.PushMessage{Payload: []byte("notice")})
+
+	// 最後一個會話離線時才取消訂閱
+	mockStore.EXPECT().Remove(gomock.Any(), "user-1", "s1").Return(nil)
+	tracker.unbind("s1")
+
+	mockStore.EXPECT().Remove(gomock.Any(), "user-1", "s2").Return(nil)
+	mockBus.EXPECT().Unwatch(gomock.Any(), "user-1").Return(nil)
+	tracker.unbind("s2")
+
+	// 未登入的會話不需處理
+	tracker.unbind("s-unknown")
+}
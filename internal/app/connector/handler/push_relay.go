@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+// pushRelay 將叢集推播匯流排的訊息轉發給本機的會話
+// 本機有某使用者的會話時才訂閱該使用者的個人頻道 (依會話數計數)
+// 訂閱與取消訂閱 (Redis I/O) 不在鎖內進行，避免拖慢其他使用者的登入與推播
+type pushRelay struct {
+	bus      ports.PushBus
+	mgr      *session.Manager
+	endpoint string                                 // 本機 Connector 的 grpc host
+	targets  func(msg *domain.PushMessage) []string // 列出本機符合推播條件的會話 (由 presenceTracker 提供)
+
+	mu      sync.Mutex
+	watches map[string]*userWatch // UserID -> 個人頻道訂閱狀態
+}
+
+// userWatch 單一使用者個人頻道的訂閱狀態
+type userWatch struct {
+	refs    int  // 本機此使用者的會話數
+	watched bool // 是否已訂閱
+	pending bool // 是否有 goroutine 正在訂閱或取消訂閱
+}
+
+func newPushRelay(bus ports.PushBus, mgr *session.Manager, endpoint string, targets func(msg *domain.PushMessage) []string) *pushRelay {
+	return &pushRelay{
+		bus:      bus,
+		mgr:      mgr,
+		endpoint: endpoint,
+		targets:  targets,
+		watches:  make(map[string]*userWatch),
+	}
+}
+
+// listen 開始訂閱全服頻道
+func (r *pushRelay) listen(ctx context.Context) error {
+	return r.bus.Listen(ctx, r.deliver)
+}
+
+// close 關閉推播訂閱
+func (r *pushRelay) close() {
+	_ = r.bus.Close()
+}
+
+// acquire 使用者在本機新增一個會話，第一個會話會訂閱其個人頻道
+func (r *pushRelay) acquire(userID string) {
+	r.mu.Lock()
+	w, ok := r.watches[userID]
+	if !ok {
+		w = &userWatch{}
+		r.watches[userID] = w
+	}
+	w.refs++
+	r.mu.Unlock()
+
+	r.sync(userID)
+}
+
+// release 使用者在本機移除一個會話，已無會話時取消訂閱其個人頻道
+func (r *pushRelay) release(userID string) {
+	r.mu.Lock()
+	w, ok := r.watches[userID]
+	if !ok {
+		r.mu.Unlock()
+		return
+	}
+	w.refs--
+	r.mu.Unlock()
+
+	r.sync(userID)
+}
+
+// sync 使個人頻道的訂閱狀態符合本機會話數 (有會話則訂閱，沒有則取消訂閱)
+// 同一使用者同時只有一個 goroutine 進行 Watch / Unwatch；期間會話數有變化時，
+// 由該 goroutine 在 I/O 完成後再次調整，其他呼叫者不需等待
+func (r *pushRelay) sync(userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		w, ok := r.watches[userID]
+		if !ok || w.pending {
+			return
+		}
+		want := w.refs > 0
+		if want == w.watched {
+			if !want {
+				delete(r.watches, userID)
+			}
+			return
+		}
+
+		w.pending = true
+		r.mu.Unlock()
+		err := r.setWatch(userID, want)
+		r.mu.Lock()
+		w.pending = false
+		// 失敗時仍視為已完成，避免重試迴圈 (下次會話數變化時會再次調整)
+		w.watched = want
+		if err != nil {
+			slog.Warn("Failed to update user push channel", "user_id", userID, "watch", want, "error", err)
+		}
+	}
+}
+
+// setWatch 訂閱或取消訂閱使用者的個人頻道
+func (r *pushRelay) setWatch(userID string, watch bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if watch {
+		return r.bus.Watch(ctx, userID)
+	}
+	return r.bus.Unwatch(ctx, userID)
+}
+
+// deliver 將推播訊息轉發給本機符合條件的會話
+func (r *pushRelay) deliver(msg *domain.PushMessage) {
+	if msg.ConnectorHost != "" && msg.ConnectorHost != r.endpoint {
+		return
+	}
+
+	targets := r.targets(msg)
+	push := protocol.NewPush("", protocol.Raw(msg.Payload))
+	delivered := 0
+	for _, sessionID := range targets {
+		if deliver(r.mgr, sessionID, push) == connectorRPC.DeliveryStatus_DELIVERED {
+			delivered++
+		}
+	}
+	slog.Debug("Cluster push delivered", "user_id", msg.UserID, "game_id", msg.GameID, "targets", len(targets), "delivered", delivered)
+}
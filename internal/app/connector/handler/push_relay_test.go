@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+)
+
+func TestPushRelay_WatchOutsideLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBus := mock_ports.NewMockPushBus(ctrl)
+	relay := newPushRelay(mockBus, session.NewManager(), "connector-1", func(*domain.PushMessage) []string { return nil })
+
+	// user-1 的訂閱卡住
+	watching := make(chan struct{})
+	unblock := make(chan struct{})
+	mockBus.EXPECT().Watch(gomock.Any(), "user-1").DoAndReturn(func(context.Context, string) error {
+		close(watching)
+		<-unblock
+		return nil
+	})
+	done := make(chan struct{})
+	go func() {
+		relay.acquire("user-1")
+		close(done)
+	}()
+	<-watching
+
+	// 其他使用者的訂閱不受影響
+	mockBus.EXPECT().Watch(gomock.Any(), "user-2").Return(nil)
+	relay.acquire("user-2")
+
+	// 訂閱進行中時會話離線: 不需等待，由進行中的 goroutine 在訂閱完成後取消訂閱
+	relay.release("user-1")
+	mockBus.EXPECT().Unwatch(gomock.Any(), "user-1").Return(nil)
+	close(unblock)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("acquire did not return")
+	}
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+	assert.NotContains(t, relay.watches, "user-1")
+	assert.Contains(t, relay.watches, "user-2")
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"hash/fnv"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/wss"
+)
+
+// kickReasonRateLimit 違規次數過多被踢除時的原因
+const kickReasonRateLimit = "Rate Limit Exceeded"
+
+// kickReasonTooManyConns 同一 IP 連線數超過上限被拒絕時的原因
+const kickReasonTooManyConns = "Too Many Connections"
+
+// loginSweepInterval 清理閒置登入計數的間隔
+const loginSweepInterval = time.Minute
+
+// RateLimit 定義 Connector 的流量限制 (各項為 0 代表不限制)
+type RateLimit struct {
+	MessagesPerSec float64            // 每個會話、每個指令的訊息速率 (Token Bucket)
+	MessageBurst   int                // 訊息的突發上限 (0 代表與每秒速率相同)
+	ActionRates    map[string]float64 // 個別指令的每秒速率，覆蓋 MessagesPerSec (ex: {"spin": 2})
+	MaxConnsPerIP  int                // 同一 IP 的同時連線數上限
+	LoginsPerMin   float64            // 同一 IP、同一 Token 每分鐘的登入 (含 Resume) 次數
+	LoginBurst     int                // 登入的突發上限 (0 代表與每分鐘次數相同)
+	KickAfter      int                // 同一會話累計違規達此次數時踢除 (0 代表只回傳錯誤)
+
+	// ForwardedHeader 信任的來源 IP 標頭 (ex: "X-Forwarded-For")，Connector 位於反向代理後方時設定
+	// 標頭含多個位址時取最後一個 (由最接近 Connector 的 Proxy 寫入，客戶端無法偽造)；空字串或標頭不存在時使用連線位址
+	ForwardedHeader string
+}
+
+// rateLimiter 依 RateLimit 追蹤每個 IP、會話與登入憑證的用量 (Thread-Safe)
+type rateLimiter struct {
+	cfg RateLimit
+
+	mu        sync.Mutex
+	ipConns   map[string]int             // IP -> 目前連線數
+	sessions  map[string]*sessionBuckets // SessionID -> 各指令的 Bucket
+	logins    map[string]*rate.Limiter   // "ip:<IP>" / "token:<Hash>" -> Bucket
+	lastSweep time.Time
+}
+
+type sessionBuckets struct {
+	actions    map[protocol.ConnectorProtocol]*rate.Limiter
+	violations int
+}
+
+func newRateLimiter(cfg RateLimit) *rateLimiter {
+	return &rateLimiter{
+		cfg:       cfg,
+		ipConns:   make(map[string]int),
+		sessions:  make(map[string]*sessionBuckets),
+		logins:    make(map[string]*rate.Limiter),
+		lastSweep: time.Now(),
+	}
+}
+
+// acquireConn 佔用 IP 的一個連線名額，超過上限回傳 false
+// 不論結果皆需於斷線時呼叫 releaseConn
+func (l *rateLimiter) acquireConn(ip string) bool {
+	if l.cfg.MaxConnsPerIP <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ipConns[ip]++
+	return l.ipConns[ip] <= l.cfg.MaxConnsPerIP
+}
+
+// releaseConn 歸還 acquireConn 佔用的名額
+func (l *rateLimiter) releaseConn(ip string) {
+	if l.cfg.MaxConnsPerIP <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.ipConns[ip] <= 1 {
+		delete(l.ipConns, ip)
+		return
+	}
+	l.ipConns[ip]--
+}
+
+// allowMessage 消耗會話在此指令上的一個 Token
+// 超過速率時 ok 為 false，累計違規達 KickAfter 時 kick 為 true
+func (l *rateLimiter) allowMessage(sessionID string, action protocol.ConnectorProtocol) (ok, kick bool) {
+	limit, custom := l.cfg.ActionRates[string(action)]
+	if !custom {
+		limit = l.cfg.MessagesPerSec
+	}
+	if limit <= 0 {
+		return true, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	sb, exists := l.sessions[sessionID]
+	if !exists {
+		sb = &sessionBuckets{actions: make(map[protocol.ConnectorProtocol]*rate.Limiter)}
+		l.sessions[sessionID] = sb
+	}
+	bucket, exists := sb.actions[action]
+	if !exists {
+		bucket = rate.NewLimiter(rate.Limit(limit), burst(l.cfg.MessageBurst, limit))
+		sb.actions[action] = bucket
+	}
+	if bucket.Allow() {
+		return true, false
+	}
+	sb.violations++
+	return false, l.cfg.KickAfter > 0 && sb.violations >= l.cfg.KickAfter
+}
+
+// allowLogin 同時消耗 IP 與 Token 的登入次數，任一超過上限即回傳 false
+func (l *rateLimiter) allowLogin(ip, token string) bool {
+	if l.cfg.LoginsPerMin <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.sweepLogins(now)
+
+	allowed := l.loginBucket("ip:"+ip).AllowN(now, 1)
+	if token != "" {
+		// 不保存原始 Token，僅以雜湊值計數
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(token))
+		allowed = l.loginBucket("token:"+strconv.FormatUint(h.Sum64(), 16)).AllowN(now, 1) && allowed
+	}
+	return allowed
+}
+
+func (l *rateLimiter) loginBucket(key string) *rate.Limiter {
+	bucket, ok := l.logins[key]
+	if !ok {
+		perSec := l.cfg.LoginsPerMin / 60
+		bucket = rate.NewLimiter(rate.Limit(perSec), burst(l.cfg.LoginBurst, l.cfg.LoginsPerMin))
+		l.logins[key] = bucket
+	}
+	return bucket
+}
+
+// sweepLogins 移除已回滿的登入 Bucket (與新建的 Bucket 等價)，避免 Map 無限成長
+func (l *rateLimiter) sweepLogins(now time.Time) {
+	if now.Sub(l.lastSweep) < loginSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.logins {
+		if bucket.TokensAt(now) >= float64(bucket.Burst()) {
+			delete(l.logins, key)
+		}
+	}
+}
+
+// forget 清除會話的訊息 Bucket (斷線時呼叫)
+func (l *rateLimiter) forget(sessionID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.sessions, sessionID)
+}
+
+// burst 未設定突發上限時以速率為準 (至少 1)
+func burst(configured int, limit float64) int {
+	if configured > 0 {
+		return configured
+	}
+	return max(1, int(limit))
+}
+
+// clientIP 取得連線的來源 IP: 設定 ForwardedHeader 時以該標頭為準，否則使用連線位址
+func (l *rateLimiter) clientIP(conn wss.Client) string {
+	if l.cfg.ForwardedHeader != "" {
+		if values := conn.Headers().Values(l.cfg.ForwardedHeader); len(values) > 0 {
+			addrs := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(addrs[len(addrs)-1]); ip != "" {
+				return hostOnly(ip)
+			}
+		}
+	}
+	return hostOnly(conn.RemoteAddr())
+}
+
+// hostOnly 去除位址中的 Port
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	mock_handlers "github.com/JoeShih716/go-k8s-game-server/test/mocks/handlers"
+	mock_wss "github.com/JoeShih716/go-k8s-game-server/test/mocks/pkg/wss"
+)
+
+func TestRateLimiter_Messages(t *testing.T) {
+	l := newRateLimiter(RateLimit{
+		MessagesPerSec: 1,
+		MessageBurst:   2,
+		ActionRates:    map[string]float64{"spin": 0},
+		KickAfter:      2,
+	})
+
+	// 突發上限內放行
+	for i := 0; i < 2; i++ {
+		ok, kick := l.allowMessage("sess-1", "bet")
+		assert.True(t, ok)
+		assert.False(t, kick)
+	}
+
+	// 超過後拒絕，累計違規達 KickAfter 時要求踢除
+	ok, kick := l.allowMessage("sess-1", "bet")
+	assert.False(t, ok)
+	assert.False(t, kick)
+	ok, kick = l.allowMessage("sess-1", "bet")
+	assert.False(t, ok)
+	assert.True(t, kick)
+
+	// 不同指令、不同會話各自計算；個別指令設為 0 代表不限制
+	ok, _ = l.allowMessage("sess-1", protocol.ActionLeave)
+	assert.True(t, ok)
+	ok, _ = l.allowMessage("sess-2", "bet")
+	assert.True(t, ok)
+	for i := 0; i < 10; i++ {
+		ok, _ = l.allowMessage("sess-1", "spin")
+		assert.True(t, ok)
+	}
+
+	// 斷線後重新計算
+	l.forget("sess-1")
+	ok, _ = l.allowMessage("sess-1", "bet")
+	assert.True(t, ok)
+}
+
+func TestRateLimiter_Connections(t *testing.T) {
+	l := newRateLimiter(RateLimit{MaxConnsPerIP: 2})
+
+	assert.True(t, l.acquireConn("10.0.0.1"))
+	assert.True(t, l.acquireConn("10.0.0.1"))
+	assert.False(t, l.acquireConn("10.0.0.1"))
+	assert.True(t, l.acquireConn("10.0.0.2"))
+
+	// 被拒絕的連線斷線時同樣歸還名額
+	l.releaseConn("10.0.0.1")
+	assert.False(t, l.acquireConn("10.0.0.1"))
+	l.releaseConn("10.0.0.1")
+	l.releaseConn("10.0.0.1")
+	assert.True(t, l.acquireConn("10.0.0.1"))
+}
+
+func TestRateLimiter_Logins(t *testing.T) {
+	l := newRateLimiter(RateLimit{LoginsPerMin: 2})
+
+	assert.True(t, l.allowLogin("10.0.0.1", "token-a"))
+	assert.True(t, l.allowLogin("10.0.0.2", "token-a"))
+	// 同一 Token 換 IP 仍受限
+	assert.False(t, l.allowLogin("10.0.0.3", "token-a"))
+
+	// 同一 IP 換 Token 仍受限
+	assert.True(t, l.allowLogin("10.0.0.4", "token-b"))
+	assert.True(t, l.allowLogin("10.0.0.4", "token-c"))
+	assert.False(t, l.allowLogin("10.0.0.4", "token-d"))
+
+	// 回滿的 Bucket 會被清除
+	l.sweepLogins(time.Now().Add(2 * loginSweepInterval))
+	assert.Empty(t, l.logins)
+}
+
+func TestWebsocketHandler_OnConnect_TooManyConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mgr := session.NewManager()
+
+	handler := NewWebsocketHandler(mgr, mockPool, mockCentral, "connector-1", WithRateLimit(RateLimit{MaxConnsPerIP: 1}))
+
+	first := mock_wss.NewMockClient(ctrl)
+	first.EXPECT().ID().Return("sess-1").AnyTimes()
+	first.EXPECT().RemoteAddr().Return("10.0.0.1:50001").AnyTimes()
+	first.EXPECT().SetTag("login_timer", gomock.Any())
+	handler.OnConnect(first)
+
+	// 同一 IP 的第二條連線: 不建立 Session，立即踢除，不設定登入計時器
+	second := mock_wss.NewMockClient(ctrl)
+	second.EXPECT().ID().Return("sess-2").AnyTimes()
+	second.EXPECT().RemoteAddr().Return("10.0.0.1:50002").AnyTimes()
+	second.EXPECT().Kick(kickReasonTooManyConns).Return(nil)
+	handler.OnConnect(second)
+
+	_, ok := mgr.Get("sess-2")
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), mgr.Count())
+}
+
+func TestRateLimiter_ClientIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := mock_wss.NewMockClient(ctrl)
+	conn.EXPECT().RemoteAddr().Return("10.0.0.1:50001").AnyTimes()
+
+	// 未設定轉送標頭: 使用連線位址
+	assert.Equal(t, "10.0.0.1", newRateLimiter(RateLimit{}).clientIP(conn))
+
+	// 取最後一個位址 (由最接近 Connector 的 Proxy 寫入)
+	l := newRateLimiter(RateLimit{ForwardedHeader: "X-Forwarded-For"})
+	conn.EXPECT().Headers().Return(http.Header{"X-Forwarded-For": {"1.1.1.1, 203.0.113.7"}})
+	assert.Equal(t, "203.0.113.7", l.clientIP(conn))
+
+	// 標頭不存在時退回連線位址
+	conn.EXPECT().Headers().Return(http.Header{})
+	assert.Equal(t, "10.0.0.1", l.clientIP(conn))
+}
@@ -3,21 +3,36 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	central_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/central"
 	game_client "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/game" // Client
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
 	"github.com/JoeShih716/go-k8s-game-server/pkg/wss"
 )
 
+// maxDrainReroutes 進入遊戲時 Game Server 回報排空中 (DRAINING)，重新取得路由的次數上限
+const maxDrainReroutes = 2
+
 // WebsocketHandler 實作 wss.Subscriber 介面，處理 WebSocket 事件
 type WebsocketHandler struct {
 	sessionMgr    *session.Manager
@@ -25,21 +40,134 @@ type WebsocketHandler struct {
 	centralClient CentralClient
 	endpoint      string
 	wg            sync.WaitGroup // 用於追蹤非同步任務 (如 OnDisconnect 的 RPC)
+
+	// Session Resume (Optional)
+	resumeStore ports.ResumeStore
+	resumeGrace time.Duration
+
+	// Game 雙向串流 (Optional，未啟用或不可用時使用 Unary OnMessage)
+	useGameStream bool
+	streams       *gameStreams
+
+	// 在線狀態與叢集推播 (Optional)
+	presenceStore ports.PresenceStore
+	pushBus       ports.PushBus
+	presence      *presenceTracker
+
+	// 維護排程通知 (Optional)
+	maintenance *maintenanceWatcher
+
+	// 流量限制 (Optional)
+	limiter *rateLimiter
+}
+
+// Option 定義了 WebsocketHandler 的配置選項函數
+type Option func(*WebsocketHandler)
+
+// WithResume 啟用斷線重連 (Session Resume)
+// 已登入的玩家斷線後，會話會被保留 grace 時間，期間可憑 Resume Token 於任一 Connector 恢復
+func WithResume(store ports.ResumeStore, grace time.Duration) Option {
+	return func(h *WebsocketHandler) {
+		h.resumeStore = store
+		h.resumeGrace = grace
+	}
+}
+
+// WithGameStream 啟用與 Game Server 之間的雙向串流 (GameRPC.Channel)
+// 同一 Game Server 的所有玩家訊息與推播共用一條串流，串流不可用時自動退回 Unary
+func WithGameStream() Option {
+	return func(h *WebsocketHandler) {
+		h.useGameStream = true
+	}
+}
+
+// WithPresence 啟用在線狀態登錄: 登入、進入遊戲與斷線時寫入 PresenceStore
+// 需搭配 RunPresenceRefresher 定期刷新存活時間
+func WithPresence(store ports.PresenceStore) Option {
+	return func(h *WebsocketHandler) {
+		h.presenceStore = store
+	}
+}
+
+// WithClusterPush 啟用叢集推播: 轉發 Central 經由 PushBus 發布的推播 (需同時啟用 WithPresence)
+// 建立後須呼叫 ListenPush 開始訂閱
+func WithClusterPush(bus ports.PushBus) Option {
+	return func(h *WebsocketHandler) {
+		h.pushBus = bus
+	}
+}
+
+// WithMaintenanceNotice 啟用維護通知: 定期向 Central 查詢維護排程，推送維護通知給受影響的玩家，
+// 並於排程開始時踢除遊戲中的玩家 (排程設定 kick_players 時)。需搭配 RunMaintenanceWatcher
+func WithMaintenanceNotice() Option {
+	return func(h *WebsocketHandler) {
+		h.maintenance = newMaintenanceWatcher(h.centralClient, h.sessionMgr)
+	}
+}
+
+// WithRateLimit 啟用流量限制: 每個會話每個指令的訊息速率、每個 IP 的同時連線數、每個 IP / Token 的登入次數
+// 超過限制時回傳錯誤，同一會話累計違規達 KickAfter 次時踢除
+func WithRateLimit(cfg RateLimit) Option {
+	return func(h *WebsocketHandler) {
+		h.limiter = newRateLimiter(cfg)
+	}
 }
 
 // NewWebsocketHandler 建立 WebSocket 事件處理器
-func NewWebsocketHandler(mgr *session.Manager, pool GRPCPool, central CentralClient, endpoint string) *WebsocketHandler {
-	return &WebsocketHandler{
+func NewWebsocketHandler(mgr *session.Manager, pool GRPCPool, central CentralClient, endpoint string, opts ...Option) *WebsocketHandler {
+	h := &WebsocketHandler{
 		sessionMgr:    mgr,
 		grpcPool:      pool,
 		centralClient: central,
 		endpoint:      endpoint,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.useGameStream {
+		h.streams = newGameStreams(pool, endpoint, mgr)
+	}
+	if h.presenceStore != nil {
+		h.presence = newPresenceTracker(h.presenceStore, h.pushBus, mgr, endpoint)
+	} else if h.pushBus != nil {
+		slog.Warn("Cluster push disabled: WithClusterPush requires WithPresence")
+	}
+	return h
+}
+
+// ListenPush 開始訂閱叢集推播 (未啟用 WithClusterPush 時不做任何事)
+func (h *WebsocketHandler) ListenPush(ctx context.Context) error {
+	if h.presence == nil {
+		return nil
+	}
+	return h.presence.listen(ctx)
 }
 
-// Close 等待所有非同步任務完成 (Graceful Shutdown)
+// RunPresenceRefresher 定期刷新本機會話的在線狀態存活時間 (Blocking，直到 ctx 結束)
+func (h *WebsocketHandler) RunPresenceRefresher(ctx context.Context, interval time.Duration) {
+	if h.presence == nil {
+		return
+	}
+	h.presence.run(ctx, interval)
+}
+
+// RunMaintenanceWatcher 定期查詢維護排程 (Blocking，直到 ctx 結束，未啟用 WithMaintenanceNotice 時立即返回)
+func (h *WebsocketHandler) RunMaintenanceWatcher(ctx context.Context, interval time.Duration) {
+	if h.maintenance == nil {
+		return
+	}
+	h.maintenance.run(ctx, interval)
+}
+
+// Close 等待所有非同步任務完成並關閉 Game 串流與推播訂閱 (Graceful Shutdown)
 func (h *WebsocketHandler) Close() {
 	h.wg.Wait()
+	if h.streams != nil {
+		h.streams.closeAll()
+	}
+	if h.presence != nil {
+		h.presence.close()
+	}
 }
 
 // 確保 WebsocketHandler 實作 wss.Subscriber 介面
@@ -47,11 +175,22 @@ var _ wss.Subscriber = (*WebsocketHandler)(nil)
 
 // OnConnect 當新連線建立時觸發
 func (h *WebsocketHandler) OnConnect(conn wss.Client) {
+	// 同一 IP 連線數超過上限: 不建立 Session，立即斷線 (名額於 OnDisconnect 歸還)
+	if h.limiter != nil {
+		if ip := h.limiter.clientIP(conn); !h.limiter.acquireConn(ip) {
+			slog.Warn("Too many connections from IP, kicking client", "id", conn.ID(), "ip", ip)
+			metrics.ConnectorRateLimited.WithLabelValues("connection").Inc()
+			_ = conn.Kick(kickReasonTooManyConns)
+			return
+		}
+	}
+
 	// 1. 建立 Session 物件
 	sess := domain.NewSession(conn)
 
 	// 2. 加入管理器
 	h.sessionMgr.Add(sess)
+	metrics.ConnectorSessions.Set(float64(h.sessionMgr.Count()))
 
 	slog.Info("Client connected", "id", conn.ID(), "online", h.sessionMgr.Count())
 
@@ -68,92 +207,81 @@ func (h *WebsocketHandler) OnDisconnect(conn wss.Client) {
 	// 清理 Timer
 	h.stopTimer(conn, "login_timer")
 	h.stopTimer(conn, "enter_game_timer")
-
-	// 若已在遊戲中，通知 Game Server 玩家離開
-	var targetEndpoint string
-
-	// 1. 嘗試取得固定路由 (Stateful)
-	if target, ok := conn.GetTag("target_endpoint"); ok {
-		if endpoint, ok := target.(string); ok && endpoint != "" {
-			targetEndpoint = endpoint
-		}
+	h.unbindPresence(conn)
+	if h.limiter != nil {
+		h.limiter.releaseConn(h.limiter.clientIP(conn))
+		h.limiter.forget(conn.ID())
 	}
 
-	// 2. 若無固定路由，嘗試取得 GameID 進行動態路由 (Stateless)
-	if targetEndpoint == "" {
-		if gameIDStr, ok := conn.GetTag("current_game_id"); ok {
-			var gameID int
-			if _, err := fmt.Sscanf(gameIDStr.(string), "%d", &gameID); err == nil {
-				// 嘗試向 Central 取得一個可用實例
-				ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-				ep, _, err := h.centralClient.GetRoute(ctx, int32(gameID))
-				cancel()
-
-				if err == nil && ep != "" {
-					targetEndpoint = ep
-					slog.Debug("Resolved stateless route for OnPlayerQuit", "gameID", gameID, "endpoint", ep)
-				} else {
-					slog.Warn("Failed to resolve route for OnPlayerQuit", "gameID", gameID, "error", err)
-				}
-			}
-		}
+	// 若啟用 Resume 且已登入，保留會話等待重連，延後至保留期限過後才通知 Game Server
+	if h.parkSession(conn) {
+		h.sessionMgr.Remove(conn.ID())
+		metrics.ConnectorSessions.Set(float64(h.sessionMgr.Count()))
+		slog.Info("Client disconnected (session parked)", "id", conn.ID(), "online", h.sessionMgr.Count())
+		return
 	}
 
-	// 3. 若有目標 Endpoint，發送信號
-	if targetEndpoint != "" {
-		// 非同步通知，避免阻塞斷線流程
-		h.wg.Add(1)
-		go func(ep string, uid string) {
-			defer h.wg.Done()
-			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-			defer cancel()
-
-			rpcConn, err := h.grpcPool.GetConnection(ep)
-			if err != nil {
-				slog.Warn("Failed to get connection for OnPlayerQuit", "endpoint", ep, "error", err)
-				return
-			}
-
-			// 使用 SDK
-			client := game_client.NewClient(rpcConn)
-			_, err = client.Quit(ctx, uid, conn.ID())
-
-			if err != nil {
-				slog.Warn("OnPlayerQuit failed", "endpoint", ep, "error", err)
-			} else {
-				slog.Info("OnPlayerQuit sent", "uid", uid, "endpoint", ep)
-			}
-		}(targetEndpoint, h.getUserID(conn))
+	// 若已在遊戲中，通知 Game Server 玩家離開
+	if targetEndpoint, gameID := h.currentRoute(conn); targetEndpoint != "" || gameID != 0 {
+		h.notifyQuit(targetEndpoint, gameID, h.getUserID(conn), conn.ID())
 	} else {
-		slog.Debug("OnPlayerQuit skipped: no target endpoint found", "id", conn.ID())
+		slog.Debug("OnPlayerQuit skipped: not in game", "id", conn.ID())
 	}
 
 	// 從管理器移除
 	h.sessionMgr.Remove(conn.ID())
+	metrics.ConnectorSessions.Set(float64(h.sessionMgr.Count()))
 
 	slog.Info("Client disconnected", "id", conn.ID(), "online", h.sessionMgr.Count())
 }
 
 // OnMessage 當收到訊息時觸發
 func (h *WebsocketHandler) OnMessage(conn wss.Client, msg []byte) {
-	// 1. 解析基礎封包
-	var envelope protocol.Envelope
-	if err := json.Unmarshal(msg, &envelope); err != nil {
-		slog.Warn("Invalid JSON envelope", "error", err)
-		h.sendError(conn, "unknown", "Invalid JSON format")
+	// 1. 解析基礎封包 (依子協議選擇 JSON / Protobuf)
+	envelope, err := protocol.CodecFor(conn.Subprotocol()).Decode(msg)
+	if err != nil {
+		slog.Warn("Invalid envelope", "subprotocol", conn.Subprotocol(), "error", err)
+		h.sendError(conn, "unknown", "", proto.ErrorCode_INVALID_PARAMS, "Invalid Envelope Format")
 		return
 	}
 
-	ctx := context.Background()
+	// 每個訊框為一條追蹤的起點，後續的 Central / Game Server 呼叫皆為其子 Span
+	ctx, span := tracing.Start(context.Background(), "Connector.OnMessage",
+		tracing.AttrSessionID.String(conn.ID()),
+		tracing.AttrAction.String(string(envelope.Action)),
+	)
+	defer span.End()
+
+	// 流量限制: 每個會話、每個指令各自一個 Token Bucket
+	if h.limiter != nil {
+		if ok, kick := h.limiter.allowMessage(conn.ID(), envelope.Action); !ok {
+			metrics.ConnectorRateLimited.WithLabelValues("message").Inc()
+			h.sendError(conn, envelope.Action, envelope.ReqID, proto.ErrorCode_RATE_LIMITED, "Rate Limit Exceeded")
+			if kick {
+				slog.Warn("Rate limit exceeded repeatedly, kicking client", "id", conn.ID(), "action", envelope.Action)
+				time.AfterFunc(100*time.Millisecond, func() { _ = conn.Kick(kickReasonRateLimit) })
+			}
+			return
+		}
+	}
 
 	// 2. 本地指令攔截 (Local Intercept)
 	// 即使已在遊戲中，這些指令也必須由 Connector 本地處理，不能轉發
 	switch envelope.Action {
 	case protocol.ActionLogin:
-		h.handleLogin(ctx, conn, envelope.Payload)
+		h.handleLogin(ctx, conn, envelope)
 		return
 	case protocol.ActionEnterGame:
-		h.handleEnterGame(ctx, conn, envelope.Payload)
+		h.handleEnterGame(ctx, conn, envelope)
+		return
+	case protocol.ActionResume:
+		h.handleResume(ctx, conn, envelope)
+		return
+	case protocol.ActionLeave:
+		h.handleLeave(ctx, conn, envelope)
+		return
+	case protocol.ActionSwitch:
+		h.handleSwitch(ctx, conn, envelope)
 		return
 	}
 
@@ -161,7 +289,7 @@ func (h *WebsocketHandler) OnMessage(conn wss.Client, msg []byte) {
 	// A. Sticky Routing (Stateful): 若已有固定路由，直接轉發
 	if target, ok := conn.GetTag("target_endpoint"); ok {
 		if endpoint, ok := target.(string); ok && endpoint != "" {
-			h.forwardToBackend(ctx, conn, endpoint, msg)
+			h.forwardToBackend(ctx, conn, endpoint, envelope)
 			return
 		}
 	}
@@ -178,13 +306,13 @@ func (h *WebsocketHandler) OnMessage(conn wss.Client, msg []byte) {
 			return
 		}
 
-		// 快速 GetRoute
+		// 快速 GetRoute (沿用進入遊戲時分配的版本)
 		routeCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
-		endpoint, _, err := h.centralClient.GetRoute(routeCtx, int32(gameID))
+		route, err := h.centralClient.GetRoute(routeCtx, h.routeRequest(conn, int32(gameID)))
 		cancel()
 
-		if err == nil && endpoint != "" {
-			h.forwardToBackend(ctx, conn, endpoint, msg)
+		if err == nil && route.Endpoint != "" {
+			h.forwardToBackend(ctx, conn, route.Endpoint, envelope)
 			return
 		}
 		slog.Warn("Failed to resolve route for stateless game", "error", err)
@@ -192,17 +320,17 @@ func (h *WebsocketHandler) OnMessage(conn wss.Client, msg []byte) {
 
 	// 4. 未知指令或未入桌
 	slog.Warn("Unknown Action and No Route", "action", envelope.Action)
-	h.sendError(conn, envelope.Action, "Unknown Action or Not In Game")
+	h.sendError(conn, envelope.Action, envelope.ReqID, proto.ErrorCode_INVALID_STATE, "Unknown Action or Not In Game")
 }
 
 // -------------------------------------------------------------
 // Handlers
 // -------------------------------------------------------------
 
-func (h *WebsocketHandler) handleLogin(ctx context.Context, conn wss.Client, payload []byte) {
+func (h *WebsocketHandler) handleLogin(ctx context.Context, conn wss.Client, env *protocol.Envelope) {
 	// 檢查是否重複登入
 	if h.getUserID(conn) != "" {
-		h.sendError(conn, protocol.ActionLogin, "Already Logged In")
+		h.sendError(conn, protocol.ActionLogin, env.ReqID, proto.ErrorCode_INVALID_STATE, "Already Logged In")
 		return
 	}
 
@@ -210,62 +338,106 @@ func (h *WebsocketHandler) handleLogin(ctx context.Context, conn wss.Client, pay
 	h.stopTimer(conn, "login_timer")
 
 	var req protocol.LoginReq
-	if err := json.Unmarshal(payload, &req); err != nil {
-		h.sendError(conn, protocol.ActionLogin, "Invalid Login Payload")
+	if err := json.Unmarshal(env.Payload, &req); err != nil {
+		metrics.ConnectorLogins.WithLabelValues("invalid_payload").Inc()
+		h.sendError(conn, protocol.ActionLogin, env.ReqID, proto.ErrorCode_INVALID_PARAMS, "Invalid Login Payload")
 		_ = conn.Kick("Invalid Protocol")
 		return
 	}
 
+	// 同一 IP / Token 登入過於頻繁 (暴力嘗試)，回傳錯誤後斷線
+	if h.limiter != nil && !h.limiter.allowLogin(h.limiter.clientIP(conn), req.Token) {
+		metrics.ConnectorRateLimited.WithLabelValues("login").Inc()
+		metrics.ConnectorLogins.WithLabelValues("rate_limited").Inc()
+		h.sendError(conn, protocol.ActionLogin, env.ReqID, proto.ErrorCode_RATE_LIMITED, "Too Many Login Attempts")
+		time.AfterFunc(100*time.Millisecond, func() { _ = conn.Kick(kickReasonRateLimit) })
+		return
+	}
+
 	// 呼叫 Central 進行登入
 	loginCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	resp, err := h.centralClient.Login(loginCtx, req.Token)
-	if err != nil {
-		slog.Error("Login failed", "error", err)
-		h.sendError(conn, protocol.ActionLogin, "Authentication Failed")
+	resp, err := h.centralClient.Login(loginCtx, req.Token, conn.ID(), h.endpoint)
+	if err == nil && resp.Code == proto.ErrorCode_MAINTENANCE {
+		slog.Info("Login rejected: under maintenance", "id", conn.ID())
+		metrics.ConnectorLogins.WithLabelValues("maintenance").Inc()
+		h.sendMaintenance(conn, protocol.ActionLogin, env.ReqID, resp.Maintenance)
+		time.AfterFunc(100*time.Millisecond, func() { _ = conn.Kick(kickReasonMaintenance) })
+		return
+	}
+	if err != nil || !resp.Success {
+		code, msg := proto.ErrorCode_SERVER_ERROR, "Authentication Failed"
+		if err != nil {
+			slog.Error("Login failed", "error", err)
+			metrics.ConnectorLogins.WithLabelValues("error").Inc()
+		} else {
+			slog.Info("Login rejected", "id", conn.ID(), "code", resp.Code, "msg", resp.ErrorMessage)
+			metrics.ConnectorLogins.WithLabelValues(strings.ToLower(resp.Code.String())).Inc()
+			code = resp.Code
+			if code == proto.ErrorCode_SUCCESS {
+				code = proto.ErrorCode_AUTH_FAILED
+			}
+			if resp.Code == proto.ErrorCode_SESSION_LIMIT {
+				msg = "Session Limit Exceeded"
+			}
+		}
+		h.sendError(conn, protocol.ActionLogin, env.ReqID, code, msg)
 		// 驗證失敗斷線
 		time.AfterFunc(100*time.Millisecond, func() { _ = conn.Kick("Auth Failed") })
 		return
 	}
 
+	// 連線已在登入期間中斷 (OnDisconnect 已執行): 釋放 Central 保留的在線會話名額
+	if _, ok := h.sessionMgr.Get(conn.ID()); !ok {
+		slog.Info("Client disconnected during login", "id", conn.ID(), "userID", resp.UserId)
+		if h.presenceStore != nil {
+			if err := h.presenceStore.Remove(ctx, resp.UserId, conn.ID()); err != nil {
+				slog.Warn("Failed to release reserved session", "id", conn.ID(), "error", err)
+			}
+		}
+		return
+	}
+
 	// 登入成功，綁定 Session
+	metrics.ConnectorLogins.WithLabelValues("success").Inc()
 	conn.SetTag("user_id", resp.UserId)
+	if req.ClientVersion != "" {
+		conn.SetTag("client_version", req.ClientVersion)
+	}
+	h.bindPresence(conn, 0)
 	slog.Info("User Logged In", "userID", resp.UserId)
 
 	balance, _ := decimal.NewFromString(resp.Balance)
-	h.sendResponse(conn, protocol.ActionLogin, protocol.LoginResp{
-		Success:  true,
-		UserID:   resp.UserId,
-		Nickname: resp.Nickname,
-		Balance:  balance,
+	h.sendResponse(conn, protocol.ActionLogin, env.ReqID, protocol.LoginResp{
+		Success:     true,
+		UserID:      resp.UserId,
+		Nickname:    resp.Nickname,
+		Balance:     balance,
+		ResumeToken: h.issueResumeToken(conn),
 	})
 
 	// 啟動 Enter Game Timer (3分鐘)
-	enterGameTimer := time.AfterFunc(3*time.Minute, func() {
-		slog.Info("Enter Game timeout, kicking client", "id", conn.ID())
-		_ = conn.Kick("Enter Game Timeout")
-	})
-	conn.SetTag("enter_game_timer", enterGameTimer)
+	h.startEnterGameTimer(conn)
 }
 
-func (h *WebsocketHandler) handleEnterGame(ctx context.Context, conn wss.Client, payload []byte) {
+func (h *WebsocketHandler) handleEnterGame(ctx context.Context, conn wss.Client, env *protocol.Envelope) {
 	// 檢查是否已經在遊戲中
 	if _, ok := conn.GetTag("current_game_id"); ok {
-		h.sendError(conn, protocol.ActionEnterGame, "Already In Game")
+		h.sendError(conn, protocol.ActionEnterGame, env.ReqID, proto.ErrorCode_INVALID_STATE, "Already In Game")
 		return
 	}
 
 	var req protocol.EnterGameReq
-	if err := json.Unmarshal(payload, &req); err != nil {
-		h.sendError(conn, protocol.ActionEnterGame, "Invalid EnterGame Payload")
+	if err := json.Unmarshal(env.Payload, &req); err != nil {
+		h.sendError(conn, protocol.ActionEnterGame, env.ReqID, proto.ErrorCode_INVALID_PARAMS, "Invalid EnterGame Payload")
 		return
 	}
 
 	// 檢查是否已登入
 	userID := h.getUserID(conn)
 	if userID == "" {
-		h.sendError(conn, protocol.ActionEnterGame, "Not Logged In")
+		h.sendError(conn, protocol.ActionEnterGame, env.ReqID, proto.ErrorCode_NOT_LOGGED_IN, "Not Logged In")
 		_ = conn.Kick("Not Logged In")
 		return
 	}
@@ -277,23 +449,154 @@ func (h *WebsocketHandler) handleEnterGame(ctx context.Context, conn wss.Client,
 	routeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	endpoint, serviceType, err := h.centralClient.GetRoute(routeCtx, req.GameID)
+	route, err := h.centralClient.JoinRoute(routeCtx, h.joinRouteRequest(conn, req.GameID))
+	var maintenanceErr *central_sdk.MaintenanceError
+	if errors.As(err, &maintenanceErr) {
+		h.sendMaintenance(conn, protocol.ActionEnterGame, env.ReqID, maintenanceErr.Info)
+		return
+	}
 	// Central 會處理 10000 邏輯，若 error 代表不合法或 demo 以外
 	if err != nil {
 		slog.Error("GetRoute failed", "game_id", req.GameID, "error", err)
-		h.sendError(conn, protocol.ActionEnterGame, "Game Service Unavailable or Invalid ID")
+		h.sendError(conn, protocol.ActionEnterGame, env.ReqID, proto.ErrorCode_GAME_UNAVAILABLE, "Game Service Unavailable or Invalid ID")
 		return
 	}
 
-	// ---------------------------------------------------------
-	// 新增: 通知 Game Server (OnPlayerJoin)
-	// ---------------------------------------------------------
+	if code, msg := h.joinGame(ctx, conn, userID, req.GameID, route); code != proto.ErrorCode_SUCCESS {
+		h.sendError(conn, protocol.ActionEnterGame, env.ReqID, code, msg)
+		return
+	}
+
+	h.sendResponse(conn, protocol.ActionEnterGame, env.ReqID, protocol.EnterGameResp{
+		Success: true,
+		GameID:  req.GameID,
+	})
+}
+
+func (h *WebsocketHandler) handleLeave(ctx context.Context, conn wss.Client, env *protocol.Envelope) {
+	if h.getUserID(conn) == "" {
+		h.sendError(conn, protocol.ActionLeave, env.ReqID, proto.ErrorCode_NOT_LOGGED_IN, "Not Logged In")
+		return
+	}
+	if _, gameID := h.currentRoute(conn); gameID == 0 {
+		h.sendError(conn, protocol.ActionLeave, env.ReqID, proto.ErrorCode_INVALID_STATE, "Not In Game")
+		return
+	}
+
+	gameID := h.leaveGame(ctx, conn)
+	h.bindPresence(conn, 0)
+
+	// 回到大廳，重新計時 (需在時限內再次進入遊戲)
+	h.startEnterGameTimer(conn)
+
+	h.sendResponse(conn, protocol.ActionLeave, env.ReqID, protocol.LeaveResp{
+		Success: true,
+		GameID:  gameID,
+	})
+}
+
+// handleSwitch 離開目前的遊戲並進入新遊戲 (未在遊戲中時等同 enter)
+// 先確認新遊戲有可用的服務才離開目前的遊戲；進入新遊戲失敗時玩家回到大廳
+func (h *WebsocketHandler) handleSwitch(ctx context.Context, conn wss.Client, env *protocol.Envelope) {
+	var req protocol.SwitchReq
+	if err := json.Unmarshal(env.Payload, &req); err != nil {
+		h.sendError(conn, protocol.ActionSwitch, env.ReqID, proto.ErrorCode_INVALID_PARAMS, "Invalid Switch Payload")
+		return
+	}
+
+	userID := h.getUserID(conn)
+	if userID == "" {
+		h.sendError(conn, protocol.ActionSwitch, env.ReqID, proto.ErrorCode_NOT_LOGGED_IN, "Not Logged In")
+		return
+	}
+	_, currentGameID := h.currentRoute(conn)
+	if currentGameID == req.GameID {
+		h.sendError(conn, protocol.ActionSwitch, env.ReqID, proto.ErrorCode_INVALID_STATE, "Already In Game")
+		return
+	}
+
+	// 確認新遊戲可用，避免離開後無處可去
+	routeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	route, err := h.centralClient.JoinRoute(routeCtx, h.joinRouteRequest(conn, req.GameID))
+	cancel()
+	var maintenanceErr *central_sdk.MaintenanceError
+	if errors.As(err, &maintenanceErr) {
+		h.sendMaintenance(conn, protocol.ActionSwitch, env.ReqID, maintenanceErr.Info)
+		return
+	}
+	if err != nil || route.Endpoint == "" {
+		slog.Error("GetRoute failed", "game_id", req.GameID, "error", err)
+		h.sendError(conn, protocol.ActionSwitch, env.ReqID, proto.ErrorCode_GAME_UNAVAILABLE, "Game Service Unavailable or Invalid ID")
+		return
+	}
+
+	var previousGameID int32
+	if currentGameID != 0 {
+		previousGameID = h.leaveGame(ctx, conn)
+	}
+	h.stopTimer(conn, "enter_game_timer")
+
+	if code, msg := h.joinGame(ctx, conn, userID, req.GameID, route); code != proto.ErrorCode_SUCCESS {
+		// 已離開原本的遊戲，回到大廳
+		h.bindPresence(conn, 0)
+		h.startEnterGameTimer(conn)
+		h.sendError(conn, protocol.ActionSwitch, env.ReqID, code, msg)
+		return
+	}
+
+	h.sendResponse(conn, protocol.ActionSwitch, env.ReqID, protocol.SwitchResp{
+		Success:        true,
+		GameID:         req.GameID,
+		PreviousGameID: previousGameID,
+	})
+}
+
+// joinGame 通知 route 指向的 Game Server 玩家加入，成功後記錄路由資訊並更新在線狀態
+// Game Server 排空中時重新向 Central 取得路由 (最多 maxDrainReroutes 次)
+// 回傳給 Client 的錯誤碼與錯誤說明 (SUCCESS 代表成功)
+func (h *WebsocketHandler) joinGame(ctx context.Context, conn wss.Client, userID string, gameID int32, route *ports.Route) (proto.ErrorCode, string) {
+	code, msg := h.requestJoin(ctx, conn, userID, route.Endpoint)
+	for i := 0; code == proto.ErrorCode_DRAINING && i < maxDrainReroutes; i++ {
+		// Central 已不再分配排空中的實例，通常會取得其他實例 (路由競態)
+		routeCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		next, err := h.centralClient.JoinRoute(routeCtx, h.joinRouteRequest(conn, gameID))
+		cancel()
+		if err != nil || next.Endpoint == "" || next.Endpoint == route.Endpoint {
+			slog.Warn("Reroute after draining failed", "game_id", gameID, "endpoint", route.Endpoint, "error", err)
+			break
+		}
+		slog.Info("Game Server draining, rerouting join", "game_id", gameID, "from", route.Endpoint, "to", next.Endpoint)
+		route = next
+		code, msg = h.requestJoin(ctx, conn, userID, route.Endpoint)
+	}
+	if code != proto.ErrorCode_SUCCESS {
+		return code, msg
+	}
+
+	endpoint, serviceType := route.Endpoint, route.ServiceType
+
+	// 緩存路由資訊到 Session (Tag)
+	conn.SetTag("current_game_id", fmt.Sprintf("%d", gameID))
+	conn.SetTag("service_type", serviceType) // 記錄服務類型
+	h.setGameVersion(conn, route.Version)
+
+	// 我們先針對 Stateful 記錄 Endpoint。
+	if serviceType == proto.ServiceType_STATEFUL {
+		conn.SetTag("target_endpoint", endpoint)
+	}
+	h.bindPresence(conn, gameID)
+
+	slog.Info("Enter Game Success", "userID", userID, "gameID", gameID, "target", endpoint, "type", serviceType, "version", route.Version)
+	return proto.ErrorCode_SUCCESS, ""
+}
+
+// requestJoin 呼叫 Game Server 的 OnPlayerJoin，回傳給 Client 的錯誤碼與錯誤說明 (SUCCESS 代表成功)
+func (h *WebsocketHandler) requestJoin(ctx context.Context, conn wss.Client, userID, endpoint string) (proto.ErrorCode, string) {
 	// 建立與 Game Server 的連線
 	rpcConn, err := h.grpcPool.GetConnection(endpoint)
 	if err != nil {
 		slog.Error("Connect to Game Server failed", "endpoint", endpoint, "error", err)
-		h.sendError(conn, protocol.ActionEnterGame, "Game Server Unavailable")
-		return
+		return proto.ErrorCode_GAME_UNAVAILABLE, "Game Server Unavailable"
 	}
 
 	// 使用 SDK
@@ -302,78 +605,425 @@ func (h *WebsocketHandler) handleEnterGame(ctx context.Context, conn wss.Client,
 	defer joinCancel()
 
 	joinResp, err := client.Join(joinCtx, userID, conn.ID(), h.endpoint)
-
 	if err != nil {
 		slog.Error("OnPlayerJoin failed", "endpoint", endpoint, "error", err)
-		h.sendError(conn, protocol.ActionEnterGame, "Join Game Failed")
+		return proto.ErrorCode_SERVER_ERROR, "Join Game Failed"
+	}
+	if joinResp.Code != proto.ErrorCode_SUCCESS {
+		slog.Error("OnPlayerJoin refused", "endpoint", endpoint, "code", joinResp.Code, "msg", joinResp.ErrorMessage)
+		return joinResp.Code, "Join Game Refused: " + joinResp.ErrorMessage
+	}
+	return proto.ErrorCode_SUCCESS, ""
+}
+
+// leaveGame 通知 Game Server 玩家離開 (同步等待) 並清除路由 Tag，回傳已離開的 GameID
+// 清除 Tag 後後續訊息不再轉發給原本的遊戲
+func (h *WebsocketHandler) leaveGame(ctx context.Context, conn wss.Client) int32 {
+	targetEndpoint, gameID := h.currentRoute(conn)
+	conn.DelTag("current_game_id")
+	conn.DelTag("service_type")
+	conn.DelTag("target_endpoint")
+	conn.DelTag("game_version")
+
+	if ep := h.quitEndpoint(targetEndpoint, gameID, conn.ID()); ep != "" {
+		h.quit(ctx, ep, h.getUserID(conn), conn.ID())
+	}
+	slog.Info("Leave Game", "userID", h.getUserID(conn), "gameID", gameID)
+	return gameID
+}
+
+func (h *WebsocketHandler) handleResume(ctx context.Context, conn wss.Client, env *protocol.Envelope) {
+	if h.resumeStore == nil {
+		h.sendError(conn, protocol.ActionResume, env.ReqID, proto.ErrorCode_INVALID_STATE, "Resume Not Supported")
 		return
 	}
 
-	if joinResp.Code != proto.ErrorCode_SUCCESS {
-		slog.Error("OnPlayerJoin refused", "code", joinResp.Code, "msg", joinResp.ErrorMessage)
-		h.sendError(conn, protocol.ActionEnterGame, "Join Game Refused: "+joinResp.ErrorMessage)
+	// 已登入的連線不可再恢復其他會話
+	if h.getUserID(conn) != "" {
+		h.sendError(conn, protocol.ActionResume, env.ReqID, proto.ErrorCode_INVALID_STATE, "Already Logged In")
 		return
 	}
-	// ---------------------------------------------------------
 
-	// 緩存路由資訊到 Session (Tag)
-	conn.SetTag("current_game_id", fmt.Sprintf("%d", req.GameID))
-	conn.SetTag("service_type", serviceType) // 記錄服務類型
+	var req protocol.ResumeReq
+	if err := json.Unmarshal(env.Payload, &req); err != nil || req.ResumeToken == "" {
+		h.sendError(conn, protocol.ActionResume, env.ReqID, proto.ErrorCode_INVALID_PARAMS, "Invalid Resume Payload")
+		return
+	}
+	if h.limiter != nil && !h.limiter.allowLogin(h.limiter.clientIP(conn), req.ResumeToken) {
+		metrics.ConnectorRateLimited.WithLabelValues("login").Inc()
+		h.sendError(conn, protocol.ActionResume, env.ReqID, proto.ErrorCode_RATE_LIMITED, "Too Many Login Attempts")
+		time.AfterFunc(100*time.Millisecond, func() { _ = conn.Kick(kickReasonRateLimit) })
+		return
+	}
 
-	// 我們先針對 Stateful 記錄 Endpoint。
+	// 認領保留的會話 (同一 Token 只能被認領一次，跨 Connector 安全)
+	claimCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	state, err := h.resumeStore.Claim(claimCtx, req.ResumeToken)
+	cancel()
+	if err != nil {
+		// Token 已過期或無效，Client 應改走 login 流程 (Login Timer 仍在計時)
+		slog.Info("Resume failed", "id", conn.ID(), "error", err)
+		h.sendError(conn, protocol.ActionResume, env.ReqID, proto.ErrorCode_AUTH_FAILED, "Resume Failed")
+		return
+	}
+
+	// 恢復成功，綁定 Session
+	h.stopTimer(conn, "login_timer")
+	conn.SetTag("user_id", state.UserID)
+	slog.Info("User Resumed", "userID", state.UserID, "old_session_id", state.SessionID, "session_id", conn.ID())
+
+	// 若斷線時在遊戲中，通知 Game Server 重新綁定
+	gameID := h.resumeGame(ctx, conn, state)
+	if gameID == 0 {
+		h.startEnterGameTimer(conn)
+	}
+	h.bindPresence(conn, gameID)
+
+	h.sendResponse(conn, protocol.ActionResume, env.ReqID, protocol.ResumeResp{
+		Success:     true,
+		UserID:      state.UserID,
+		GameID:      gameID,
+		ResumeToken: h.issueResumeToken(conn),
+	})
+}
+
+// resumeGame 通知 Game Server 玩家重連並恢復路由 Tag
+// 回傳恢復的 GameID，若未在遊戲中或恢復失敗則回傳 0 (Client 需重新 enter)
+func (h *WebsocketHandler) resumeGame(ctx context.Context, conn wss.Client, state *domain.ResumeState) int32 {
+	if !state.InGame() {
+		return 0
+	}
+
+	// Stateful 使用原本的固定路由，Stateless 重新向 Central 取得實例
+	endpoint := state.TargetEndpoint
+	serviceType := proto.ServiceType_STATEFUL
+	if endpoint == "" {
+		routeCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
+		route, err := h.centralClient.GetRoute(routeCtx, &domain.RouteRequest{
+			GameID:  state.GameID,
+			UserID:  state.UserID,
+			Version: state.Version,
+		})
+		cancel()
+		if err != nil || route.Endpoint == "" {
+			slog.Warn("Failed to resolve route for Resume", "gameID", state.GameID, "error", err)
+			return 0
+		}
+		endpoint, serviceType = route.Endpoint, route.ServiceType
+	}
+
+	rpcConn, err := h.grpcPool.GetConnection(endpoint)
+	if err != nil {
+		slog.Warn("Failed to get connection for OnPlayerReconnect", "endpoint", endpoint, "error", err)
+		return 0
+	}
+
+	client := game_client.NewClient(rpcConn)
+	reconnectCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := client.Reconnect(reconnectCtx, state.UserID, conn.ID(), state.SessionID, h.endpoint)
+	if err != nil {
+		slog.Warn("OnPlayerReconnect failed", "endpoint", endpoint, "error", err)
+		return 0
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		slog.Warn("OnPlayerReconnect refused", "endpoint", endpoint, "code", resp.Code, "msg", resp.ErrorMessage)
+		return 0
+	}
+
+	conn.SetTag("current_game_id", fmt.Sprintf("%d", state.GameID))
+	conn.SetTag("service_type", serviceType)
 	if serviceType == proto.ServiceType_STATEFUL {
 		conn.SetTag("target_endpoint", endpoint)
 	}
+	h.setGameVersion(conn, state.Version)
+	return state.GameID
+}
 
-	slog.Info("Enter Game Success", "userID", userID, "gameID", req.GameID, "target", endpoint, "type", serviceType)
+// RunResumeSweeper 定期處理保留期限已過的會話 (Blocking，直到 ctx 結束)
+// 所有 Connector 皆可執行，透過 ResumeStore.Claim 保證同一會話只會被處理一次，
+// 因此即使原 Connector 已重啟，Game Server 仍會收到 OnPlayerQuit。
+func (h *WebsocketHandler) RunResumeSweeper(ctx context.Context, interval time.Duration) {
+	if h.resumeStore == nil {
+		return
+	}
 
-	h.sendResponse(conn, protocol.ActionEnterGame, protocol.EnterGameResp{
-		Success: true,
-		GameID:  req.GameID,
-	})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.sweepExpiredSessions(ctx)
+		}
+	}
 }
 
-// forwardToBackend 將訊息直接透傳給後端
-func (h *WebsocketHandler) forwardToBackend(ctx context.Context, conn wss.Client, targetAddr string, msg []byte) {
-	// 準備 gRPC 請求
-	rpcConn, err := h.grpcPool.GetConnection(targetAddr)
+// sweepExpiredSessions 認領已過期的保留會話並通知 Game Server 玩家離開
+func (h *WebsocketHandler) sweepExpiredSessions(ctx context.Context) {
+	tokens, err := h.resumeStore.ListExpired(ctx, time.Now())
 	if err != nil {
-		h.sendError(conn, "forward", "Backend Connection Failed")
+		slog.Warn("Failed to list expired sessions", "error", err)
 		return
 	}
 
-	// 使用 SDK
-	client := game_client.NewClient(rpcConn)
+	for _, token := range tokens {
+		state, err := h.resumeStore.Claim(ctx, token)
+		if err != nil {
+			if !errors.Is(err, ports.ErrResumeNotFound) {
+				slog.Warn("Failed to claim expired session", "error", err)
+			}
+			continue // 已被恢復或由其他 Connector 處理
+		}
+
+		slog.Info("Parked session expired", "uid", state.UserID, "session_id", state.SessionID)
+		if state.InGame() {
+			h.notifyQuit(state.TargetEndpoint, state.GameID, state.UserID, state.SessionID)
+		}
+	}
+}
 
-	// 呼叫後端
+// forwardToBackend 將指令代碼與解析後的 payload 轉發給後端
+// 不論 Client 使用 JSON 或 Protobuf 外層，Game Server 收到的都是相同的 action 與 payload
+// 啟用串流時優先經由串流轉發，串流不可用時退回 Unary OnMessage
+func (h *WebsocketHandler) forwardToBackend(ctx context.Context, conn wss.Client, targetAddr string, env *protocol.Envelope) {
 	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	rpcResp, err := client.SendMessage(callCtx, h.getUserID(conn), conn.ID(), msg)
+	userID := h.getUserID(conn)
+	_, gameID := h.currentRoute(conn)
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(tracing.AttrUserID.String(userID), tracing.AttrGameID.Int(int(gameID)), tracing.AttrEndpoint.String(targetAddr))
+
+	gameLabel := strconv.Itoa(int(gameID))
+	start := time.Now()
+	result := "error"
+	defer func() {
+		metrics.ConnectorForwardDuration.WithLabelValues(gameLabel).Observe(time.Since(start).Seconds())
+		metrics.ConnectorForwarded.WithLabelValues(gameLabel, result).Inc()
+	}()
+
+	var rpcResp *gameRPC.MsgResp
+	var err error
+	if st := h.gameStream(targetAddr); st != nil {
+		rpcResp, err = st.SendMessage(callCtx, userID, conn.ID(), string(env.Action), env.Payload)
+	}
+	if rpcResp == nil && (err == nil || errors.Is(err, game_client.ErrStreamClosed)) {
+		// 準備 gRPC 請求
+		rpcConn, connErr := h.grpcPool.GetConnection(targetAddr)
+		if connErr != nil {
+			h.sendError(conn, env.Action, env.ReqID, proto.ErrorCode_SERVER_ERROR, "Backend Connection Failed")
+			return
+		}
+
+		// 使用 SDK
+		client := game_client.NewClient(rpcConn)
+
+		// 呼叫後端
+		rpcResp, err = client.SendMessage(callCtx, userID, conn.ID(), string(env.Action), env.Payload)
+	}
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		slog.Error("RPC OnMessage failed", "target", targetAddr, "error", err)
-		h.sendError(conn, "forward", "Game Server Error: "+err.Error())
+		h.sendError(conn, env.Action, env.ReqID, proto.ErrorCode_SERVER_ERROR, "Game Server Error: "+err.Error())
 		return
 	}
 
-	// 轉發回應給前端 (假設後端回傳的就是完整 JSON 封包)
-	_ = conn.SendMessage(string(rpcResp.Payload))
+	// 轉發回應給前端: Game Server 的資料放入 data，並帶回請求的 req_id 與錯誤碼
+	result = "ok"
+	h.send(conn, &protocol.Response{
+		Action:  env.Action,
+		ReqID:   env.ReqID,
+		Code:    rpcResp.Code,
+		Message: rpcResp.ErrorMessage,
+		Data:    protocol.Raw(rpcResp.Payload),
+	})
+}
+
+// gameStream 取得 Game Server 的串流 (未啟用或不可用時回傳 nil)
+func (h *WebsocketHandler) gameStream(targetAddr string) *game_client.Stream {
+	if h.streams == nil {
+		return nil
+	}
+	return h.streams.get(targetAddr)
 }
 
 // -------------------------------------------------------------
 // Helpers
 // -------------------------------------------------------------
 
-func (_ *WebsocketHandler) stopTimer(conn wss.Client, tagKey string) {
-	if v, ok := conn.GetTag(tagKey); ok {
-		if t, ok := v.(*time.Timer); ok {
-			t.Stop()
+// currentRoute 取得連線目前所在的遊戲路由
+// targetEndpoint 為 Stateful 固定路由 (Stateless 為空)，gameID 為 0 代表未在遊戲中
+func (_ *WebsocketHandler) currentRoute(conn wss.Client) (targetEndpoint string, gameID int32) {
+	if target, ok := conn.GetTag("target_endpoint"); ok {
+		if endpoint, ok := target.(string); ok {
+			targetEndpoint = endpoint
+		}
+	}
+	if gameIDStr, ok := conn.GetTag("current_game_id"); ok {
+		if str, ok := gameIDStr.(string); ok {
+			_, _ = fmt.Sscanf(str, "%d", &gameID)
+		}
+	}
+	return targetEndpoint, gameID
+}
+
+// notifyQuit 非同步通知 Game Server 玩家離開
+// 若無固定路由 (Stateless)，則向 Central 取得一個可用實例
+func (h *WebsocketHandler) notifyQuit(targetEndpoint string, gameID int32, uid, sessionID string) {
+	ep := h.quitEndpoint(targetEndpoint, gameID, sessionID)
+	if ep == "" {
+		return
+	}
+
+	// 非同步通知，避免阻塞斷線流程
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.quit(context.Background(), ep, uid, sessionID)
+	}()
+}
+
+// quitEndpoint 取得通知玩家離開的 Game Server 位址 (找不到時回傳空字串)
+// Stateful 使用固定路由，Stateless 向 Central 取得一個可用實例
+func (h *WebsocketHandler) quitEndpoint(targetEndpoint string, gameID int32, sessionID string) string {
+	if targetEndpoint == "" && gameID != 0 {
+		// 嘗試向 Central 取得一個可用實例
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		route, err := h.centralClient.GetRoute(ctx, &domain.RouteRequest{GameID: gameID})
+		cancel()
+
+		if err == nil && route.Endpoint != "" {
+			targetEndpoint = route.Endpoint
+			slog.Debug("Resolved stateless route for OnPlayerQuit", "gameID", gameID, "endpoint", targetEndpoint)
+		} else {
+			slog.Warn("Failed to resolve route for OnPlayerQuit", "gameID", gameID, "error", err)
 		}
 	}
+
+	if targetEndpoint == "" {
+		slog.Debug("OnPlayerQuit skipped: no target endpoint found", "id", sessionID)
+	}
+	return targetEndpoint
 }
 
-func (_ *WebsocketHandler) getUserID(conn wss.Client) string {
-	if v, ok := conn.GetTag("user_id"); ok {
+// quit 通知 Game Server 玩家離開 (OnPlayerQuit)
+func (h *WebsocketHandler) quit(ctx context.Context, ep, uid, sessionID string) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rpcConn, err := h.grpcPool.GetConnection(ep)
+	if err != nil {
+		slog.Warn("Failed to get connection for OnPlayerQuit", "endpoint", ep, "error", err)
+		return
+	}
+
+	// 使用 SDK
+	client := game_client.NewClient(rpcConn)
+	_, err = client.Quit(ctx, uid, sessionID)
+
+	if err != nil {
+		slog.Warn("OnPlayerQuit failed", "endpoint", ep, "error", err)
+	} else {
+		slog.Info("OnPlayerQuit sent", "uid", uid, "endpoint", ep)
+	}
+}
+
+// parkSession 保留已登入連線的會話狀態，等待玩家重連
+// 回傳 false 代表未保留 (未啟用 Resume、未登入或寫入失敗)，呼叫端應立即處理離線
+func (h *WebsocketHandler) parkSession(conn wss.Client) bool {
+	if h.resumeStore == nil {
+		return false
+	}
+
+	uid := h.getUserID(conn)
+	token := h.getStringTag(conn, "resume_token")
+	if uid == "" || token == "" {
+		return false
+	}
+
+	targetEndpoint, gameID := h.currentRoute(conn)
+	state := &domain.ResumeState{
+		UserID:         uid,
+		SessionID:      conn.ID(),
+		ConnectorHost:  h.endpoint,
+		GameID:         gameID,
+		TargetEndpoint: targetEndpoint,
+		Version:        h.getStringTag(conn, "game_version"),
+		ParkedAt:       time.Now().Unix(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	if err := h.resumeStore.Park(ctx, token, state, h.resumeGrace); err != nil {
+		slog.Warn("Failed to park session, quitting immediately", "id", conn.ID(), "error", err)
+		return false
+	}
+	return true
+}
+
+// joinRouteRequest 建立新加入遊戲的路由查詢 (由 Central 依版本路由規則分配版本)
+func (h *WebsocketHandler) joinRouteRequest(conn wss.Client, gameID int32) *domain.RouteRequest {
+	return &domain.RouteRequest{
+		GameID:        gameID,
+		UserID:        h.getUserID(conn),
+		ClientVersion: h.getStringTag(conn, "client_version"),
+	}
+}
+
+// routeRequest 建立已在遊戲中的路由查詢，固定使用進入遊戲時分配的版本
+func (h *WebsocketHandler) routeRequest(conn wss.Client, gameID int32) *domain.RouteRequest {
+	req := h.joinRouteRequest(conn, gameID)
+	req.Version = h.getStringTag(conn, "game_version")
+	return req
+}
+
+// setGameVersion 記錄會話分配到的遊戲實例版本 (空字串代表未區分版本)
+func (h *WebsocketHandler) setGameVersion(conn wss.Client, version string) {
+	if version != "" {
+		conn.SetTag("game_version", version)
+	}
+}
+
+// issueResumeToken 為連線發行新的 Resume Token (未啟用 Resume 時回傳空字串)
+func (h *WebsocketHandler) issueResumeToken(conn wss.Client) string {
+	if h.resumeStore == nil {
+		return ""
+	}
+	token := uuid.NewString()
+	conn.SetTag("resume_token", token)
+	return token
+}
+
+// bindPresence 更新連線的在線狀態 (未啟用時不做任何事)
+func (h *WebsocketHandler) bindPresence(conn wss.Client, gameID int32) {
+	if h.presence != nil {
+		h.presence.bind(conn.ID(), h.getUserID(conn), gameID)
+	}
+}
+
+// unbindPresence 移除連線的在線狀態 (未登入或未啟用時不做任何事)
+func (h *WebsocketHandler) unbindPresence(conn wss.Client) {
+	if h.presence != nil {
+		h.presence.unbind(conn.ID())
+	}
+}
+
+// startEnterGameTimer 啟動 Enter Game Timer (3分鐘內必須進入遊戲)
+func (_ *WebsocketHandler) startEnterGameTimer(conn wss.Client) {
+	enterGameTimer := time.AfterFunc(3*time.Minute, func() {
+		slog.Info("Enter Game timeout, kicking client", "id", conn.ID())
+		_ = conn.Kick("Enter Game Timeout")
+	})
+	conn.SetTag("enter_game_timer", enterGameTimer)
+}
+
+func (_ *WebsocketHandler) getStringTag(conn wss.Client, key string) string {
+	if v, ok := conn.GetTag(key); ok {
 		if s, ok := v.(string); ok {
 			return s
 		}
@@ -381,20 +1031,62 @@ func (_ *WebsocketHandler) getUserID(conn wss.Client) string {
 	return ""
 }
 
-func (_ *WebsocketHandler) sendError(conn wss.Client, action protocol.ConnectorProtocol, msg string) {
-	resp := protocol.Response{
-		Action: action,
-		Error:  msg,
+func (_ *WebsocketHandler) stopTimer(conn wss.Client, tagKey string) {
+	if v, ok := conn.GetTag(tagKey); ok {
+		if t, ok := v.(*time.Timer); ok {
+			t.Stop()
+		}
 	}
-	bytes, _ := json.Marshal(resp)
-	_ = conn.SendMessage(string(bytes))
 }
 
-func (_ *WebsocketHandler) sendResponse(conn wss.Client, action protocol.ConnectorProtocol, data any) {
-	resp := protocol.Response{
+func (h *WebsocketHandler) getUserID(conn wss.Client) string {
+	return h.getStringTag(conn, "user_id")
+}
+
+func (h *WebsocketHandler) sendError(conn wss.Client, action protocol.ConnectorProtocol, reqID string, code proto.ErrorCode, msg string) {
+	h.send(conn, &protocol.Response{
+		Action:  action,
+		ReqID:   reqID,
+		Code:    code,
+		Message: msg,
+	})
+}
+
+// sendMaintenance 回應請求因維護被拒 (data 附上維護通知)
+func (h *WebsocketHandler) sendMaintenance(conn wss.Client, action protocol.ConnectorProtocol, reqID string, info *centralRPC.MaintenanceInfo) {
+	h.send(conn, &protocol.Response{
+		Action:  action,
+		ReqID:   reqID,
+		Code:    proto.ErrorCode_MAINTENANCE,
+		Message: "Under Maintenance",
+		Data:    toMaintenanceNotice(toMaintenance(info), true),
+	})
+}
+
+func (h *WebsocketHandler) sendResponse(conn wss.Client, action protocol.ConnectorProtocol, reqID string, data any) {
+	h.send(conn, &protocol.Response{
 		Action: action,
+		ReqID:  reqID,
 		Data:   data,
+	})
+}
+
+// send 以請求回應 (type: response) 的形式，依連線協商的子協議編碼並發送
+func (h *WebsocketHandler) send(conn wss.Client, resp *protocol.Response) {
+	resp.Type = protocol.TypeResponse
+	bytes, err := protocol.CodecFor(conn.Subprotocol()).Encode(resp)
+	if err != nil {
+		slog.Error("Failed to encode response", "action", resp.Action, "error", err)
+		return
+	}
+	h.write(conn, bytes)
+}
+
+// write 依連線模式選擇訊框類型 (Text / Binary) 發送原始資料
+func (_ *WebsocketHandler) write(conn wss.Client, frame []byte) {
+	if protocol.CodecFor(conn.Subprotocol()).Binary() {
+		_ = conn.SendBinary(frame)
+		return
 	}
-	bytes, _ := json.Marshal(resp)
-	_ = conn.SendMessage(string(bytes))
+	_ = conn.SendMessage(string(frame))
 }
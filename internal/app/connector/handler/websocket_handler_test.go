@@ -1,17 +1,30 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	gproto "google.golang.org/protobuf/proto"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
 	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/session"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	central_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/central"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
 	mock_handlers "github.com/JoeShih716/go-k8s-game-server/test/mocks/handlers"
 	mock_wss "github.com/JoeShih716/go-k8s-game-server/test/mocks/pkg/wss"
 )
@@ -101,11 +114,13 @@ func TestWebsocketHandler_Login(t *testing.T) {
 	})
 
 	// Login Expectations
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()      // JSON mode
 	mockWssClient.EXPECT().GetTag("user_id").Return(nil, false)     // Check not logged in
 	mockWssClient.EXPECT().GetTag("login_timer").Return(nil, false) // Stop timer
 
 	// Central Login verification
-	mockCentral.EXPECT().Login(gomock.Any(), "valid-token").Return(&centralRPC.LoginResponse{
+	mockCentral.EXPECT().Login(gomock.Any(), "valid-token", gomock.Any(), "connector-1").Return(&centralRPC.LoginResponse{
+		Success:  true,
 		UserId:   "user-100",
 		Nickname: "TestUser",
 		Balance:  "1000",
@@ -126,6 +141,86 @@ func TestWebsocketHandler_Login(t *testing.T) {
 	handler.OnMessage(mockWssClient, msg)
 }
 
+func TestWebsocketHandler_Login_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mgr := session.NewManager()
+
+	handler := NewWebsocketHandler(mgr, mockPool, mockCentral, "connector-1")
+
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().SetTag("login_timer", gomock.Any())
+	handler.OnConnect(mockWssClient)
+
+	msg, _ := json.Marshal(protocol.Envelope{
+		Action:  protocol.ActionLogin,
+		Payload: json.RawMessage(`{"token":"expired-token"}`),
+	})
+
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return(nil, false)
+	mockWssClient.EXPECT().GetTag("login_timer").Return(nil, false)
+
+	// Central answers with success=false (not a transport error)
+	mockCentral.EXPECT().Login(gomock.Any(), "expired-token", gomock.Any(), "connector-1").Return(&centralRPC.LoginResponse{
+		Success:      false,
+		Code:         proto.ErrorCode_AUTH_FAILED,
+		ErrorMessage: "invalid token: expired",
+	}, nil)
+
+	// Must not bind the session
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).DoAndReturn(func(msg string) error {
+		assert.Contains(t, msg, "Authentication Failed")
+		return nil
+	})
+	mockWssClient.EXPECT().Kick("Auth Failed").AnyTimes()
+
+	// Act
+	handler.OnMessage(mockWssClient, msg)
+}
+
+func TestWebsocketHandler_Login_Maintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	handler := NewWebsocketHandler(session.NewManager(), mock_handlers.NewMockGRPCPool(ctrl), mockCentral, "connector-1")
+
+	msg, _ := json.Marshal(protocol.Envelope{
+		Action:  protocol.ActionLogin,
+		Payload: json.RawMessage(`{"token":"player-token"}`),
+	})
+
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return(nil, false)
+	mockWssClient.EXPECT().GetTag("login_timer").Return(nil, false)
+
+	// 全服維護中
+	mockCentral.EXPECT().Login(gomock.Any(), "player-token", "sess-1", "connector-1").Return(&centralRPC.LoginResponse{
+		Code:         proto.ErrorCode_MAINTENANCE,
+		ErrorMessage: "server is under maintenance",
+		Maintenance:  &centralRPC.MaintenanceInfo{Message: "scheduled upgrade", StartAt: 1800000000},
+	}, nil)
+
+	// 回應附上維護通知後斷線 (不綁定 Session)
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).DoAndReturn(func(msg string) error {
+		assert.Contains(t, msg, `"action":"login"`)
+		assert.Contains(t, msg, `"code":5`)
+		assert.Contains(t, msg, `"message":"scheduled upgrade"`)
+		assert.Contains(t, msg, `"game_id":0`)
+		return nil
+	})
+	mockWssClient.EXPECT().Kick("Under Maintenance").AnyTimes()
+
+	handler.OnMessage(mockWssClient, msg)
+}
+
 func TestWebsocketHandler_EnterGame(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -150,12 +245,15 @@ func TestWebsocketHandler_EnterGame(t *testing.T) {
 	})
 
 	// Expectations
-	mockWssClient.EXPECT().GetTag("current_game_id").Return(nil, false)  // Not in game
-	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true)    // Logged in
-	mockWssClient.EXPECT().GetTag("enter_game_timer").Return(nil, false) // Stop timer
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()                 // JSON mode
+	mockWssClient.EXPECT().GetTag("current_game_id").Return(nil, false)        // Not in game
+	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true).Times(2) // Logged in
+	mockWssClient.EXPECT().GetTag("enter_game_timer").Return(nil, false)       // Stop timer
 
-	// Central GetRoute
-	mockCentral.EXPECT().GetRoute(gomock.Any(), int32(1001)).Return("node-1:8090", proto.ServiceType_STATELESS, nil)
+	// Central JoinRoute
+	mockWssClient.EXPECT().GetTag("client_version").Return("1.4.2", true)
+	mockCentral.EXPECT().JoinRoute(gomock.Any(), &domain.RouteRequest{GameID: 1001, UserID: "user-100", ClientVersion: "1.4.2"}).
+		Return(&ports.Route{Endpoint: "node-1:8090", ServiceType: proto.ServiceType_STATELESS}, nil)
 
 	// Game Server OnPlayerJoin (gRPC)
 	// We need to mock the gRPC Client.
@@ -186,3 +284,403 @@ func TestWebsocketHandler_EnterGame(t *testing.T) {
 	// Act
 	handler.OnMessage(mockWssClient, msg)
 }
+
+func TestWebsocketHandler_Login_Binary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mgr := session.NewManager()
+
+	handler := NewWebsocketHandler(mgr, mockPool, mockCentral, "connector-1")
+
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().SetTag("login_timer", gomock.Any())
+	handler.OnConnect(mockWssClient)
+
+	// Prepare Protobuf Login Frame
+	msg, _ := gproto.Marshal(&connectorRPC.ClientEnvelope{
+		Action:  string(protocol.ActionLogin),
+		ReqId:   "req-1",
+		Payload: []byte(`{"token":"valid-token"}`),
+	})
+
+	// Binary mode negotiated
+	mockWssClient.EXPECT().Subprotocol().Return(protocol.SubprotocolProto).AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return(nil, false)
+	mockWssClient.EXPECT().GetTag("login_timer").Return(nil, false)
+
+	mockCentral.EXPECT().Login(gomock.Any(), "valid-token", gomock.Any(), "connector-1").Return(&centralRPC.LoginResponse{
+		Success:  true,
+		UserId:   "user-100",
+		Nickname: "TestUser",
+		Balance:  "1000",
+	}, nil)
+
+	mockWssClient.EXPECT().SetTag("user_id", "user-100")
+	// Response must be a binary protobuf frame echoing req_id
+	mockWssClient.EXPECT().SendBinary(gomock.Any()).DoAndReturn(func(frame []byte) error {
+		var resp connectorRPC.ClientResponse
+		assert.NoError(t, gproto.Unmarshal(frame, &resp))
+		assert.Equal(t, string(protocol.ActionLogin), resp.Action)
+		assert.Equal(t, "req-1", resp.ReqId)
+		assert.Equal(t, protocol.TypeResponse, resp.Type)
+		assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+		assert.Contains(t, string(resp.Data), "user-100")
+		return nil
+	})
+	mockWssClient.EXPECT().SetTag("enter_game_timer", gomock.Any())
+
+	// Act
+	handler.OnMessage(mockWssClient, msg)
+}
+
+func TestWebsocketHandler_OnDisconnect_ParksSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mockStore := mock_ports.NewMockResumeStore(ctrl)
+	mgr := session.NewManager()
+
+	handler := NewWebsocketHandler(mgr, mockPool, mockCentral, "connector-1", WithResume(mockStore, 30*time.Second))
+
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().SetTag("login_timer", gomock.Any())
+	handler.OnConnect(mockWssClient)
+
+	// Expectations: logged in and playing a stateful game
+	mockWssClient.EXPECT().GetTag("login_timer").Return(nil, false)
+	mockWssClient.EXPECT().GetTag("enter_game_timer").Return(nil, false)
+	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true)
+	mockWssClient.EXPECT().GetTag("resume_token").Return("token-1", true)
+	mockWssClient.EXPECT().GetTag("target_endpoint").Return("node-1:8090", true)
+	mockWssClient.EXPECT().GetTag("current_game_id").Return("20000", true)
+	mockWssClient.EXPECT().GetTag("game_version").Return("v2", true)
+
+	// Session is parked instead of sending OnPlayerQuit (no GRPCPool calls)
+	mockStore.EXPECT().Park(gomock.Any(), "token-1", gomock.Any(), 30*time.Second).
+		DoAndReturn(func(_ context.Context, _ string, state *domain.ResumeState, _ time.Duration) error {
+			assert.Equal(t, "user-100", state.UserID)
+			assert.Equal(t, "sess-1", state.SessionID)
+			assert.Equal(t, "connector-1", state.ConnectorHost)
+			assert.Equal(t, int32(20000), state.GameID)
+			assert.Equal(t, "node-1:8090", state.TargetEndpoint)
+			assert.Equal(t, "v2", state.Version)
+			return nil
+		})
+
+	// Act
+	handler.OnDisconnect(mockWssClient)
+
+	// Assert
+	assert.Equal(t, int64(0), mgr.Count())
+}
+
+func TestWebsocketHandler_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mockStore := mock_ports.NewMockResumeStore(ctrl)
+	mgr := session.NewManager()
+
+	handler := NewWebsocketHandler(mgr, mockPool, mockCentral, "connector-2", WithResume(mockStore, 30*time.Second))
+
+	mockWssClient.EXPECT().ID().Return("sess-2").AnyTimes()
+	mockWssClient.EXPECT().SetTag("login_timer", gomock.Any())
+	handler.OnConnect(mockWssClient)
+
+	msg, _ := json.Marshal(protocol.Envelope{
+		Action:  protocol.ActionResume,
+		Payload: json.RawMessage(`{"resume_token":"token-1"}`),
+	})
+
+	// Expectations
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return(nil, false)
+	mockWssClient.EXPECT().GetTag("login_timer").Return(nil, false)
+
+	// Parked session was not in a game
+	mockStore.EXPECT().Claim(gomock.Any(), "token-1").Return(&domain.ResumeState{
+		UserID:    "user-100",
+		SessionID: "sess-1",
+	}, nil)
+
+	mockWssClient.EXPECT().SetTag("user_id", "user-100")
+	mockWssClient.EXPECT().SetTag("enter_game_timer", gomock.Any())
+	mockWssClient.EXPECT().SetTag("resume_token", gomock.Any())
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).DoAndReturn(func(msg string) error {
+		var resp protocol.Response
+		assert.NoError(t, json.Unmarshal([]byte(msg), &resp))
+		assert.Equal(t, protocol.ActionResume, resp.Action)
+		assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+		assert.Contains(t, msg, "user-100")
+		assert.Contains(t, msg, "resume_token")
+		return nil
+	})
+
+	// Act
+	handler.OnMessage(mockWssClient, msg)
+}
+
+func TestWebsocketHandler_Resume_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	mockStore := mock_ports.NewMockResumeStore(ctrl)
+	mgr := session.NewManager()
+
+	handler := NewWebsocketHandler(mgr, mockPool, mockCentral, "connector-2", WithResume(mockStore, 30*time.Second))
+
+	mockWssClient.EXPECT().ID().Return("sess-2").AnyTimes()
+	mockWssClient.EXPECT().SetTag("login_timer", gomock.Any())
+	handler.OnConnect(mockWssClient)
+
+	msg, _ := json.Marshal(protocol.Envelope{
+		Action:  protocol.ActionResume,
+		Payload: json.RawMessage(`{"resume_token":"token-1"}`),
+	})
+
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return(nil, false)
+	mockStore.EXPECT().Claim(gomock.Any(), "token-1").Return(nil, ports.ErrResumeNotFound)
+
+	// Login timer keeps running, client must fall back to login
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).DoAndReturn(func(msg string) error {
+		assert.Contains(t, msg, "Resume Failed")
+		return nil
+	})
+
+	// Act
+	handler.OnMessage(mockWssClient, msg)
+}
+
+func TestWebsocketHandler_Leave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	handler := NewWebsocketHandler(session.NewManager(), mockPool, mockCentral, "connector-1")
+
+	msg, _ := json.Marshal(protocol.Envelope{Action: protocol.ActionLeave})
+
+	// Setup: 已登入並在 Stateful 遊戲 1001 中
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true).AnyTimes()
+	mockWssClient.EXPECT().GetTag("current_game_id").Return("1001", true).AnyTimes()
+	mockWssClient.EXPECT().GetTag("target_endpoint").Return("node-1:8090", true).AnyTimes()
+
+	// 清除路由 Tag，同步通知原本的 Game Server (OnPlayerQuit)
+	mockWssClient.EXPECT().DelTag("current_game_id")
+	mockWssClient.EXPECT().DelTag("service_type")
+	mockWssClient.EXPECT().DelTag("target_endpoint")
+	mockWssClient.EXPECT().DelTag("game_version")
+	mockPool.EXPECT().GetConnection("node-1:8090").Return(nil, fmt.Errorf("mock connection error"))
+
+	// 回到大廳，重新啟動 Enter Game Timer
+	mockWssClient.EXPECT().SetTag("enter_game_timer", gomock.Any())
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).Do(func(msg string) {
+		var resp protocol.Response
+		assert.NoError(t, json.Unmarshal([]byte(msg), &resp))
+		assert.Equal(t, protocol.ActionLeave, resp.Action)
+		assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+		assert.Contains(t, msg, `"game_id":1001`)
+	})
+
+	handler.OnMessage(mockWssClient, msg)
+}
+
+func TestWebsocketHandler_Switch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	handler := NewWebsocketHandler(session.NewManager(), mockPool, mockCentral, "connector-1")
+
+	switchTo := func(gameID int32) []byte {
+		msg, _ := json.Marshal(protocol.Envelope{
+			Action:  protocol.ActionSwitch,
+			Payload: json.RawMessage(fmt.Sprintf(`{"game_id":%d}`, gameID)),
+		})
+		return msg
+	}
+
+	// Setup: 已登入並在 Stateless 遊戲 1001 中
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true).AnyTimes()
+	mockWssClient.EXPECT().GetTag("current_game_id").Return("1001", true).AnyTimes()
+	mockWssClient.EXPECT().GetTag("target_endpoint").Return(nil, false).AnyTimes()
+	mockWssClient.EXPECT().GetTag("client_version").Return(nil, false).AnyTimes()
+	join2002 := &domain.RouteRequest{GameID: 2002, UserID: "user-100"}
+
+	// 切換到目前的遊戲
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).Do(func(msg string) {
+		assert.Contains(t, msg, "Already In Game")
+	})
+	handler.OnMessage(mockWssClient, switchTo(1001))
+
+	// 新遊戲無可用服務時留在原本的遊戲 (不清除路由 Tag)
+	mockCentral.EXPECT().JoinRoute(gomock.Any(), join2002).Return(nil, fmt.Errorf("service not found"))
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).Do(func(msg string) {
+		assert.Contains(t, msg, "Game Service Unavailable")
+	})
+	handler.OnMessage(mockWssClient, switchTo(2002))
+
+	// 新遊戲維護中時留在原本的遊戲，回應附上維護通知
+	mockCentral.EXPECT().JoinRoute(gomock.Any(), join2002).Return(nil, &central_sdk.MaintenanceError{
+		Info: &centralRPC.MaintenanceInfo{GameId: 2002, Message: "upgrading", EndAt: 1900000000},
+	})
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).Do(func(msg string) {
+		var resp struct {
+			Action protocol.ConnectorProtocol `json:"action"`
+			Code   proto.ErrorCode            `json:"code"`
+			Data   protocol.MaintenanceNotice `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(msg), &resp))
+		assert.Equal(t, protocol.ActionSwitch, resp.Action)
+		assert.Equal(t, proto.ErrorCode_MAINTENANCE, resp.Code)
+		assert.Equal(t, protocol.MaintenanceNotice{GameID: 2002, Message: "upgrading", EndAt: 1900000000, Started: true}, resp.Data)
+	})
+	handler.OnMessage(mockWssClient, switchTo(2002))
+
+	// 離開原本的遊戲後，進入新遊戲失敗則回到大廳
+	gomock.InOrder(
+		mockCentral.EXPECT().JoinRoute(gomock.Any(), join2002).Return(&ports.Route{Endpoint: "node-2:8090", ServiceType: proto.ServiceType_STATEFUL}, nil),
+		mockCentral.EXPECT().GetRoute(gomock.Any(), &domain.RouteRequest{GameID: 1001}).
+			Return(&ports.Route{Endpoint: "node-1:8090", ServiceType: proto.ServiceType_STATELESS}, nil), // OnPlayerQuit
+	)
+	mockWssClient.EXPECT().DelTag("current_game_id")
+	mockWssClient.EXPECT().DelTag("service_type")
+	mockWssClient.EXPECT().DelTag("target_endpoint")
+	mockWssClient.EXPECT().DelTag("game_version")
+	mockPool.EXPECT().GetConnection("node-1:8090").Return(nil, fmt.Errorf("mock connection error"))
+	mockWssClient.EXPECT().GetTag("enter_game_timer").Return(nil, false)
+	mockPool.EXPECT().GetConnection("node-2:8090").Return(nil, fmt.Errorf("mock connection error"))
+	mockWssClient.EXPECT().SetTag("enter_game_timer", gomock.Any())
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).Do(func(msg string) {
+		var resp protocol.Response
+		assert.NoError(t, json.Unmarshal([]byte(msg), &resp))
+		assert.Equal(t, protocol.ActionSwitch, resp.Action)
+		assert.Equal(t, proto.ErrorCode_GAME_UNAVAILABLE, resp.Code)
+		assert.Contains(t, resp.Message, "Game Server Unavailable")
+	})
+	handler.OnMessage(mockWssClient, switchTo(2002))
+}
+
+// TestWebsocketHandler_Forward_PinsVersion 測試 Stateless 遊戲轉發時沿用進入遊戲時分配的版本
+func TestWebsocketHandler_Forward_PinsVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	handler := NewWebsocketHandler(session.NewManager(), mockPool, mockCentral, "connector-1")
+
+	msg, _ := json.Marshal(protocol.Envelope{Action: "spin", ReqID: "req-1"})
+
+	// Setup: 已登入並在 Stateless 遊戲 1001 中，分配到 v2 版本
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().Subprotocol().Return("").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true).AnyTimes()
+	mockWssClient.EXPECT().GetTag("target_endpoint").Return(nil, false).Times(2)   // 路由 + 追蹤屬性
+	mockWssClient.EXPECT().GetTag("current_game_id").Return("1001", true).Times(2) // 路由 + 追蹤屬性
+	mockWssClient.EXPECT().GetTag("client_version").Return("1.4.2", true)
+	mockWssClient.EXPECT().GetTag("game_version").Return("v2", true)
+
+	mockCentral.EXPECT().GetRoute(gomock.Any(), &domain.RouteRequest{GameID: 1001, UserID: "user-100", ClientVersion: "1.4.2", Version: "v2"}).
+		Return(&ports.Route{Endpoint: "node-2:8090", ServiceType: proto.ServiceType_STATELESS, Version: "v2"}, nil)
+	mockPool.EXPECT().GetConnection("node-2:8090").Return(nil, fmt.Errorf("mock connection error"))
+	// 錯誤回應帶回 req_id 與錯誤碼
+	mockWssClient.EXPECT().SendMessage(gomock.Any()).Do(func(msg string) {
+		var resp protocol.Response
+		assert.NoError(t, json.Unmarshal([]byte(msg), &resp))
+		assert.Equal(t, protocol.TypeResponse, resp.Type)
+		assert.Equal(t, protocol.ConnectorProtocol("spin"), resp.Action)
+		assert.Equal(t, "req-1", resp.ReqID)
+		assert.Equal(t, proto.ErrorCode_SERVER_ERROR, resp.Code)
+		assert.Equal(t, "Backend Connection Failed", resp.Message)
+	})
+
+	handler.OnMessage(mockWssClient, msg)
+}
+
+// fakeGameServer 以固定的錯誤碼回應 OnPlayerJoin
+type fakeGameServer struct {
+	gameRPC.UnimplementedGameRPCServer
+	code proto.ErrorCode
+}
+
+func (f *fakeGameServer) OnPlayerJoin(context.Context, *gameRPC.JoinReq) (*gameRPC.JoinResp, error) {
+	return &gameRPC.JoinResp{Code: f.code}, nil
+}
+
+// startFakeGameServer 啟動 Game Server 並回傳連線
+func startFakeGameServer(t *testing.T, code proto.ErrorCode) *grpc.ClientConn {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	gameRPC.RegisterGameRPCServer(server, &fakeGameServer{code: code})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// TestWebsocketHandler_JoinGame_RerouteOnDraining Game Server 排空中時重新取得路由並改送其他實例
+func TestWebsocketHandler_JoinGame_RerouteOnDraining(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWssClient := mock_wss.NewMockClient(ctrl)
+	mockPool := mock_handlers.NewMockGRPCPool(ctrl)
+	mockCentral := mock_handlers.NewMockCentralClient(ctrl)
+	handler := NewWebsocketHandler(session.NewManager(), mockPool, mockCentral, "connector-1")
+
+	mockWssClient.EXPECT().ID().Return("sess-1").AnyTimes()
+	mockWssClient.EXPECT().GetTag("user_id").Return("user-100", true).AnyTimes()
+	mockWssClient.EXPECT().GetTag("client_version").Return(nil, false).AnyTimes()
+
+	mockPool.EXPECT().GetConnection("node-1:8090").Return(startFakeGameServer(t, proto.ErrorCode_DRAINING), nil)
+	mockPool.EXPECT().GetConnection("node-2:8090").Return(startFakeGameServer(t, proto.ErrorCode_SUCCESS), nil)
+	mockCentral.EXPECT().JoinRoute(gomock.Any(), &domain.RouteRequest{GameID: 1001, UserID: "user-100"}).
+		Return(&ports.Route{Endpoint: "node-2:8090", ServiceType: proto.ServiceType_STATEFUL}, nil)
+
+	// 路由資訊記錄為新的實例
+	mockWssClient.EXPECT().SetTag("current_game_id", "1001")
+	mockWssClient.EXPECT().SetTag("service_type", proto.ServiceType_STATEFUL)
+	mockWssClient.EXPECT().SetTag("target_endpoint", "node-2:8090")
+
+	code, _ := handler.joinGame(context.Background(), mockWssClient, "user-100", 1001,
+		&ports.Route{Endpoint: "node-1:8090", ServiceType: proto.ServiceType_STATEFUL})
+	assert.Equal(t, proto.ErrorCode_SUCCESS, code)
+
+	// Central 仍回傳同一實例時不再重試，回報 DRAINING
+	mockPool.EXPECT().GetConnection("node-1:8090").Return(startFakeGameServer(t, proto.ErrorCode_DRAINING), nil)
+	mockCentral.EXPECT().JoinRoute(gomock.Any(), gomock.Any()).
+		Return(&ports.Route{Endpoint: "node-1:8090", ServiceType: proto.ServiceType_STATEFUL}, nil)
+
+	code, _ = handler.joinGame(context.Background(), mockWssClient, "user-100", 1001,
+		&ports.Route{Endpoint: "node-1:8090", ServiceType: proto.ServiceType_STATEFUL})
+	assert.Equal(t, proto.ErrorCode_DRAINING, code)
+}
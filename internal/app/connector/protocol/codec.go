@@ -0,0 +1,96 @@
+package protocol
+
+import (
+	"encoding/json"
+
+	gproto "google.golang.org/protobuf/proto"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+)
+
+// WebSocket 子協議名稱 (Sec-WebSocket-Protocol)
+// 未指定子協議的連線一律視為 JSON 模式，確保既有 Web Client 相容。
+const (
+	SubprotocolJSON  = "game.json"  // 文字訊框 + JSON 封包
+	SubprotocolProto = "game.proto" // 二進位訊框 + Protobuf 封包 (connectorRPC.ClientEnvelope)
+)
+
+// Subprotocols 回傳 Connector 支援的子協議 (依優先順序)
+func Subprotocols() []string {
+	return []string{SubprotocolProto, SubprotocolJSON}
+}
+
+// Codec 定義 Client 封包的編解碼方式
+type Codec interface {
+	// Decode 將收到的訊框解析為 Envelope
+	Decode(frame []byte) (*Envelope, error)
+	// Encode 將 Response 編碼為要發送的訊框
+	Encode(resp *Response) ([]byte, error)
+	// Binary 是否使用二進位訊框發送
+	Binary() bool
+}
+
+// CodecFor 根據協商出的子協議取得對應的 Codec
+func CodecFor(subprotocol string) Codec {
+	if subprotocol == SubprotocolProto {
+		return protoCodec{}
+	}
+	return jsonCodec{}
+}
+
+// jsonCodec JSON 模式 (預設)
+type jsonCodec struct{}
+
+func (jsonCodec) Decode(frame []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(frame, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+func (jsonCodec) Encode(resp *Response) ([]byte, error) {
+	return json.Marshal(resp)
+}
+
+func (jsonCodec) Binary() bool { return false }
+
+// protoCodec Protobuf 二進位模式
+// 外層為 connectorRPC.ClientEnvelope / ClientResponse，
+// 內層 payload (Connector 本地指令) 沿用 JSON 結構以共用同一套處理邏輯。
+type protoCodec struct{}
+
+func (protoCodec) Decode(frame []byte) (*Envelope, error) {
+	var pb connectorRPC.ClientEnvelope
+	if err := gproto.Unmarshal(frame, &pb); err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Action:  ConnectorProtocol(pb.GetAction()),
+		ReqID:   pb.GetReqId(),
+		Payload: pb.GetPayload(),
+	}, nil
+}
+
+func (protoCodec) Encode(resp *Response) ([]byte, error) {
+	pb := &connectorRPC.ClientResponse{
+		Type:    resp.Type,
+		Action:  string(resp.Action),
+		ReqId:   resp.ReqID,
+		Code:    resp.Code,
+		Message: resp.Message,
+	}
+	if raw, ok := resp.Data.(RawData); ok {
+		// Game Server 的資料原樣帶入 (可能本身即為 Protobuf)
+		pb.Data = raw
+	} else if resp.Data != nil {
+		data, err := json.Marshal(resp.Data)
+		if err != nil {
+			return nil, err
+		}
+		pb.Data = data
+	}
+	return gproto.Marshal(pb)
+}
+
+func (protoCodec) Binary() bool { return true }
@@ -0,0 +1,78 @@
+package protocol_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gproto "google.golang.org/protobuf/proto"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/connector/protocol"
+)
+
+// TestJSONCodec 測試 JSON 模式的 req_id 對應、錯誤碼與推送封包
+func TestJSONCodec(t *testing.T) {
+	codec := protocol.CodecFor("")
+
+	env, err := codec.Decode([]byte(`{"action":"spin","req_id":"r-7","payload":{"bet":1}}`))
+	require.NoError(t, err)
+	assert.Equal(t, protocol.ConnectorProtocol("spin"), env.Action)
+	assert.Equal(t, "r-7", env.ReqID)
+
+	frame, err := codec.Encode(&protocol.Response{
+		Type:    protocol.TypeResponse,
+		Action:  env.Action,
+		ReqID:   env.ReqID,
+		Code:    proto.ErrorCode_RATE_LIMITED,
+		Message: "Rate Limit Exceeded",
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"response","action":"spin","req_id":"r-7","code":7,"message":"Rate Limit Exceeded"}`, string(frame))
+
+	// Game Server 的資料: JSON 原樣嵌入，文字為字串，其他為 Base64
+	cases := []struct {
+		payload []byte
+		want    string
+	}{
+		{[]byte(`{"win":10}`), `{"type":"push","data":{"win":10}}`},
+		{[]byte("Welcome!"), `{"type":"push","data":"Welcome!"}`},
+		{[]byte{0xff, 0x00}, `{"type":"push","data":"/wA="}`},
+		{nil, `{"type":"push"}`},
+	}
+	for _, c := range cases {
+		frame, err := codec.Encode(protocol.NewPush("", protocol.Raw(c.payload)))
+		require.NoError(t, err)
+		assert.JSONEq(t, c.want, string(frame))
+	}
+}
+
+// TestProtoCodec 測試二進位模式的 Game Server 資料原樣帶入 data
+func TestProtoCodec(t *testing.T) {
+	codec := protocol.CodecFor(protocol.SubprotocolProto)
+
+	frame, err := codec.Encode(protocol.NewPush("", protocol.Raw([]byte{0x08, 0x01})))
+	require.NoError(t, err)
+	var push connectorRPC.ClientResponse
+	require.NoError(t, gproto.Unmarshal(frame, &push))
+	assert.Equal(t, protocol.TypePush, push.Type)
+	assert.Equal(t, []byte{0x08, 0x01}, push.Data)
+
+	frame, err = codec.Encode(&protocol.Response{
+		Type:   protocol.TypeResponse,
+		Action: protocol.ActionEnterGame,
+		ReqID:  "r-1",
+		Code:   proto.ErrorCode_GAME_UNAVAILABLE,
+		Data:   protocol.EnterGameResp{GameID: 1001},
+	})
+	require.NoError(t, err)
+	var resp connectorRPC.ClientResponse
+	require.NoError(t, gproto.Unmarshal(frame, &resp))
+	assert.Equal(t, "r-1", resp.ReqId)
+	assert.Equal(t, proto.ErrorCode_GAME_UNAVAILABLE, resp.Code)
+	var data protocol.EnterGameResp
+	require.NoError(t, json.Unmarshal(resp.Data, &data))
+	assert.Equal(t, int32(1001), data.GameID)
+}
@@ -2,34 +2,82 @@ package protocol
 
 import (
 	"encoding/json"
+	"unicode/utf8"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 )
 
 // ConnectorProtocol 定義指令代碼 (使用 string 方便前端對接)
 type ConnectorProtocol string
 
 const (
-	ActionLogin     ConnectorProtocol = "login" // 登入
-	ActionEnterGame ConnectorProtocol = "enter" // 進入遊戲
+	ActionLogin     ConnectorProtocol = "login"  // 登入
+	ActionEnterGame ConnectorProtocol = "enter"  // 進入遊戲
+	ActionResume    ConnectorProtocol = "resume" // 斷線重連 (憑 Resume Token 恢復會話)
+	ActionLeave     ConnectorProtocol = "leave"  // 離開目前的遊戲 (保持連線)
+	ActionSwitch    ConnectorProtocol = "switch" // 切換到其他遊戲 (離開目前的遊戲並進入新遊戲)
+
+	ActionMaintenance ConnectorProtocol = "maintenance" // 維護通知 (Server 主動推送，或附於被維護拒絕的回應中)
+)
+
+// 封包類型 (Response.Type)，讓 Client 區分請求的回應與 Server 主動推送
+const (
+	TypeResponse = "response" // 對應某個請求的回應 (帶回 req_id)
+	TypePush     = "push"     // Server 主動推送 (Game Server 的 Peer.Send、叢集推播、維護通知)
 )
 
 // Envelope 基礎封包結構 (所有請求的外層包裝)
 type Envelope struct {
 	Action  ConnectorProtocol `json:"action"`            // 指令代碼
+	ReqID   string            `json:"req_id,omitempty"`  // 客戶端請求 ID (選填，回應時原樣帶回)
 	Payload json.RawMessage   `json:"payload,omitempty"` // 具體請求內容
 }
 
-// Response 通用回應結構 (所有回應的外層包裝)
+// Response 通用回應結構 (所有回應與推送的外層包裝)
 type Response struct {
-	Action ConnectorProtocol `json:"action"`          // 對應的指令代碼
-	Data   any               `json:"data,omitempty"`  // 成功時的資料
-	Error  string            `json:"error,omitempty"` // 失敗時的錯誤訊息
+	Type    string            `json:"type"`              // 封包類型: response / push
+	Action  ConnectorProtocol `json:"action,omitempty"`  // 對應的指令代碼 (Game Server 推送時為空)
+	ReqID   string            `json:"req_id,omitempty"`  // 對應請求的 req_id (推送時為空)
+	Code    proto.ErrorCode   `json:"code,omitempty"`    // 錯誤碼 (common.ErrorCode，0 代表成功)
+	Message string            `json:"message,omitempty"` // 錯誤說明 (僅供除錯顯示，判斷請以 code 為準)
+	Data    any               `json:"data,omitempty"`    // 資料
+}
+
+// NewPush 建立 Server 主動推送的封包
+func NewPush(action ConnectorProtocol, data any) *Response {
+	return &Response{Type: TypePush, Action: action, Data: data}
+}
+
+// RawData Game Server 回傳或推送的原始資料 (Connector 不解析內容)
+// 二進位模式直接放入 data；JSON 模式下合法 JSON 原樣嵌入，其他 UTF-8 文字為字串，非文字資料為 Base64 字串
+type RawData []byte
+
+// Raw 包裝原始資料 (空資料回傳 nil，使 data 欄位省略)
+func Raw(payload []byte) any {
+	if len(payload) == 0 {
+		return nil
+	}
+	return RawData(payload)
+}
+
+// MarshalJSON 實作 json.Marshaler
+func (d RawData) MarshalJSON() ([]byte, error) {
+	switch {
+	case json.Valid(d):
+		return d, nil
+	case utf8.Valid(d):
+		return json.Marshal(string(d))
+	default:
+		return json.Marshal([]byte(d))
+	}
 }
 
 // LoginReq 登入請求
 type LoginReq struct {
-	Token string `json:"token"`
+	Token         string `json:"token"`
+	ClientVersion string `json:"client_version,omitempty"` // 客戶端版本號 (ex: "1.4.2")，用於版本路由規則
 }
 
 // LoginResp 登入回應
@@ -39,6 +87,7 @@ type LoginResp struct {
 	UserID       string          `json:"user_id"`
 	Nickname     string          `json:"nickname"`
 	Balance      decimal.Decimal `json:"balance"`
+	ResumeToken  string          `json:"resume_token,omitempty"` // 斷線重連用 Token (未啟用 Resume 時為空)
 }
 
 // EnterGameReq 進入遊戲請求
@@ -52,3 +101,47 @@ type EnterGameResp struct {
 	ErrorMessage string `json:"error_message,omitempty"`
 	GameID       int32  `json:"game_id"`
 }
+
+// ResumeReq 斷線重連請求
+type ResumeReq struct {
+	ResumeToken string `json:"resume_token"`
+}
+
+// ResumeResp 斷線重連回應
+type ResumeResp struct {
+	Success      bool   `json:"success"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	UserID       string `json:"user_id"`
+	GameID       int32  `json:"game_id,omitempty"` // 恢復所在的遊戲 (0 代表需重新 enter)
+	ResumeToken  string `json:"resume_token"`      // 新的 Resume Token (舊 Token 已失效)
+}
+
+// LeaveResp 離開遊戲回應
+type LeaveResp struct {
+	Success      bool   `json:"success"`
+	ErrorMessage string `json:"error_message,omitempty"`
+	GameID       int32  `json:"game_id"` // 已離開的遊戲
+}
+
+// SwitchReq 切換遊戲請求
+type SwitchReq struct {
+	GameID int32 `json:"game_id"`
+}
+
+// SwitchResp 切換遊戲回應
+type SwitchResp struct {
+	Success        bool   `json:"success"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+	GameID         int32  `json:"game_id"`                    // 新進入的遊戲
+	PreviousGameID int32  `json:"previous_game_id,omitempty"` // 已離開的遊戲 (0 代表原本未在遊戲中)
+}
+
+// MaintenanceNotice 維護通知
+// 登入或進入遊戲因維護被拒時附於回應的 data，維護排程建立與開始時也會主動推送 (action: maintenance)
+type MaintenanceNotice struct {
+	GameID  int32  `json:"game_id"`            // 維護的遊戲 (0 代表全服維護)
+	Message string `json:"message,omitempty"`  // 維護公告
+	StartAt int64  `json:"start_at,omitempty"` // 開始時間 (Unix Timestamp)
+	EndAt   int64  `json:"end_at,omitempty"`   // 預計結束時間 (Unix Timestamp，0 代表未定)
+	Started bool   `json:"started"`            // 維護是否已開始
+}
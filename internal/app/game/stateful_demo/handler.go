@@ -79,7 +79,28 @@ func (_ *Handler) OnQuit(_ context.Context, peer *engine.Peer) error {
 	return nil
 }
 
+// OnReconnect 處理玩家斷線重連
+func (_ *Handler) OnReconnect(ctx context.Context, peer *engine.Peer, oldSessionID string) error {
+	slog.Info("Player Reconnected Stateful Service",
+		"user_id", peer.User.ID,
+		"session_id", peer.SessionID,
+		"old_session_id", oldSessionID,
+		"connector", peer.ConnectorHost,
+	)
+	return peer.Send(ctx, []byte("Welcome back! User ID: "+peer.User.ID))
+}
+
 type echoResponse struct {
 	Host    string `json:"host"`
 	Payload string `json:"payload"`
 }
+
+// OnDrain 伺服器進入排空模式 (即將關閉) 時通知在線玩家
+func (_ *Handler) OnDrain(ctx context.Context, peers []*engine.Peer, deadline time.Time) {
+	slog.Info("Stateful Service draining", "peers", len(peers), "deadline", deadline)
+
+	msg := []byte("Server is shutting down at " + deadline.Format(time.RFC3339) + ", please finish your game")
+	if err := engine.Broadcast(ctx, peers, msg); err != nil {
+		slog.Warn("OnDrain: Broadcast failed", "error", err)
+	}
+}
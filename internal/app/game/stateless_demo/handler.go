@@ -22,12 +22,13 @@ func NewHandler(host string) *Handler {
 }
 
 // OnMessage 處理這來自 Connector 的請求
-func (h *Handler) OnMessage(_ context.Context, peer *engine.Peer, payload []byte) ([]byte, error) {
+func (h *Handler) OnMessage(ctx context.Context, peer *engine.Peer, payload []byte) ([]byte, error) {
 	// 取得 Payload (假設內容是字串)
 	payloadStr := string(payload)
 
 	slog.Info("Stateless-Demo Service Received",
 		"user_id", peer.User.ID,
+		"action", engine.Action(ctx),
 		"req_id", peer.SessionID, // 注意: 這裡 SessionID 可能就是 ReqID (視 Connector 實作而定) 或者就是 SessionID
 		"payload", payloadStr,
 	)
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/mgmt/service"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+// localEnv 允許不設定管理 Token 的環境 (本機開發)
+const localEnv = "local"
+
+// ErrAdminTokenRequired 非本機開發環境未設定管理 Token (Fail Closed，拒絕啟動)
+var ErrAdminTokenRequired = errors.New("mgmt: admin token is required outside the local environment")
+
+// HTTPHandler 提供營運管理的 HTTP JSON API
+//
+//	GET    /api/services                  列出服務實例
+//	POST   /api/services/{lease_id}/drain 排空服務實例 (DELETE 取消排空)
+//	GET    /api/online                    在線人數統計
+//	POST   /api/users/{user_id}/kick      踢除使用者 (body: {"reason": "..."})
+//	POST   /api/announcements             推播公告 (body: service.Announcement)
+//	GET    /api/maintenance               列出維護排程
+//	PUT    /api/maintenance/{game_id}     建立維護排程 (game_id 0 為全服，body: maintenanceReq，DELETE 結束維護)
+type HTTPHandler struct {
+	svc        *service.MgmtService
+	adminToken string
+	mux        *http.ServeMux
+}
+
+// NewHTTPHandler 建立管理 API Handler，所有請求需帶上 "Authorization: Bearer {adminToken}"
+// adminToken 為空時僅允許本機開發環境 (env 為 "local") 不驗證，其他環境回傳 ErrAdminTokenRequired
+func NewHTTPHandler(svc *service.MgmtService, adminToken, env string) (*HTTPHandler, error) {
+	if adminToken == "" && env != localEnv {
+		return nil, ErrAdminTokenRequired
+	}
+
+	h := &HTTPHandler{
+		svc:        svc,
+		adminToken: adminToken,
+		mux:        http.NewServeMux(),
+	}
+	h.mux.HandleFunc("GET /api/services", h.listServices)
+	h.mux.HandleFunc("POST /api/services/{lease_id}/drain", h.drain(true))
+	h.mux.HandleFunc("DELETE /api/services/{lease_id}/drain", h.drain(false))
+	h.mux.HandleFunc("GET /api/online", h.onlineStats)
+	h.mux.HandleFunc("POST /api/users/{user_id}/kick", h.kickUser)
+	h.mux.HandleFunc("POST /api/announcements", h.announce)
+	h.mux.HandleFunc("GET /api/maintenance", h.listMaintenance)
+	h.mux.HandleFunc("PUT /api/maintenance/{game_id}", h.scheduleMaintenance)
+	h.mux.HandleFunc("DELETE /api/maintenance/{game_id}", h.endMaintenance)
+	return h, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.adminToken != "" {
+		token := r.Header.Get("Authorization")
+		if subtle.ConstantTimeCompare([]byte(token), []byte("Bearer "+h.adminToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *HTTPHandler) listServices(w http.ResponseWriter, r *http.Request) {
+	instances, err := h.svc.ListServices(r.Context())
+	respond(w, instances, err)
+}
+
+func (h *HTTPHandler) drain(draining bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := h.svc.Drain(r.Context(), r.PathValue("lease_id"), draining)
+		respond(w, map[string]bool{"draining": draining}, err)
+	}
+}
+
+func (h *HTTPHandler) onlineStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.svc.OnlineStats(r.Context())
+	respond(w, stats, err)
+}
+
+func (h *HTTPHandler) kickUser(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeOptional(w, r, &req) {
+		return
+	}
+	kicked, err := h.svc.KickUser(r.Context(), r.PathValue("user_id"), req.Reason)
+	respond(w, map[string]int{"kicked": kicked}, err)
+}
+
+func (h *HTTPHandler) announce(w http.ResponseWriter, r *http.Request) {
+	var req service.Announcement
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Message == "" {
+		writeError(w, http.StatusBadRequest, "message is required")
+		return
+	}
+	err := h.svc.Announce(r.Context(), &req)
+	respond(w, map[string]bool{"sent": err == nil}, err)
+}
+
+func (h *HTTPHandler) listMaintenance(w http.ResponseWriter, r *http.Request) {
+	list, err := h.svc.ListMaintenance(r.Context())
+	respond(w, list, err)
+}
+
+// maintenanceReq 建立維護排程的請求 (時間皆為 Unix Timestamp，省略 start_at 代表立即開始)
+type maintenanceReq struct {
+	Message     string   `json:"message"`
+	StartAt     int64    `json:"start_at"`
+	EndAt       int64    `json:"end_at"`
+	Whitelist   []string `json:"whitelist"`
+	KickPlayers bool     `json:"kick_players"`
+}
+
+func (h *HTTPHandler) scheduleMaintenance(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+	var req maintenanceReq
+	if !decodeOptional(w, r, &req) {
+		return
+	}
+	m := &domain.Maintenance{
+		GameID:      gameID,
+		Message:     req.Message,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		Whitelist:   req.Whitelist,
+		KickPlayers: req.KickPlayers,
+	}
+	err := h.svc.ScheduleMaintenance(r.Context(), m)
+	respond(w, m, err)
+}
+
+func (h *HTTPHandler) endMaintenance(w http.ResponseWriter, r *http.Request) {
+	gameID, ok := pathGameID(w, r)
+	if !ok {
+		return
+	}
+	err := h.svc.EndMaintenance(r.Context(), gameID)
+	respond(w, map[string]any{"game_id": gameID, "maintenance": false}, err)
+}
+
+// -------------------------------------------------------------
+// Helpers
+// -------------------------------------------------------------
+
+// pathGameID 解析路徑中的 game_id，格式錯誤時回應 400 並回傳 false
+func pathGameID(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	gameID, err := strconv.ParseInt(r.PathValue("game_id"), 10, 32)
+	if err != nil || gameID < 0 {
+		writeError(w, http.StatusBadRequest, "invalid game_id")
+		return 0, false
+	}
+	return int32(gameID), true
+}
+
+// decodeOptional 解析可省略的 JSON Body，格式錯誤時回應 400 並回傳 false
+func decodeOptional(w http.ResponseWriter, r *http.Request, dest any) bool {
+	if r.ContentLength == 0 {
+		return true
+	}
+	if err := json.NewDecoder(r.Body).Decode(dest); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	return true
+}
+
+// respond 依錯誤類型決定 HTTP Status，成功時回傳 {"data": ...}
+func respond(w http.ResponseWriter, data any, err error) {
+	if err != nil {
+		writeError(w, statusOf(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"data": data})
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, ports.ErrLeaseNotFound), errors.Is(err, ports.ErrUserOffline):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrPresenceDisabled), errors.Is(err, service.ErrPushDisabled), errors.Is(err, service.ErrMaintenanceDisabled):
+		return http.StatusNotImplemented
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		slog.Error("Mgmt request failed", "error", err)
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
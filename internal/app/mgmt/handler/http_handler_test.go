@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/app/mgmt/service"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+)
+
+func setupHandler(t *testing.T, token string) (*HTTPHandler, *mock_ports.MockRegistryService, *mock_ports.MockMaintenanceStore) {
+	ctrl := gomock.NewController(t)
+	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
+	mockMaintenance := mock_ports.NewMockMaintenanceStore(ctrl)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := service.NewMgmtService(mockRegistry, logger, service.WithMaintenance(mockMaintenance))
+	h, err := NewHTTPHandler(svc, token, "local")
+	assert.NoError(t, err)
+	return h, mockRegistry, mockMaintenance
+}
+
+func serve(h http.Handler, method, target, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewHTTPHandler_RequiresTokenOutsideLocal(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	svc := service.NewMgmtService(nil, logger)
+
+	_, err := NewHTTPHandler(svc, "", "local_k8s")
+	assert.ErrorIs(t, err, ErrAdminTokenRequired)
+	_, err = NewHTTPHandler(svc, "", "production")
+	assert.ErrorIs(t, err, ErrAdminTokenRequired)
+
+	_, err = NewHTTPHandler(svc, "secret", "production")
+	assert.NoError(t, err)
+}
+
+func TestHTTPHandler_Auth(t *testing.T) {
+	h, mockRegistry, _ := setupHandler(t, "secret")
+
+	rec := serve(h, http.MethodGet, "/api/services", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(h, http.MethodGet, "/api/services", "", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	mockRegistry.EXPECT().ListServices(gomock.Any()).Return([]*domain.ServiceInstance{
+		{LeaseID: "lease-1", Endpoint: "10.0.0.1:8090", ServiceType: "STATEFUL", GameIDs: []int32{20000}},
+	}, nil)
+	rec = serve(h, http.MethodGet, "/api/services", "", "secret")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"lease_id":"lease-1"`)
+}
+
+func TestHTTPHandler_Drain(t *testing.T) {
+	h, mockRegistry, _ := setupHandler(t, "")
+
+	mockRegistry.EXPECT().SetDraining(gomock.Any(), "lease-1", true).Return(nil)
+	rec := serve(h, http.MethodPost, "/api/services/lease-1/drain", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockRegistry.EXPECT().SetDraining(gomock.Any(), "lease-x", false).Return(ports.ErrLeaseNotFound)
+	rec = serve(h, http.MethodDelete, "/api/services/lease-x/drain", "", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestHTTPHandler_Maintenance(t *testing.T) {
+	h, _, mockMaintenance := setupHandler(t, "")
+
+	mockMaintenance.EXPECT().Set(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m *domain.Maintenance) error {
+		assert.Equal(t, int32(1001), m.GameID)
+		assert.Equal(t, "upgrading", m.Message)
+		assert.Equal(t, []string{"tester"}, m.Whitelist)
+		assert.True(t, m.KickPlayers)
+		return nil
+	})
+	rec := serve(h, http.MethodPut, "/api/maintenance/1001", `{"message":"upgrading","whitelist":["tester"],"kick_players":true}`, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 全服維護，結束時間早於開始時間
+	rec = serve(h, http.MethodPut, "/api/maintenance/0", `{"start_at":2000000000,"end_at":1900000000}`, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockMaintenance.EXPECT().Delete(gomock.Any(), int32(0)).Return(nil)
+	rec = serve(h, http.MethodDelete, "/api/maintenance/0", "", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(h, http.MethodPut, "/api/maintenance/abc", "", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 未啟用的功能回傳 501
+	rec = serve(h, http.MethodGet, "/api/online", "", "")
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
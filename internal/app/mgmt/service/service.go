@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+var (
+	// ErrPresenceDisabled 未設定在線狀態登錄 (無法查詢在線人數或踢除使用者)
+	ErrPresenceDisabled = errors.New("mgmt: presence is not enabled")
+	// ErrPushDisabled 未設定推播匯流排 (無法發送公告)
+	ErrPushDisabled = errors.New("mgmt: push is not enabled")
+	// ErrMaintenanceDisabled 未設定維護狀態儲存
+	ErrMaintenanceDisabled = errors.New("mgmt: maintenance is not enabled")
+	// ErrInvalidSchedule 維護排程的時間不合法 (結束時間早於開始時間或已過)
+	ErrInvalidSchedule = errors.New("mgmt: invalid maintenance schedule")
+)
+
+// ActionAnnouncement 公告推播給 Client 的指令代碼
+const ActionAnnouncement = "announcement"
+
+// defaultKickReason 未指定原因時的踢除原因
+const defaultKickReason = "Kicked by administrator"
+
+// MgmtService 負責營運管理操作 (服務列表、在線人數、踢人、排空、公告、維護)
+// 透過 Ports 存取共享狀態，不直接操作 Redis
+type MgmtService struct {
+	registry ports.RegistryService
+	logger   *slog.Logger
+
+	// Optional (nil 代表未啟用對應功能)
+	presence    ports.PresenceStore
+	kicker      ports.SessionKicker
+	pushBus     ports.PushBus
+	maintenance ports.MaintenanceStore
+	drainer     ports.ServiceDrainer
+}
+
+// Option 定義了 MgmtService 的配置選項函數
+type Option func(*MgmtService)
+
+// WithPresence 啟用在線人數查詢與踢除使用者 (kicker 經由 ConnectorRPC.Kick 踢除會話)
+func WithPresence(store ports.PresenceStore, kicker ports.SessionKicker) Option {
+	return func(s *MgmtService) {
+		s.presence = store
+		s.kicker = kicker
+	}
+}
+
+// WithPush 啟用公告推播
+func WithPush(bus ports.PushBus) Option {
+	return func(s *MgmtService) {
+		s.pushBus = bus
+	}
+}
+
+// WithMaintenance 啟用維護模式切換
+func WithMaintenance(store ports.MaintenanceStore) Option {
+	return func(s *MgmtService) {
+		s.maintenance = store
+	}
+}
+
+// WithGameDrainer 排空 Stateful 遊戲服務時一併通知該實例進入排空模式 (通知玩家、期限到達後踢除)
+func WithGameDrainer(drainer ports.ServiceDrainer) Option {
+	return func(s *MgmtService) {
+		s.drainer = drainer
+	}
+}
+
+// NewMgmtService 建立 Mgmt Service
+func NewMgmtService(registry ports.RegistryService, logger *slog.Logger, opts ...Option) *MgmtService {
+	s := &MgmtService{
+		registry: registry,
+		logger:   logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ---------------------------------------------------------
+// Services
+// ---------------------------------------------------------
+
+// ListServices 列出所有已註冊的服務實例
+func (s *MgmtService) ListServices(ctx context.Context) ([]*domain.ServiceInstance, error) {
+	return s.registry.ListServices(ctx)
+}
+
+// Drain 設定服務實例是否排空中 (排空中的實例不再分配新玩家，既有玩家不受影響)
+// 啟用 WithGameDrainer 時，Stateful 遊戲服務會一併進入排空模式 (通知玩家並於期限到達後踢除)
+func (s *MgmtService) Drain(ctx context.Context, leaseID string, draining bool) error {
+	if err := s.registry.SetDraining(ctx, leaseID, draining); err != nil {
+		return err
+	}
+	s.logger.Info("Service drain updated", "lease_id", leaseID, "draining", draining)
+
+	if !draining || s.drainer == nil {
+		return nil
+	}
+	instance, err := s.findInstance(ctx, leaseID)
+	if err != nil || instance == nil || instance.ServiceType != proto.ServiceType_STATEFUL.String() {
+		return err
+	}
+	if err := s.drainer.Drain(ctx, instance.Endpoint); err != nil {
+		s.logger.Warn("Failed to drain game server", "lease_id", leaseID, "endpoint", instance.Endpoint, "error", err)
+		return err
+	}
+	return nil
+}
+
+// findInstance 依租約 ID 查詢服務實例 (找不到回傳 nil)
+func (s *MgmtService) findInstance(ctx context.Context, leaseID string) (*domain.ServiceInstance, error) {
+	instances, err := s.registry.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, instance := range instances {
+		if instance.LeaseID == leaseID {
+			return instance, nil
+		}
+	}
+	return nil, nil
+}
+
+// ---------------------------------------------------------
+// Players
+// ---------------------------------------------------------
+
+// OnlineStats 在線人數統計
+type OnlineStats struct {
+	Sessions    int            `json:"sessions"`     // 在線會話數
+	Users       int            `json:"users"`        // 不重複的使用者數
+	ByConnector map[string]int `json:"by_connector"` // Connector -> 會話數
+	ByGame      map[int32]int  `json:"by_game"`      // GameID -> 會話數 (0 代表在大廳)
+}
+
+// OnlineStats 依 Connector 與遊戲統計在線會話數
+func (s *MgmtService) OnlineStats(ctx context.Context) (*OnlineStats, error) {
+	if s.presence == nil {
+		return nil, ErrPresenceDisabled
+	}
+	sessions, err := s.presence.ListByGame(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &OnlineStats{
+		Sessions:    len(sessions),
+		ByConnector: make(map[string]int),
+		ByGame:      make(map[int32]int),
+	}
+	users := make(map[string]struct{}, len(sessions))
+	for _, p := range sessions {
+		users[p.UserID] = struct{}{}
+		stats.ByConnector[p.ConnectorHost]++
+		stats.ByGame[p.GameID]++
+	}
+	stats.Users = len(users)
+	return stats, nil
+}
+
+// KickUser 踢除使用者所有在線會話，回傳成功踢除的會話數
+// 使用者不在線回傳 ports.ErrUserOffline
+func (s *MgmtService) KickUser(ctx context.Context, userID, reason string) (int, error) {
+	if s.presence == nil || s.kicker == nil {
+		return 0, ErrPresenceDisabled
+	}
+	sessions, err := s.presence.ListByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(sessions) == 0 {
+		return 0, ports.ErrUserOffline
+	}
+	if reason == "" {
+		reason = defaultKickReason
+	}
+
+	kicked := 0
+	var errs []error
+	for _, p := range sessions {
+		if err := s.kicker.Kick(ctx, p.ConnectorHost, p.SessionID, reason); err != nil {
+			s.logger.Warn("Failed to kick session", "user_id", userID, "session_id", p.SessionID, "connector", p.ConnectorHost, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		kicked++
+	}
+	s.logger.Info("User kicked", "user_id", userID, "sessions", len(sessions), "kicked", kicked, "reason", reason)
+	return kicked, errors.Join(errs...)
+}
+
+// Announcement 公告內容 (GameID / ConnectorHost 為空代表不限)
+type Announcement struct {
+	Message       string `json:"message"`
+	GameID        int32  `json:"game_id,omitempty"`
+	ConnectorHost string `json:"connector_host,omitempty"`
+}
+
+// Announce 推播公告給符合條件的所有在線會話
+func (s *MgmtService) Announce(ctx context.Context, a *Announcement) error {
+	if s.pushBus == nil {
+		return ErrPushDisabled
+	}
+	payload, err := json.Marshal(map[string]any{
+		"action": ActionAnnouncement,
+		"data":   a,
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.pushBus.Publish(ctx, &domain.PushMessage{
+		GameID:        a.GameID,
+		ConnectorHost: a.ConnectorHost,
+		Payload:       payload,
+	}); err != nil {
+		return err
+	}
+	s.logger.Info("Announcement sent", "game_id", a.GameID, "connector", a.ConnectorHost)
+	return nil
+}
+
+// ---------------------------------------------------------
+// Maintenance
+// ---------------------------------------------------------
+
+// ListMaintenance 列出所有維護排程 (含尚未開始的排程)
+func (s *MgmtService) ListMaintenance(ctx context.Context) ([]*domain.Maintenance, error) {
+	if s.maintenance == nil {
+		return nil, ErrMaintenanceDisabled
+	}
+	return s.maintenance.List(ctx)
+}
+
+// ScheduleMaintenance 建立或覆寫維護排程 (GameID 0 為全服維護)
+// 生效期間 Central 拒絕登入 (全服) 或加入遊戲，Connector 會通知玩家並依 KickPlayers 於開始時踢除遊戲中的玩家
+func (s *MgmtService) ScheduleMaintenance(ctx context.Context, m *domain.Maintenance) error {
+	if s.maintenance == nil {
+		return ErrMaintenanceDisabled
+	}
+	if m.EndAt > 0 && (m.EndAt <= m.StartAt || m.EndAt <= time.Now().Unix()) {
+		return ErrInvalidSchedule
+	}
+	if err := s.maintenance.Set(ctx, m); err != nil {
+		return err
+	}
+	s.logger.Info("Maintenance scheduled", "game_id", m.GameID, "start_at", m.StartAt, "end_at", m.EndAt, "whitelist", len(m.Whitelist), "kick", m.KickPlayers)
+	return nil
+}
+
+// EndMaintenance 取消或提前結束維護排程
+func (s *MgmtService) EndMaintenance(ctx context.Context, gameID int32) error {
+	if s.maintenance == nil {
+		return ErrMaintenanceDisabled
+	}
+	if err := s.maintenance.Delete(ctx, gameID); err != nil {
+		return err
+	}
+	s.logger.Info("Maintenance ended", "game_id", gameID)
+	return nil
+}
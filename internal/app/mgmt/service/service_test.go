@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+)
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stdout, nil))
+}
+
+func TestMgmtService_OnlineStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPresence := mock_ports.NewMockPresenceStore(ctrl)
+	svc := NewMgmtService(mock_ports.NewMockRegistryService(ctrl), newTestLogger(), WithPresence(mockPresence, nil))
+	ctx := context.Background()
+
+	mockPresence.EXPECT().ListByGame(ctx, int32(0)).Return([]*domain.Presence{
+		{UserID: "user-1", SessionID: "s1", ConnectorHost: "c1", GameID: 1001},
+		{UserID: "user-1", SessionID: "s2", ConnectorHost: "c2"},
+		{UserID: "user-2", SessionID: "s3", ConnectorHost: "c1", GameID: 1001},
+	}, nil)
+
+	stats, err := svc.OnlineStats(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.Sessions)
+	assert.Equal(t, 2, stats.Users)
+	assert.Equal(t, map[string]int{"c1": 2, "c2": 1}, stats.ByConnector)
+	assert.Equal(t, map[int32]int{0: 1, 1001: 2}, stats.ByGame)
+
+	// 未啟用在線狀態
+	_, err = NewMgmtService(nil, newTestLogger()).OnlineStats(ctx)
+	assert.ErrorIs(t, err, ErrPresenceDisabled)
+}
+
+func TestMgmtService_KickUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPresence := mock_ports.NewMockPresenceStore(ctrl)
+	mockKicker := mock_ports.NewMockSessionKicker(ctrl)
+	svc := NewMgmtService(nil, newTestLogger(), WithPresence(mockPresence, mockKicker))
+	ctx := context.Background()
+
+	mockPresence.EXPECT().ListByUser(ctx, "user-1").Return([]*domain.Presence{
+		{UserID: "user-1", SessionID: "s1", ConnectorHost: "c1"},
+		{UserID: "user-1", SessionID: "s2", ConnectorHost: "c2"},
+	}, nil)
+	mockKicker.EXPECT().Kick(ctx, "c1", "s1", defaultKickReason).Return(nil)
+	mockKicker.EXPECT().Kick(ctx, "c2", "s2", defaultKickReason).Return(errors.New("connector down"))
+
+	// 部分失敗回傳錯誤與成功踢除的數量
+	kicked, err := svc.KickUser(ctx, "user-1", "")
+	assert.Error(t, err)
+	assert.Equal(t, 1, kicked)
+
+	// 使用者不在線
+	mockPresence.EXPECT().ListByUser(ctx, "user-2").Return(nil, nil)
+	_, err = svc.KickUser(ctx, "user-2", "cheating")
+	assert.ErrorIs(t, err, ports.ErrUserOffline)
+}
+
+func TestMgmtService_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
+	svc := NewMgmtService(mockRegistry, newTestLogger())
+	ctx := context.Background()
+
+	mockRegistry.EXPECT().SetDraining(ctx, "lease-1", true).Return(nil)
+	assert.NoError(t, svc.Drain(ctx, "lease-1", true))
+
+	mockRegistry.EXPECT().SetDraining(ctx, "lease-x", true).Return(ports.ErrLeaseNotFound)
+	assert.ErrorIs(t, svc.Drain(ctx, "lease-x", true), ports.ErrLeaseNotFound)
+}
+
+func TestMgmtService_Drain_GameServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRegistry := mock_ports.NewMockRegistryService(ctrl)
+	mockDrainer := mock_ports.NewMockServiceDrainer(ctrl)
+	svc := NewMgmtService(mockRegistry, newTestLogger(), WithGameDrainer(mockDrainer))
+	ctx := context.Background()
+
+	instances := []*domain.ServiceInstance{
+		{LeaseID: "lease-1", Endpoint: "10.0.0.1:9000", ServiceType: proto.ServiceType_STATEFUL.String()},
+		{LeaseID: "lease-2", Endpoint: "10.0.0.2:9000", ServiceType: proto.ServiceType_STATELESS.String()},
+	}
+
+	// Stateful: 一併通知遊戲服務進入排空模式
+	mockRegistry.EXPECT().SetDraining(ctx, "lease-1", true).Return(nil)
+	mockRegistry.EXPECT().ListServices(ctx).Return(instances, nil)
+	mockDrainer.EXPECT().Drain(ctx, "10.0.0.1:9000").Return(nil)
+	assert.NoError(t, svc.Drain(ctx, "lease-1", true))
+
+	// Stateless: 僅標記租約
+	mockRegistry.EXPECT().SetDraining(ctx, "lease-2", true).Return(nil)
+	mockRegistry.EXPECT().ListServices(ctx).Return(instances, nil)
+	assert.NoError(t, svc.Drain(ctx, "lease-2", true))
+
+	// 取消排空不通知遊戲服務
+	mockRegistry.EXPECT().SetDraining(ctx, "lease-1", false).Return(nil)
+	assert.NoError(t, svc.Drain(ctx, "lease-1", false))
+
+	// 遊戲服務排空失敗
+	drainErr := errors.New("unavailable")
+	mockRegistry.EXPECT().SetDraining(ctx, "lease-1", true).Return(nil)
+	mockRegistry.EXPECT().ListServices(ctx).Return(instances, nil)
+	mockDrainer.EXPECT().Drain(ctx, "10.0.0.1:9000").Return(drainErr)
+	assert.ErrorIs(t, svc.Drain(ctx, "lease-1", true), drainErr)
+}
+
+func TestMgmtService_Announce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBus := mock_ports.NewMockPushBus(ctrl)
+	svc := NewMgmtService(nil, newTestLogger(), WithPush(mockBus))
+	ctx := context.Background()
+
+	mockBus.EXPECT().Publish(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, msg *domain.PushMessage) error {
+		assert.Equal(t, int32(1001), msg.GameID)
+		assert.Empty(t, msg.UserID)
+
+		var envelope struct {
+			Action string       `json:"action"`
+			Data   Announcement `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(msg.Payload, &envelope))
+		assert.Equal(t, ActionAnnouncement, envelope.Action)
+		assert.Equal(t, "server restart in 5 minutes", envelope.Data.Message)
+		return nil
+	})
+	assert.NoError(t, svc.Announce(ctx, &Announcement{Message: "server restart in 5 minutes", GameID: 1001}))
+
+	// 未啟用推播
+	assert.ErrorIs(t, NewMgmtService(nil, newTestLogger()).Announce(ctx, &Announcement{Message: "hi"}), ErrPushDisabled)
+}
+
+func TestMgmtService_ScheduleMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_ports.NewMockMaintenanceStore(ctrl)
+	svc := NewMgmtService(nil, newTestLogger(), WithMaintenance(mockStore))
+	ctx := context.Background()
+	now := time.Now().Unix()
+
+	m := &domain.Maintenance{GameID: 1001, Message: "upgrading", StartAt: now + 600, EndAt: now + 3600, Whitelist: []string{"tester"}}
+	mockStore.EXPECT().Set(ctx, m).Return(nil)
+	assert.NoError(t, svc.ScheduleMaintenance(ctx, m))
+
+	// 結束時間早於開始時間或已過
+	assert.ErrorIs(t, svc.ScheduleMaintenance(ctx, &domain.Maintenance{GameID: 0, StartAt: now + 600, EndAt: now + 60}), ErrInvalidSchedule)
+	assert.ErrorIs(t, svc.ScheduleMaintenance(ctx, &domain.Maintenance{GameID: 0, EndAt: now - 60}), ErrInvalidSchedule)
+
+	mockStore.EXPECT().Delete(ctx, int32(1001)).Return(nil)
+	assert.NoError(t, svc.EndMaintenance(ctx, 1001))
+
+	// 未啟用維護
+	assert.ErrorIs(t, NewMgmtService(nil, newTestLogger()).EndMaintenance(ctx, 1001), ErrMaintenanceDisabled)
+}
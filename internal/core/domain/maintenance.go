@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// GlobalMaintenanceGameID 全服維護使用的 GameID
+const GlobalMaintenanceGameID int32 = 0
+
+// Maintenance 代表一段維護排程 (全服或單一遊戲)
+type Maintenance struct {
+	GameID      int32    `json:"game_id"`                // 維護中的遊戲 ID (0 代表全服維護)
+	Message     string   `json:"message,omitempty"`      // 對玩家顯示的維護公告
+	StartAt     int64    `json:"start_at,omitempty"`     // 開始時間 (Unix Timestamp，0 代表立即開始)
+	EndAt       int64    `json:"end_at,omitempty"`       // 結束時間 (Unix Timestamp，0 代表需手動結束)
+	Whitelist   []string `json:"whitelist,omitempty"`    // 維護期間仍可登入/進入遊戲的使用者 (測試人員)
+	KickPlayers bool     `json:"kick_players,omitempty"` // 開始時是否踢除遊戲中的玩家 (白名單除外)
+	UpdatedAt   int64    `json:"updated_at"`             // 最後更新時間 (Unix Timestamp)
+}
+
+// Global 是否為全服維護
+func (m *Maintenance) Global() bool {
+	return m.GameID == GlobalMaintenanceGameID
+}
+
+// Active 維護排程在 now 是否生效中
+func (m *Maintenance) Active(now time.Time) bool {
+	ts := now.Unix()
+	if m.StartAt > 0 && ts < m.StartAt {
+		return false
+	}
+	return m.EndAt <= 0 || ts < m.EndAt
+}
+
+// Ended 維護排程在 now 是否已結束
+func (m *Maintenance) Ended(now time.Time) bool {
+	return m.EndAt > 0 && now.Unix() >= m.EndAt
+}
+
+// Allows 使用者是否在白名單中 (維護期間不受限制)
+func (m *Maintenance) Allows(userID string) bool {
+	return userID != "" && slices.Contains(m.Whitelist, userID)
+}
+
+// Affects 維護排程是否涵蓋此遊戲 (全服維護涵蓋所有遊戲)
+func (m *Maintenance) Affects(gameID int32) bool {
+	return m.Global() || m.GameID == gameID
+}
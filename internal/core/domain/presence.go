@@ -0,0 +1,12 @@
+package domain
+
+// Presence 代表一個在線會話的狀態 (使用者在哪個 Connector、哪個遊戲)，跨 Connector 共享
+// 由 Connector 在登入、進入遊戲與斷線時寫入，並定期刷新存活時間
+type Presence struct {
+	UserID        string `json:"user_id"`        // 綁定的使用者 ID
+	SessionID     string `json:"session_id"`     // Session ID
+	ConnectorHost string `json:"connector_host"` // 所在的 Connector (grpc host)
+	GameID        int32  `json:"game_id"`        // 所在遊戲 ID (0 代表未進入遊戲)
+	Since         int64  `json:"since"`          // 登入時間 (Unix Timestamp)
+	UpdatedAt     int64  `json:"updated_at"`     // 最後更新時間 (Unix Timestamp)
+}
@@ -0,0 +1,18 @@
+package domain
+
+// PushMessage 代表一則經由叢集推播匯流排發送給玩家的訊息
+// 空的 UserID 代表全服廣播，GameID / ConnectorHost 為可選的篩選條件
+type PushMessage struct {
+	UserID        string `json:"user_id,omitempty"`        // 目標使用者 (空字串代表所有在線玩家)
+	GameID        int32  `json:"game_id,omitempty"`        // 僅推播給在此遊戲中的會話 (0 代表不限)
+	ConnectorHost string `json:"connector_host,omitempty"` // 僅推播給此 Connector 上的會話 (空字串代表不限)
+	Payload       []byte `json:"payload"`                  // 推送給客戶端的資料 (放在 push 封包的 data)
+}
+
+// Match 判斷會話是否符合推播的篩選條件 (不檢查 UserID)
+func (m *PushMessage) Match(connectorHost string, gameID int32) bool {
+	if m.ConnectorHost != "" && m.ConnectorHost != connectorHost {
+		return false
+	}
+	return m.GameID == 0 || m.GameID == gameID
+}
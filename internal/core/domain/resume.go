@@ -0,0 +1,19 @@
+package domain
+
+// ResumeState 代表一個斷線後被保留 (Parked) 的會話狀態。
+// 玩家在保留期限內可憑 Resume Token 於任一 Connector 恢復會話，
+// 期限過後則視為正式離線，通知 Game Server 玩家離開。
+type ResumeState struct {
+	UserID         string `json:"user_id"`         // 綁定的使用者 ID
+	SessionID      string `json:"session_id"`      // 斷線前的 Session ID
+	ConnectorHost  string `json:"connector_host"`  // 斷線前所在的 Connector (grpc host)
+	GameID         int32  `json:"game_id"`         // 所在遊戲 ID (0 代表未進入遊戲)
+	TargetEndpoint string `json:"target_endpoint"` // Stateful 遊戲的固定路由 (Stateless 為空)
+	Version        string `json:"version"`         // 分配到的遊戲實例版本 (重連後沿用同一版本)
+	ParkedAt       int64  `json:"parked_at"`       // 斷線時間 (Unix Timestamp)
+}
+
+// InGame 是否在遊戲中斷線
+func (s *ResumeState) InGame() bool {
+	return s.GameID != 0
+}
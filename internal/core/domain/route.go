@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// RouteRequest 代表一次遊戲路由查詢 (Central 依版本路由規則挑選實例)
+type RouteRequest struct {
+	GameID        int32  // 目標遊戲
+	UserID        string // 使用者 (指定使用者、比例分流規則)
+	ClientVersion string // 客戶端版本號 (最低客戶端版本規則)
+	Version       string // 固定版本: 已分配版本的會話沿用同一版本 (空字串代表依規則挑選)
+}
+
+// RouteRule 版本路由規則 (Canary / Blue-Green)
+// 設定的條件需全部符合才會命中，未設定任何條件的規則命中所有使用者 (ex: Blue-Green 切換)
+type RouteRule struct {
+	GameID           int32    `json:"game_id"`                      // 套用的遊戲 (0 代表所有遊戲)
+	Version          string   `json:"version"`                      // 命中時路由到的實例版本
+	Percent          int      `json:"percent,omitempty"`            // 依使用者 ID 分流的百分比 (1-100，0 代表不以比例分流)
+	UserIDs          []string `json:"user_ids,omitempty"`           // 指定使用者
+	MinClientVersion string   `json:"min_client_version,omitempty"` // 客戶端版本需大於等於此版本 (ex: "1.4")
+}
+
+// Covers 規則是否套用於此遊戲
+func (r *RouteRule) Covers(gameID int32) bool {
+	return r.GameID == 0 || r.GameID == gameID
+}
+
+// Match 路由查詢是否命中此規則
+func (r *RouteRule) Match(req *RouteRequest) bool {
+	if !r.Covers(req.GameID) {
+		return false
+	}
+	if len(r.UserIDs) > 0 && !slices.Contains(r.UserIDs, req.UserID) {
+		return false
+	}
+	if r.MinClientVersion != "" && (req.ClientVersion == "" || CompareVersions(req.ClientVersion, r.MinClientVersion) < 0) {
+		return false
+	}
+	if r.Percent > 0 && r.Percent < 100 && (req.UserID == "" || userBucket(req.UserID) >= r.Percent) {
+		return false
+	}
+	return true
+}
+
+// userBucket 將使用者穩定地分配到 0-99 的分流桶 (同一使用者每次查詢皆落在同一桶)
+func userBucket(userID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+// VersionSelector 限定路由候選實例的版本
+type VersionSelector struct {
+	Version string   // 僅選擇此版本 (空字串代表不限)
+	Exclude []string // 排除的版本 (Version 為空時生效，讓未命中規則的使用者避開 Canary 版本)
+	Pinned  bool     // 會話已分配此版本: 沒有此版本的實例時不退回其他版本 (視為找不到實例)
+}
+
+// Match 實例版本是否符合
+func (s VersionSelector) Match(version string) bool {
+	if s.Version != "" {
+		return version == s.Version
+	}
+	return !slices.Contains(s.Exclude, version)
+}
+
+// CompareVersions 比較兩個以 "." 分隔的版本號 (ex: "1.4.2")，可帶 "v" 前綴
+// a < b 回傳 -1，a == b 回傳 0，a > b 回傳 1；非數字的段落以字串比較
+func CompareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y string
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		xn, xErr := strconv.Atoi(orZero(x))
+		yn, yErr := strconv.Atoi(orZero(y))
+		switch {
+		case xErr == nil && yErr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		case x != y:
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func orZero(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
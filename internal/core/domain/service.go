@@ -0,0 +1,14 @@
+package domain
+
+// ServiceInstance 代表一個已向 Central 註冊的服務實例 (Lease)
+type ServiceInstance struct {
+	LeaseID     string            `json:"lease_id"`          // 租約 ID
+	ServiceName string            `json:"service_name"`      // 服務名稱 (ex: "slots-service")
+	ServiceType string            `json:"service_type"`      // 服務類型 (STATELESS / STATEFUL)
+	Endpoint    string            `json:"endpoint"`          // 服務地址 (host:port)
+	GameIDs     []int32           `json:"game_ids"`          // 支援的遊戲 ID 列表
+	Load        int32             `json:"load"`              // 最近一次心跳回報的負載
+	Draining    bool              `json:"draining"`          // 是否排空中 (不再分配新玩家)
+	Version     string            `json:"version,omitempty"` // 實例版本 (版本路由)
+	Labels      map[string]string `json:"labels,omitempty"`  // 實例標籤
+}
@@ -42,6 +42,28 @@ func (s *Session) Send(msg string) error {
 	return s.conn.SendMessage(msg)
 }
 
+// SendBinary 以二進位訊框發送訊息給此會話的客戶端
+//
+// 參數:
+//
+//	msg: []byte - 訊息內容
+//
+// 回傳值:
+//
+//	error: 若發送失敗則回傳錯誤
+func (s *Session) SendBinary(msg []byte) error {
+	return s.conn.SendBinary(msg)
+}
+
+// Subprotocol 回傳此會話協商出的 WebSocket 子協議
+//
+// 回傳值:
+//
+//	string: 子協議名稱 (未協商則為空字串)
+func (s *Session) Subprotocol() string {
+	return s.conn.Subprotocol()
+}
+
 // Kick 強制中斷此會話
 //
 // 參數:
@@ -54,3 +76,27 @@ func (s *Session) Send(msg string) error {
 func (s *Session) Kick(reason string) error {
 	return s.conn.Kick(reason)
 }
+
+// SetTag 在底層連線附加鍵值對資料
+//
+// 參數:
+//
+//	key: string - 鍵名
+//	value: any - 資料
+func (s *Session) SetTag(key string, value any) {
+	s.conn.SetTag(key, value)
+}
+
+// GetTag 取得底層連線附加的資料
+//
+// 參數:
+//
+//	key: string - 鍵名
+//
+// 回傳值:
+//
+//	any: 資料
+//	bool: 是否存在
+func (s *Session) GetTag(key string) (any, bool) {
+	return s.conn.GetTag(key)
+}
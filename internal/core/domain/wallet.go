@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransactionType 錢包交易類型
+type TransactionType string
+
+const (
+	TransactionDeposit  TransactionType = "deposit"  // 入帳 (派彩、退款)
+	TransactionWithdraw TransactionType = "withdraw" // 扣款 (下注)
+)
+
+// Transaction 代表錢包帳本 (Ledger) 中的一筆交易紀錄。
+// ID 由呼叫端提供作為冪等鍵 (Idempotency Key)，同一 ID 只會入帳一次。
+type Transaction struct {
+	ID           string          // 交易 ID (冪等鍵)
+	UserID       string          // 使用者 ID
+	Type         TransactionType // 交易類型
+	Amount       decimal.Decimal // 交易金額 (恆為正數)
+	BalanceAfter decimal.Decimal // 交易後餘額
+	Reason       string          // 交易原因 (ex: "bet:slot-10001")
+	CreatedAt    time.Time       // 交易時間
+}
@@ -1,9 +1,34 @@
 package ports
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // 定義 Ports 層級通用的錯誤
 var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenMalformed Token 格式錯誤 (非本系統簽發的 Token)
+	ErrTokenMalformed = fmt.Errorf("%w: malformed", ErrInvalidToken)
+	// ErrTokenExpired Token 已過期 (exp)
+	ErrTokenExpired = fmt.Errorf("%w: expired", ErrInvalidToken)
+	// ErrTokenNotValidYet Token 尚未生效 (nbf / iat)
+	ErrTokenNotValidYet = fmt.Errorf("%w: not valid yet", ErrInvalidToken)
+	// ErrInsufficientFunds 餘額不足
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrInvalidAmount 交易金額必須大於 0
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrTransactionConflict 相同交易 ID 已被用於不同的交易內容 (使用者、類型或金額不同)
+	ErrTransactionConflict = errors.New("transaction id conflict")
+	// ErrResumeNotFound Resume Token 不存在、已過期或已被其他 Connector 認領
+	ErrResumeNotFound = errors.New("resume token not found")
+	// ErrUserOffline 使用者沒有符合條件的在線會話
+	ErrUserOffline = errors.New("user offline")
+	// ErrMaintenance 遊戲維護中
+	ErrMaintenance = errors.New("under maintenance")
+	// ErrLeaseNotFound 服務租約不存在或已過期
+	ErrLeaseNotFound = errors.New("lease not found")
+	// ErrSessionLimitExceeded 使用者的在線會話數已達上限 (重複登入被拒)
+	ErrSessionLimitExceeded = errors.New("session limit exceeded")
 )
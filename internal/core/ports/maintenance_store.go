@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// MaintenanceStore 定義維護排程的存取介面 (跨服務共享，e.g. Redis)
+// 每個遊戲 (含全服，GameID 0) 同時只有一筆排程，排程結束後自動移除
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_maintenance_store.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports MaintenanceStore
+type MaintenanceStore interface {
+	// Set 建立或覆寫遊戲的維護排程 (StartAt 可為未來時間)
+	Set(ctx context.Context, maintenance *domain.Maintenance) error
+
+	// Delete 取消或提前結束遊戲的維護排程 (無排程不回傳錯誤)
+	Delete(ctx context.Context, gameID int32) error
+
+	// Get 取得遊戲的維護排程 (無排程回傳 nil，不代表排程已生效)
+	Get(ctx context.Context, gameID int32) (*domain.Maintenance, error)
+
+	// List 列出所有維護排程 (含尚未開始的排程)
+	List(ctx context.Context) ([]*domain.Maintenance, error)
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// PresenceStore 定義在線狀態 (Presence) 登錄的介面
+// 實作需跨 Connector 共享 (e.g. Redis)，由 Connector 在登入、進入遊戲與斷線時維護，
+// 資料具存活時間 (TTL)，Connector 需定期 Refresh，異常終止的 Connector 其資料會自動過期
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_presence_store.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports PresenceStore
+type PresenceStore interface {
+	// Set 新增或更新會話的在線狀態
+	Set(ctx context.Context, presence *domain.Presence) error
+
+	// Reserve 使用者在線會話數 (不含 presence 本身) 未達 limit 時寫入會話的在線狀態，檢查與寫入為原子操作
+	// 寫入的在線狀態為暫時保留 (存活時間較短)，需由 Connector 以 Set 確認；登入失敗時應以 Remove 釋放
+	// 已達上限時不寫入，回傳 ErrSessionLimitExceeded 與目前的在線會話 (不含 presence 本身)
+	Reserve(ctx context.Context, presence *domain.Presence, limit int) ([]*domain.Presence, error)
+
+	// Remove 移除會話的在線狀態
+	Remove(ctx context.Context, userID, sessionID string) error
+
+	// Refresh 延長多個會話的存活時間 (不存在的會話會重新寫入)
+	Refresh(ctx context.Context, presences []*domain.Presence) error
+
+	// ListByUser 列出使用者所有在線會話 (不在線時回傳空列表)
+	ListByUser(ctx context.Context, userID string) ([]*domain.Presence, error)
+
+	// ListByGame 列出在遊戲中的在線會話 (gameID 為 0 代表所有在線會話)
+	ListByGame(ctx context.Context, gameID int32) ([]*domain.Presence, error)
+}
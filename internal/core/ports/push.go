@@ -0,0 +1,31 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// PushHandler 處理從推播匯流排收到的訊息
+type PushHandler func(msg *domain.PushMessage)
+
+// PushBus 定義叢集推播匯流排的介面 (e.g. Redis Pub/Sub)
+// Central 負責發布；每個 Connector 訂閱全服頻道，並為本機在線的使用者訂閱個人頻道
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_push_bus.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports PushBus
+type PushBus interface {
+	// Publish 發布訊息: msg.UserID 不為空時發布到該使用者的頻道，否則發布到全服頻道
+	Publish(ctx context.Context, msg *domain.PushMessage) error
+
+	// Listen 開始訂閱全服頻道，收到的訊息 (含個人頻道) 皆交由 handler 處理
+	Listen(ctx context.Context, handler PushHandler) error
+
+	// Watch 訂閱使用者的個人頻道 (須先呼叫 Listen)
+	Watch(ctx context.Context, userID string) error
+
+	// Unwatch 取消訂閱使用者的個人頻道
+	Unwatch(ctx context.Context, userID string) error
+
+	// Close 關閉訂閱
+	Close() error
+}
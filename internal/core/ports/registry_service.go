@@ -5,6 +5,7 @@ import (
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 )
 
 // RegistryService 定義服務註冊與發現的介面
@@ -22,9 +23,23 @@ type RegistryService interface {
 	Deregister(ctx context.Context, leaseID string) error
 
 	// SelectServiceByGame 根據 GameID 選擇一個合適的服務實例 (負載均衡)
-	// 回傳 Endpoint (host:port) 與 ServiceType
-	SelectServiceByGame(ctx context.Context, gameID int32) (string, proto.ServiceType, error)
+	// 僅從符合 selector 的版本中挑選，沒有符合的實例時退回不限版本
+	// 沒有可用實例時回傳的 Route.Endpoint 為空字串
+	SelectServiceByGame(ctx context.Context, gameID int32, selector domain.VersionSelector) (*Route, error)
 
 	// CleanupDeadServices 清理無效的服務節點 (Zombie Endpoints)
 	CleanupDeadServices(ctx context.Context) error
+
+	// ListServices 列出所有有效租約的服務實例
+	ListServices(ctx context.Context) ([]*domain.ServiceInstance, error)
+
+	// SetDraining 設定服務實例是否排空中 (排空中的實例不會被 SelectServiceByGame 選中)
+	SetDraining(ctx context.Context, leaseID string, draining bool) error
+}
+
+// Route 代表 SelectServiceByGame 挑選出的服務實例
+type Route struct {
+	Endpoint    string            // 服務地址 (host:port)，空字串代表沒有可用實例
+	ServiceType proto.ServiceType // 服務類型
+	Version     string            // 實例版本 (未標記版本為空字串)
 }
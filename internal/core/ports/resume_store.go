@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// ResumeStore 定義斷線會話保留 (Session Resume) 的儲存介面
+// 實作需跨 Connector 共享 (e.g. Redis)，讓玩家可在任一 Connector 恢復會話
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_resume_store.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports ResumeStore
+type ResumeStore interface {
+	// Park 保留會話狀態，grace 為可恢復的期限
+	Park(ctx context.Context, token string, state *domain.ResumeState, grace time.Duration) error
+
+	// Claim 認領並移除保留的會話狀態 (原子操作，同一 Token 只會有一個呼叫者成功)
+	// Token 不存在或已被認領時回傳 ErrResumeNotFound
+	Claim(ctx context.Context, token string) (*domain.ResumeState, error)
+
+	// ListExpired 列出保留期限已過 (before 之前) 的 Token
+	ListExpired(ctx context.Context, before time.Time) ([]string, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+// ServiceDrainer 定義通知遊戲服務進入排空模式的介面 (經由 GameRPC.Drain)
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_service_drainer.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports ServiceDrainer
+type ServiceDrainer interface {
+	// Drain 通知 endpoint 上的遊戲服務進入排空模式 (通知玩家、期限到達後踢除剩餘玩家)
+	Drain(ctx context.Context, endpoint string) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+// SessionKicker 定義踢除指定 Connector 上會話的介面 (經由 ConnectorRPC.Kick)
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_session_kicker.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports SessionKicker
+type SessionKicker interface {
+	// Kick 踢除 connectorHost 上的會話 (會話已不存在時視為成功)
+	Kick(ctx context.Context, connectorHost, sessionID, reason string) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// TokenVerifier 定義登入 Token 的驗證介面
+// 驗證成功時將 Token 內的 Claims 對應為使用者身分 (僅含 ID / 名稱，不含餘額)
+//
+//go:generate mockgen -destination=../../../test/mocks/core/ports/mock_token_verifier.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports TokenVerifier
+type TokenVerifier interface {
+	// Verify 驗證 Token (簽章、有效期限等)，失敗時回傳的錯誤皆可用 errors.Is(err, ErrInvalidToken) 判斷
+	Verify(ctx context.Context, token string) (*domain.User, error)
+}
@@ -17,4 +17,7 @@ type UserService interface {
 	CreateGuestUser(ctx context.Context, token string, user *domain.User) error
 	// GetUserByID 根據 ID 取得使用者
 	GetUserByID(ctx context.Context, id string) (*domain.User, error)
+	// EnsureUser 使用者資料不存在時建立 (已存在則不覆寫)
+	// 供外部驗證 (JWT) 的使用者首次登入時建立資料，讓遊戲服務可以依 ID 查詢
+	EnsureUser(ctx context.Context, user *domain.User) error
 }
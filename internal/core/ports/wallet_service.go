@@ -4,18 +4,31 @@ import (
 	"context"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 )
 
+// MaxTransactionListLimit ListTransactions 單次查詢的筆數上限
+const MaxTransactionListLimit = 200
+
 // WalletService 定義錢包相關的業務邏輯介面
 //
+// Deposit / Withdraw 的 txID 為冪等鍵 (Idempotency Key):
+// 以相同 txID 重複呼叫只會入帳一次，並回傳第一次的交易結果，
+// 呼叫端可放心在逾時或錯誤時重試。
+//
 //go:generate mockgen -destination=../../../test/mocks/core/ports/mock_wallet_service.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports WalletService
 type WalletService interface {
 	// GetBalance 取得餘額
 	GetBalance(ctx context.Context, userID string) (decimal.Decimal, error)
 
 	// Deposit 存款 (增加餘額)
-	Deposit(ctx context.Context, userID string, amount decimal.Decimal, reason string) error
+	Deposit(ctx context.Context, userID string, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error)
+
+	// Withdraw 提款 (扣除餘額)，餘額不足時回傳 ErrInsufficientFunds
+	Withdraw(ctx context.Context, userID string, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error)
 
-	// Withdraw 提款 (扣除餘額)
-	Withdraw(ctx context.Context, userID string, amount decimal.Decimal, reason string) error
+	// ListTransactions 查詢交易紀錄 (依時間新到舊)
+	// limit 小於等於 0 或超過 MaxTransactionListLimit 時以 MaxTransactionListLimit 為準，offset 小於 0 視為 0
+	ListTransactions(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, error)
 }
@@ -0,0 +1,49 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	userMysql "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/user/mysql"
+	walletMysql "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/mysql"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/config"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/mysql"
+)
+
+// InitializeMySQLClient initializes the MySQL client with config and runs schema migrations
+func InitializeMySQLClient(_ context.Context, cfg *config.Config) (*mysql.Client, error) {
+	client, err := mysql.NewClient(mysql.Config{
+		Host:            cfg.MySQL.Host,
+		Port:            cfg.MySQL.Port,
+		User:            cfg.MySQL.User,
+		Password:        cfg.MySQL.Password,
+		DBName:          cfg.MySQL.DBName,
+		MaxOpenConns:    cfg.MySQL.MaxOpenConns,
+		MaxIdleConns:    cfg.MySQL.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.MySQL.ConnMaxLifetimeSec) * time.Second,
+		LogLevel:        cfg.MySQL.LogLevel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrate(client); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("mysql migration failed: %w", err)
+	}
+	return client, nil
+}
+
+// NeedsMySQL reports whether any configured service is backed by MySQL
+func NeedsMySQL(cfg *config.Config) bool {
+	return cfg.UserStore.Store == StoreMySQL || cfg.Wallet.Store == StoreMySQL
+}
+
+// migrate runs the schema migrations of every MySQL-backed service
+func migrate(client *mysql.Client) error {
+	if err := userMysql.Migrate(client.DB()); err != nil {
+		return err
+	}
+	return walletMysql.Migrate(client.DB())
+}
@@ -1,41 +1,223 @@
 package di
 
 import (
+	"crypto/rsa"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/shopspring/decimal"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/auth/jwt"
+	maintenanceStore "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/maintenance/redis"
+	pushBus "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/push/redis"
 	infraRedis "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/redis"
 	registry "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/service_discovery/redis"
+	sessionStore "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/session/redis"
+	userMysql "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/user/mysql"
 	user "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/user/redis"
 	wallet "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/mock"
+	walletMysql "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/mysql"
 	"github.com/JoeShih716/go-k8s-game-server/internal/kit/config"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/mysql"
 )
 
-// ProvideUserService creates a UserService using the 'user' Redis DB
-func ProvideUserService(cfg *config.Config, redisProvider *infraRedis.Provider) ports.UserService {
-	switch cfg.App.Env {
-	case "prod":
-		return user.NewUserService(redisProvider.GetUser())
-	default:
-		userRedisClient := redisProvider.GetUser()
+// 儲存方式 (Config: user_store.store, wallet.store)
+const (
+	StoreRedis = "redis"
+	StoreMySQL = "mysql"
+	StoreMock  = "mock"
+)
+
+// ProvideUserService creates a UserService based on config
+//   - redis (default): users live in the 'user' Redis DB
+//   - mysql: users persist in MySQL, optionally cached in the 'user' Redis DB
+func ProvideUserService(cfg *config.Config, redisProvider *infraRedis.Provider, mysqlClient *mysql.Client) ports.UserService {
+	userRedisClient := redisProvider.GetUser()
+
+	switch cfg.UserStore.Store {
+	case StoreMySQL:
+		if mysqlClient == nil {
+			panic("MySQL user store selected but MySQL is not initialized")
+		}
+		var opts []userMysql.Option
+		if cfg.UserStore.CacheTTLSec > 0 && userRedisClient != nil {
+			opts = append(opts, userMysql.WithCache(userRedisClient, time.Duration(cfg.UserStore.CacheTTLSec)*time.Second))
+		}
+		return userMysql.NewUserService(mysqlClient.DB(), opts...)
+	case StoreRedis, "":
 		if userRedisClient == nil {
 			panic("Redis User DB (key: 'user') not found in config")
 		}
 		return user.NewUserService(userRedisClient)
+	default:
+		panic(fmt.Sprintf("unsupported user store: %s", cfg.UserStore.Store))
 	}
 }
 
 // ProvideRegistry creates a ServiceRegistry using the 'central' Redis DB
-func ProvideRegistry(_ *config.Config, redisProvider *infraRedis.Provider) ports.RegistryService {
+// Load balancing strategies are configured via cfg.Routing
+func ProvideRegistry(cfg *config.Config, redisProvider *infraRedis.Provider) ports.RegistryService {
 	centralRedisClient := redisProvider.GetCentral()
 	if centralRedisClient == nil {
 		panic("Redis Central DB (key: 'central') not found in config")
 	}
-	return registry.NewRedisRegistry(centralRedisClient)
+
+	var opts []registry.RegistryOption
+	if name := cfg.Routing.DefaultStrategy; name != "" {
+		if strategy, ok := registry.NewStrategy(name); ok {
+			opts = append(opts, registry.WithDefaultStrategy(strategy))
+		} else {
+			slog.Warn("Unknown default routing strategy, fallback to random", "strategy", name)
+		}
+	}
+	for gameIDStr, name := range cfg.Routing.GameStrategies {
+		var gameID int32
+		if _, err := fmt.Sscanf(gameIDStr, "%d", &gameID); err != nil {
+			slog.Warn("Invalid game id in routing config", "game_id", gameIDStr)
+			continue
+		}
+		strategy, ok := registry.NewStrategy(name)
+		if !ok {
+			slog.Warn("Unknown routing strategy", "game_id", gameID, "strategy", name)
+			continue
+		}
+		opts = append(opts, registry.WithGameStrategy(gameID, strategy))
+	}
+
+	return registry.NewRedisRegistry(centralRedisClient, opts...)
 }
 
-// ProvideWalletService selects implementation based on Environment
-func ProvideWalletService(_ *config.Config, _ *infraRedis.Provider) ports.WalletService {
-	slog.Warn("Using Mock Wallet in PROD (Not implemented yet)")
-	return wallet.NewMockWallet()
+// ProvideRouteRules converts the version routing rules in cfg.Routing.Rules
+// Rules without a version or with an out of range percent are skipped
+func ProvideRouteRules(cfg *config.Config) []domain.RouteRule {
+	rules := make([]domain.RouteRule, 0, len(cfg.Routing.Rules))
+	for i, r := range cfg.Routing.Rules {
+		if r.Version == "" || r.Percent < 0 || r.Percent > 100 {
+			slog.Warn("Invalid routing rule, skipped", "index", i, "version", r.Version, "percent", r.Percent)
+			continue
+		}
+		rules = append(rules, domain.RouteRule{
+			GameID:           r.GameID,
+			Version:          r.Version,
+			Percent:          r.Percent,
+			UserIDs:          r.UserIDs,
+			MinClientVersion: r.MinClientVersion,
+		})
+	}
+	return rules
+}
+
+// ProvideWalletService creates a WalletService based on config
+//   - mock (default): in-memory wallet for development
+//   - mysql: transactional ledger in MySQL
+func ProvideWalletService(cfg *config.Config, _ *infraRedis.Provider, mysqlClient *mysql.Client) ports.WalletService {
+	switch cfg.Wallet.Store {
+	case StoreMySQL:
+		if mysqlClient == nil {
+			panic("MySQL wallet store selected but MySQL is not initialized")
+		}
+		var opts []walletMysql.Option
+		if cfg.Wallet.InitialBalance != "" {
+			initial, err := decimal.NewFromString(cfg.Wallet.InitialBalance)
+			if err != nil {
+				panic(fmt.Sprintf("invalid wallet.initial_balance: %v", err))
+			}
+			opts = append(opts, walletMysql.WithInitialBalance(initial))
+		}
+		return walletMysql.NewWalletService(mysqlClient.DB(), opts...)
+	case StoreMock, "":
+		slog.Warn("Using Mock Wallet (in-memory, not persistent)")
+		return wallet.NewMockWallet()
+	default:
+		panic(fmt.Sprintf("unsupported wallet store: %s", cfg.Wallet.Store))
+	}
+}
+
+// ProvideResumeStore creates a ResumeStore using the 'session' Redis DB
+// Returns nil (resume disabled) if the DB is not configured or grace period is 0
+func ProvideResumeStore(cfg *config.Config, redisProvider *infraRedis.Provider) ports.ResumeStore {
+	if cfg.WSS.ResumeGraceSec <= 0 {
+		return nil
+	}
+	sessionRedisClient := redisProvider.GetSession()
+	if sessionRedisClient == nil {
+		slog.Warn("Session resume disabled: Redis Session DB (key: 'session') not found in config")
+		return nil
+	}
+	return sessionStore.NewResumeStore(sessionRedisClient)
+}
+
+// ProvidePresenceStore creates a PresenceStore using the 'session' Redis DB
+// Returns nil (presence disabled) if the DB is not configured
+func ProvidePresenceStore(_ *config.Config, redisProvider *infraRedis.Provider) ports.PresenceStore {
+	sessionRedisClient := redisProvider.GetSession()
+	if sessionRedisClient == nil {
+		slog.Warn("Presence disabled: Redis Session DB (key: 'session') not found in config")
+		return nil
+	}
+	return sessionStore.NewPresenceStore(sessionRedisClient)
+}
+
+// ProvidePushBus creates a Redis Pub/Sub PushBus using the 'session' Redis DB connection
+// Returns nil (cluster push disabled) if the DB is not configured
+func ProvidePushBus(_ *config.Config, redisProvider *infraRedis.Provider) ports.PushBus {
+	sessionRedisClient := redisProvider.GetSession()
+	if sessionRedisClient == nil {
+		return nil
+	}
+	return pushBus.NewBus(sessionRedisClient)
+}
+
+// ProvideMaintenanceStore creates a MaintenanceStore using the 'central' Redis DB
+// Returns nil (maintenance disabled) if the DB is not configured
+func ProvideMaintenanceStore(_ *config.Config, redisProvider *infraRedis.Provider) ports.MaintenanceStore {
+	centralRedisClient := redisProvider.GetCentral()
+	if centralRedisClient == nil {
+		slog.Warn("Maintenance disabled: Redis Central DB (key: 'central') not found in config")
+		return nil
+	}
+	return maintenanceStore.NewStore(centralRedisClient)
+}
+
+// ProvideTokenVerifier creates a JWT TokenVerifier from the auth config
+// Returns nil (JWT disabled) if no algorithm is configured
+func ProvideTokenVerifier(cfg *config.Config) (ports.TokenVerifier, error) {
+	jwtCfg := cfg.Auth.JWT
+	opts := []jwt.Option{
+		jwt.WithIssuer(jwtCfg.Issuer),
+		jwt.WithAudience(jwtCfg.Audience),
+		jwt.WithLeeway(time.Duration(jwtCfg.LeewaySec) * time.Second),
+		jwt.WithNameClaim(jwtCfg.NameClaim),
+	}
+
+	switch jwtCfg.Algorithm {
+	case "":
+		return nil, nil
+	case jwt.AlgorithmHS256:
+		return jwt.NewHS256Verifier([]byte(jwtCfg.Secret), opts...)
+	case jwt.AlgorithmRS256:
+		var keys map[string]*rsa.PublicKey
+		switch {
+		case jwtCfg.JWKSFile != "":
+			jwks, err := jwt.LoadJWKSFile(jwtCfg.JWKSFile)
+			if err != nil {
+				return nil, err
+			}
+			keys = jwks
+		case jwtCfg.PublicKeyFile != "":
+			key, err := jwt.LoadPublicKeyFile(jwtCfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			keys = map[string]*rsa.PublicKey{"": key}
+		default:
+			return nil, fmt.Errorf("RS256 requires auth.jwt.jwks_file or auth.jwt.public_key_file")
+		}
+		return jwt.NewRS256Verifier(keys, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported jwt algorithm: %s", jwtCfg.Algorithm)
+	}
 }
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	connector_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/connector"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+)
+
+const (
+	// broadcastConcurrency 廣播時同時進行的 Connector RPC 上限
+	broadcastConcurrency = 8
+	// defaultSendTimeout 呼叫端未設定 Deadline 時的推播逾時
+	defaultSendTimeout = 3 * time.Second
+)
+
+// errNoConnector 沒有可用的 Connector 連線 (未設定 Pool 且無串流，e.g. 單元測試)
+// 訊息並未嘗試發送，Send 與 Broadcast 視為不發送 (No-op)，不回報投遞失敗
+var errNoConnector = errors.New("engine: no connector pool")
+
+// BroadcastError 廣播部分失敗，記錄每個未送達 Session 的原因
+// 可用 errors.Is(err, ErrSessionNotFound) 等判斷是否包含特定原因
+type BroadcastError struct {
+	Failed map[string]error // key: SessionID
+	Total  int              // 本次廣播的 Session 總數
+}
+
+func (e *BroadcastError) Error() string {
+	for sessionID, err := range e.Failed {
+		return fmt.Sprintf("engine: broadcast failed for %d of %d sessions (e.g. %s: %v)", len(e.Failed), e.Total, sessionID, err)
+	}
+	return "engine: broadcast failed"
+}
+
+// Unwrap 回傳所有不重複的失敗原因
+func (e *BroadcastError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Failed {
+		if !containsErr(errs, err) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func containsErr(errs []error, target error) bool {
+	for _, err := range errs {
+		if err == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Broadcast 推播訊息給多個玩家
+// 依 ConnectorHost 分組，每個 Connector 只發送一次 RPC (並行數上限 broadcastConcurrency)
+// 全部送達回傳 nil，否則回傳 *BroadcastError
+func Broadcast(ctx context.Context, peers []*Peer, payload []byte) error {
+	if len(peers) == 0 {
+		return nil
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSendTimeout)
+		defer cancel()
+	}
+
+	groups := make(map[string][]*Peer)
+	for _, p := range peers {
+		groups[p.ConnectorHost] = append(groups[p.ConnectorHost], p)
+	}
+
+	var (
+		mu     sync.Mutex
+		failed = make(map[string]error)
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, broadcastConcurrency)
+	)
+	for host, group := range groups {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(host string, group []*Peer) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			sessionIDs := make([]string, len(group))
+			for i, p := range group {
+				sessionIDs[i] = p.SessionID
+			}
+			// 同一 Server 的 Peer 共用 Pool 與串流
+			statuses, err := pushToConnector(ctx, group[0].rpcPool, group[0].channels, host, sessionIDs, payload)
+			if errors.Is(err, errNoConnector) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range sessionIDs {
+				if err != nil {
+					failed[id] = err
+				} else if statusErr := connector_sdk.StatusError(statuses[id]); statusErr != nil {
+					failed[id] = statusErr
+				}
+			}
+		}(host, group)
+	}
+	wg.Wait()
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BroadcastError{Failed: failed, Total: len(peers)}
+}
+
+// pushToConnector 經由單一 Connector 推播給多個 Session，回傳各 Session 的投遞狀態
+// 優先使用 Connector 串流 (與玩家訊息共用同一條連線，保留推播順序)，串流已關閉時退回 Unary ConnectorRPC
+func pushToConnector(ctx context.Context, pool *grpcpkg.Pool, channels *channelRegistry, host string, sessionIDs []string, payload []byte) (_ map[string]connectorRPC.DeliveryStatus, err error) {
+	ctx, span := tracing.Start(ctx, "Engine.Push", attribute.String("connector", host), attribute.Int("sessions", len(sessionIDs)))
+	defer func() { tracing.End(span, err) }()
+
+	if channels != nil {
+		if cc := channels.get(host); cc != nil {
+			results, err := cc.push(ctx, sessionIDs, payload)
+			if err == nil {
+				statuses := make(map[string]connectorRPC.DeliveryStatus, len(results))
+				for _, r := range results {
+					statuses[r.SessionId] = r.Status
+				}
+				return statuses, nil
+			}
+			if !errors.Is(err, ErrChannelClosed) {
+				return nil, err
+			}
+			// 串流已關閉，退回 Unary
+		}
+	}
+
+	if pool == nil {
+		return nil, errNoConnector
+	}
+	conn, err := pool.GetConnection(host)
+	if err != nil {
+		return nil, err
+	}
+	return connector_sdk.NewClient(conn).Broadcast(ctx, sessionIDs, payload)
+}
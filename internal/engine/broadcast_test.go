@@ -0,0 +1,96 @@
+package engine_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+)
+
+// fakeConnector 記錄 SendMessage 次數，offline 中的 Session 回報 NOT_FOUND
+type fakeConnector struct {
+	connectorRPC.UnimplementedConnectorRPCServer
+	calls   atomic.Int32
+	offline map[string]bool
+}
+
+func (f *fakeConnector) SendMessage(_ context.Context, req *connectorRPC.SendMessageReq) (*connectorRPC.SendMessageResp, error) {
+	f.calls.Add(1)
+	resp := &connectorRPC.SendMessageResp{}
+	for _, id := range req.SessionIds {
+		status := connectorRPC.DeliveryStatus_DELIVERED
+		if f.offline[id] {
+			status = connectorRPC.DeliveryStatus_NOT_FOUND
+		}
+		resp.Results = append(resp.Results, &connectorRPC.DeliveryResult{SessionId: id, Status: status})
+	}
+	return resp, nil
+}
+
+func startFakeConnector(t *testing.T, f *fakeConnector) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	connectorRPC.RegisterConnectorRPCServer(server, f)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func TestBroadcast_GroupsByConnector(t *testing.T) {
+	connA := &fakeConnector{}
+	connB := &fakeConnector{offline: map[string]bool{"b-1": true}}
+	hostA := startFakeConnector(t, connA)
+	hostB := startFakeConnector(t, connB)
+
+	pool := grpcpkg.NewPool()
+	defer pool.Close()
+
+	mgr := engine.NewPeerManager()
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("a-%d", i)
+		mgr.Add(engine.NewPeer(&domain.User{ID: id}, id, hostA, pool))
+	}
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("b-%d", i)
+		mgr.Add(engine.NewPeer(&domain.User{ID: id}, id, hostB, pool))
+	}
+
+	err := mgr.Broadcast(context.Background(), []byte("tick"))
+
+	// 每個 Connector 只發送一次 RPC
+	assert.Equal(t, int32(1), connA.calls.Load())
+	assert.Equal(t, int32(1), connB.calls.Load())
+
+	// 部分失敗彙整為 BroadcastError
+	var bErr *engine.BroadcastError
+	require.ErrorAs(t, err, &bErr)
+	assert.Equal(t, 8, bErr.Total)
+	assert.Len(t, bErr.Failed, 1)
+	assert.ErrorIs(t, bErr.Failed["b-1"], engine.ErrSessionNotFound)
+	assert.ErrorIs(t, err, engine.ErrSessionNotFound)
+
+	// 全部送達回傳 nil
+	connB.offline = nil
+	assert.NoError(t, engine.Broadcast(context.Background(), []*engine.Peer{mgr.Get("a-0"), mgr.Get("b-1")}, []byte("tick")))
+	assert.Equal(t, int32(2), connA.calls.Load())
+	assert.Equal(t, int32(2), connB.calls.Load())
+}
+
+func TestSend_NoConnectorPool(t *testing.T) {
+	// 未設定 Pool 時不發送，也不回報投遞失敗
+	peer := engine.NewPeer(&domain.User{ID: "a"}, "a", "connector-1", nil)
+	assert.NoError(t, peer.Send(context.Background(), []byte("tick")))
+	assert.NoError(t, engine.Broadcast(context.Background(), []*engine.Peer{peer}, []byte("tick")))
+}
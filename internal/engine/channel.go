@@ -0,0 +1,279 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+)
+
+var (
+	// ErrChannelClosed Connector 串流已關閉，推播未送出 (呼叫端可安全退回 Unary ConnectorRPC)
+	ErrChannelClosed = errors.New("engine: connector channel closed")
+	// ErrChannelAckLost 推播已送出，但串流在收到回執前關閉 (投遞結果未知)
+	ErrChannelAckLost = errors.New("engine: connector channel closed before ack")
+)
+
+// Channel 實作 GameRPC.Channel: 與單一 Connector 之間的雙向串流
+// 首個上行訊框必須為 Hello，之後此 Connector 的玩家訊息與推播皆經由此串流
+func (s *Server) Channel(stream gameRPC.GameRPC_ChannelServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := first.GetHello()
+	if hello == nil || hello.ConnectorHost == "" {
+		return errors.New("engine: first channel frame must be hello")
+	}
+
+	cc := newConnectorChannel(hello.ConnectorHost, stream)
+	if err := cc.send(&gameRPC.ChannelDown{Frame: &gameRPC.ChannelDown_Ready{Ready: &gameRPC.ChannelReady{}}}); err != nil {
+		return err
+	}
+	s.channels.add(cc)
+	defer s.channels.remove(cc)
+	slog.Info("Connector channel opened", "service", s.serviceName, "connector", cc.host)
+
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			frame, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			switch f := frame.Frame.(type) {
+			case *gameRPC.ChannelUp_Message:
+				s.dispatchChannelMessage(stream.Context(), cc, f.Message)
+			case *gameRPC.ChannelUp_PushAck:
+				cc.resolve(f.PushAck.PushId, f.PushAck.Results)
+			}
+		}
+	}()
+
+	// 回傳即結束串流 (Recv 會隨 stream Context 取消而返回)
+	select {
+	case err = <-recvErr:
+	case <-cc.closing:
+	}
+	cc.shutdown()
+	slog.Info("Connector channel closed", "service", s.serviceName, "connector", cc.host, "error", err)
+	return nil
+}
+
+// CloseChannels 關閉所有 Connector 串流 (須於 grpc.Server.GracefulStop 前呼叫，否則 GracefulStop 會等待串流結束)
+func (s *Server) CloseChannels() {
+	s.channels.closeAll()
+}
+
+// dispatchChannelMessage 依 SessionID 排入序列化佇列處理
+// 同一 Session 的訊息依到達順序處理，不同 Session 之間並行
+func (s *Server) dispatchChannelMessage(ctx context.Context, cc *connectorChannel, msg *gameRPC.ChannelMessage) {
+	if msg.Req == nil || msg.Req.Header == nil {
+		return
+	}
+	// 串流沒有逐筆的 gRPC Metadata，由訊息標頭延續 Connector 端的追蹤
+	ctx = tracing.Extract(ctx, msg.Req.Header.TraceContext)
+	s.lanes.submit(msg.Req.Header.SessionId, func() {
+		resp, err := s.OnMessage(ctx, msg.Req)
+		if resp == nil {
+			resp = &gameRPC.MsgResp{Code: proto.ErrorCode_SERVER_ERROR}
+			if err != nil {
+				resp.ErrorMessage = err.Error()
+			}
+		}
+		if err := cc.send(&gameRPC.ChannelDown{Frame: &gameRPC.ChannelDown_Reply{
+			Reply: &gameRPC.ChannelReply{Seq: msg.Seq, Resp: resp},
+		}}); err != nil {
+			slog.Warn("Failed to send channel reply", "connector", cc.host, "error", err)
+		}
+	})
+}
+
+// connectorChannel 代表一條已建立的 Connector 串流
+type connectorChannel struct {
+	host   string
+	stream gameRPC.GameRPC_ChannelServer
+	sendMu sync.Mutex  // gRPC 串流不允許並發 Send
+	closed atomic.Bool // Handler 已返回，不可再 Send
+
+	mu      sync.Mutex
+	pending map[uint64]chan []*connectorRPC.DeliveryResult // 等待回執的推播 (nil 代表已關閉)
+	pushID  atomic.Uint64
+
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+func newConnectorChannel(host string, stream gameRPC.GameRPC_ChannelServer) *connectorChannel {
+	return &connectorChannel{
+		host:    host,
+		stream:  stream,
+		pending: make(map[uint64]chan []*connectorRPC.DeliveryResult),
+		closing: make(chan struct{}),
+	}
+}
+
+// push 經由串流推播訊息並等待 Connector 回執，回傳每個 Session 的投遞結果
+func (c *connectorChannel) push(ctx context.Context, sessionIDs []string, payload []byte) ([]*connectorRPC.DeliveryResult, error) {
+	id := c.pushID.Add(1)
+	ch := make(chan []*connectorRPC.DeliveryResult, 1)
+
+	c.mu.Lock()
+	if c.pending == nil {
+		c.mu.Unlock()
+		return nil, ErrChannelClosed
+	}
+	c.pending[id] = ch
+	c.mu.Unlock()
+	defer c.forget(id)
+
+	err := c.send(&gameRPC.ChannelDown{Frame: &gameRPC.ChannelDown_Push{
+		Push: &gameRPC.ChannelPush{PushId: id, SessionIds: sessionIDs, Payload: payload, TraceContext: tracing.Inject(ctx)},
+	}})
+	if err != nil {
+		return nil, ErrChannelClosed
+	}
+
+	select {
+	case results, ok := <-ch:
+		if !ok {
+			return nil, ErrChannelAckLost
+		}
+		return results, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// resolve 將推播回執交給等待中的 push
+func (c *connectorChannel) resolve(id uint64, results []*connectorRPC.DeliveryResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ch, ok := c.pending[id]; ok {
+		ch <- results
+		delete(c.pending, id)
+	}
+}
+
+func (c *connectorChannel) forget(id uint64) {
+	c.mu.Lock()
+	if c.pending != nil {
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
+}
+
+func (c *connectorChannel) send(frame *gameRPC.ChannelDown) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.closed.Load() {
+		return ErrChannelClosed
+	}
+	return c.stream.Send(frame)
+}
+
+// close 通知 Channel Handler 結束串流
+func (c *connectorChannel) close() {
+	c.closeOnce.Do(func() { close(c.closing) })
+}
+
+// shutdown 停止發送並讓所有等待回執的推播失敗
+// 持有 sendMu 設定 closed: 等待進行中的 Send 完成，之後的 Send 一律回傳 ErrChannelClosed，
+// 確保 Handler 返回 (串流結束) 後不會再有 Send
+func (c *connectorChannel) shutdown() {
+	c.sendMu.Lock()
+	c.closed.Store(true)
+	c.sendMu.Unlock()
+
+	c.mu.Lock()
+	for _, ch := range c.pending {
+		close(ch)
+	}
+	c.pending = nil
+	c.mu.Unlock()
+}
+
+// channelRegistry 以 Connector Host 索引目前的串流 (同一 Connector 重連時以新串流為準)
+type channelRegistry struct {
+	mu       sync.RWMutex
+	channels map[string]*connectorChannel
+}
+
+func newChannelRegistry() *channelRegistry {
+	return &channelRegistry{channels: make(map[string]*connectorChannel)}
+}
+
+func (r *channelRegistry) add(cc *connectorChannel) {
+	r.mu.Lock()
+	old := r.channels[cc.host]
+	r.channels[cc.host] = cc
+	r.mu.Unlock()
+	if old != nil {
+		old.close()
+	}
+}
+
+func (r *channelRegistry) remove(cc *connectorChannel) {
+	r.mu.Lock()
+	if r.channels[cc.host] == cc {
+		delete(r.channels, cc.host)
+	}
+	r.mu.Unlock()
+}
+
+func (r *channelRegistry) get(host string) *connectorChannel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.channels[host]
+}
+
+func (r *channelRegistry) closeAll() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, cc := range r.channels {
+		cc.close()
+	}
+}
+
+// sessionLanes 以 Key (SessionID) 序列化執行任務: 同一 Key 依提交順序執行，不同 Key 並行
+type sessionLanes struct {
+	mu    sync.Mutex
+	lanes map[string][]func() // Key 存在代表該 Lane 正在執行
+}
+
+func newSessionLanes() *sessionLanes {
+	return &sessionLanes{lanes: make(map[string][]func())}
+}
+
+func (l *sessionLanes) submit(key string, task func()) {
+	l.mu.Lock()
+	queue, running := l.lanes[key]
+	l.lanes[key] = append(queue, task)
+	l.mu.Unlock()
+	if !running {
+		go l.drain(key)
+	}
+}
+
+func (l *sessionLanes) drain(key string) {
+	for {
+		l.mu.Lock()
+		queue := l.lanes[key]
+		if len(queue) == 0 {
+			delete(l.lanes, key)
+			l.mu.Unlock()
+			return
+		}
+		task := queue[0]
+		l.lanes[key] = queue[1:]
+		l.mu.Unlock()
+		task()
+	}
+}
@@ -0,0 +1,223 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	game_client "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/game"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
+)
+
+// startChannelServer 以 bufconn 啟動 Game Server，回傳 Client 連線
+func startChannelServer(t *testing.T, server *engine.Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	gameRPC.RegisterGameRPCServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.CloseChannels()
+		grpcServer.GracefulStop()
+	})
+	return conn
+}
+
+// TestServer_Channel_MessageAndPush 測試串流轉發訊息、依 Session 保序，以及經由串流推播並取得投遞結果
+func TestServer_Channel_MessageAndPush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock_engine.NewMockGameHandler(ctrl)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+
+	userID := "user-123"
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID}, nil)
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), userID).Return(decimal.NewFromInt(100), nil)
+	mockHandler.EXPECT().OnJoin(gomock.Any(), gomock.Any()).Return(nil)
+
+	// 記錄處理順序；第一則訊息觸發推播，Connector 回報 Session 不存在
+	var mu sync.Mutex
+	var order []string
+	var pushErr error
+	mockHandler.EXPECT().OnMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, peer *engine.Peer, payload []byte) ([]byte, error) {
+			if action := engine.Action(ctx); action != "echo" {
+				t.Errorf("expected action echo, got %q", action)
+			}
+			if string(payload) == "m0" {
+				err := peer.Send(ctx, []byte("push"))
+				mu.Lock()
+				pushErr = err
+				mu.Unlock()
+			}
+			mu.Lock()
+			order = append(order, string(payload))
+			mu.Unlock()
+			return append([]byte("echo:"), payload...), nil
+		}).Times(5)
+
+	server := engine.NewServer(mockHandler, nil, true, "test-service", mockUserSvc, mockWalletSvc)
+	conn := startChannelServer(t, server)
+
+	_, err := server.OnPlayerJoin(context.Background(), &gameRPC.JoinReq{
+		Header:        &proto.PacketHeader{UserId: userID, SessionId: "sess-abc"},
+		ConnectorHost: "connector-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pushed := make(chan string, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	stream, err := game_client.OpenStream(ctx, conn, "connector-1", func(_ context.Context, sessionIDs []string, payload []byte) []*connectorRPC.DeliveryResult {
+		pushed <- string(payload)
+		return []*connectorRPC.DeliveryResult{{SessionId: sessionIDs[0], Status: connectorRPC.DeliveryStatus_NOT_FOUND}}
+	})
+	if err != nil {
+		t.Fatalf("open stream failed: %v", err)
+	}
+	defer stream.Close()
+
+	// 同一 Session 連續送出 (不等待回應)，處理順序須與送出順序相同
+	var wg sync.WaitGroup
+	for _, payload := range []string{"m0", "m1", "m2", "m3", "m4"} {
+		wg.Add(1)
+		go func(p string) {
+			defer wg.Done()
+			resp, err := stream.SendMessage(ctx, userID, "sess-abc", "echo", []byte(p))
+			if err != nil {
+				t.Errorf("send %s failed: %v", p, err)
+				return
+			}
+			if string(resp.Payload) != "echo:"+p {
+				t.Errorf("expected echo:%s, got %s", p, resp.Payload)
+			}
+		}(payload)
+		// 確保送出順序
+		time.Sleep(5 * time.Millisecond)
+	}
+	wg.Wait()
+
+	if got := <-pushed; got != "push" {
+		t.Errorf("expected push payload, got %s", got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !errors.Is(pushErr, engine.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", pushErr)
+	}
+	if strings.Join(order, ",") != "m0,m1,m2,m3,m4" {
+		t.Errorf("messages processed out of order: %v", order)
+	}
+}
+
+// TestServer_Channel_PropagatesTrace 測試追蹤上下文經由串流傳到 Game Handler，並隨 Peer.Send 推播帶回 Connector
+func TestServer_Channel_PropagatesTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock_engine.NewMockGameHandler(ctrl)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+
+	userID := "user-123"
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID}, nil)
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), userID).Return(decimal.NewFromInt(100), nil)
+	mockHandler.EXPECT().OnJoin(gomock.Any(), gomock.Any()).Return(nil)
+
+	handlerTrace := make(chan string, 1)
+	mockHandler.EXPECT().OnMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, peer *engine.Peer, payload []byte) ([]byte, error) {
+			handlerTrace <- tracing.TraceID(ctx)
+			_ = peer.Send(ctx, []byte("push"))
+			return payload, nil
+		})
+
+	server := engine.NewServer(mockHandler, nil, true, "test-service", mockUserSvc, mockWalletSvc)
+	conn := startChannelServer(t, server)
+
+	_, err := server.OnPlayerJoin(context.Background(), &gameRPC.JoinReq{
+		Header:        &proto.PacketHeader{UserId: userID, SessionId: "sess-abc"},
+		ConnectorHost: "connector-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pushTrace := make(chan string, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	stream, err := game_client.OpenStream(ctx, conn, "connector-1", func(ctx context.Context, sessionIDs []string, _ []byte) []*connectorRPC.DeliveryResult {
+		pushTrace <- tracing.TraceID(ctx)
+		return []*connectorRPC.DeliveryResult{{SessionId: sessionIDs[0], Status: connectorRPC.DeliveryStatus_DELIVERED}}
+	})
+	if err != nil {
+		t.Fatalf("open stream failed: %v", err)
+	}
+	defer stream.Close()
+
+	// 模擬 Connector 收到訊框時建立的根 Span
+	frameCtx, span := tracing.Start(ctx, "Connector.OnMessage")
+	traceID := tracing.TraceID(frameCtx)
+	if _, err := stream.SendMessage(frameCtx, userID, "sess-abc", "echo", []byte("m0")); err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	span.End()
+
+	if got := <-handlerTrace; got != traceID {
+		t.Errorf("handler trace ID = %q, want %q", got, traceID)
+	}
+	if got := <-pushTrace; got != traceID {
+		t.Errorf("push trace ID = %q, want %q", got, traceID)
+	}
+
+	// Game Server 端的 Span 皆屬於同一條追蹤
+	names := map[string]bool{}
+	for _, s := range recorder.Ended() {
+		if s.SpanContext().TraceID().String() == traceID {
+			names[s.Name()] = true
+		}
+	}
+	for _, name := range []string{"GameRPC.Channel/Message", "GameServer.OnMessage", "GameHandler.OnMessage", "Engine.Push"} {
+		if !names[name] {
+			t.Errorf("expected span %s in trace, got %v", name, names)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+)
+
+// DrainHandler 可選介面: GameHandler 若實作此介面，伺服器進入排空模式時會被呼叫
+// 可用來通知玩家伺服器即將關閉 (e.g. 提示在 deadline 前結束牌局)；peers 為當下在線的玩家 (Stateless 為空)
+type DrainHandler interface {
+	OnDrain(ctx context.Context, peers []*Peer, deadline time.Time)
+}
+
+// ErrDraining 伺服器排空中，不再接受新玩家
+var ErrDraining = errors.New("engine: server is draining")
+
+const (
+	// kickReasonDrain 排空期限到達時踢除剩餘玩家的原因
+	kickReasonDrain = "Server Shutting Down"
+	// drainKickGrace 踢除剩餘玩家後，等待 Connector 回呼 OnPlayerQuit (退款、離開房間) 的時間
+	drainKickGrace = 5 * time.Second
+	// drainPollInterval 檢查是否已排空的間隔
+	drainPollInterval = 500 * time.Millisecond
+)
+
+// WithDrainTimeout 設定排空期限的預設值: 收到停止信號時先排空再關閉，Drain RPC 未指定期限時亦使用此值
+// 0 代表停止時不排空 (直接登出並關閉)
+func WithDrainTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.drainTimeout = timeout
+	}
+}
+
+// StartDrain 進入排空模式: 標記 Central 租約為排空中 (不再分配新玩家)、拒絕新玩家加入，並呼叫 DrainHandler.OnDrain
+// 已在線的玩家可繼續遊戲直到離開或房間結束。已在排空中時只會提前期限，回傳實際的排空期限
+func (s *Server) StartDrain(ctx context.Context, deadline time.Time) time.Time {
+	s.drainMu.Lock()
+	if s.draining {
+		if deadline.Before(s.drainDeadline) {
+			s.drainDeadline = deadline
+		}
+		deadline = s.drainDeadline
+		s.drainMu.Unlock()
+		return deadline
+	}
+	s.draining = true
+	s.drainDeadline = deadline
+	s.drainMu.Unlock()
+
+	peers := s.peers()
+	slog.Info("Server draining", "service", s.serviceName, "deadline", deadline, "peers", len(peers), "rooms", s.rooms.Count())
+
+	if s.markDraining != nil {
+		if err := s.markDraining(ctx); err != nil {
+			slog.Warn("Failed to mark lease as draining", "service", s.serviceName, "error", err)
+		}
+	}
+	if dh, ok := s.handler.(DrainHandler); ok {
+		if err := s.invoke(ctx, "OnDrain", func(ctx context.Context) { dh.OnDrain(ctx, peers, deadline) }); err != nil {
+			slog.Warn("Handler.OnDrain failed", "error", err)
+		}
+	}
+	return deadline
+}
+
+// Draining 是否處於排空模式
+func (s *Server) Draining() bool {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	return s.draining
+}
+
+// Drained 是否已排空: 無在線玩家 (Stateful) 且無處理中的 RPC
+func (s *Server) Drained() bool {
+	if s.inFlight.Load() > 0 {
+		return false
+	}
+	return s.peerMgr == nil || s.peerMgr.Count() == 0
+}
+
+// Drain 進入排空模式 (GameRPC)，deadline_sec 為 0 時使用 WithDrainTimeout 的預設值
+// 已在排空中時不會延後期限，回傳實際生效的期限 (要求較晚時 DeadlineClamped 為 true)
+func (s *Server) Drain(ctx context.Context, req *gameRPC.DrainReq) (*gameRPC.DrainResp, error) {
+	timeout := s.drainTimeout
+	if req.DeadlineSec > 0 {
+		timeout = time.Duration(req.DeadlineSec) * time.Second
+	}
+	if timeout <= 0 {
+		return &gameRPC.DrainResp{
+			Code:         proto.ErrorCode_INVALID_PARAMS,
+			ErrorMessage: "deadline_sec is required",
+		}, nil
+	}
+
+	// 已在排空中時期限只會提前，要求的期限較晚時回傳既有期限並標記 DeadlineClamped
+	requested := time.Now().Add(timeout)
+	deadline := s.StartDrain(ctx, requested)
+	return &gameRPC.DrainResp{
+		Code:            proto.ErrorCode_SUCCESS,
+		PeerCount:       int32(len(s.peers())),
+		RoomCount:       int32(s.rooms.Count()),
+		Deadline:        deadline.Unix(),
+		DeadlineClamped: deadline.Before(requested),
+	}, nil
+}
+
+// drainAndWait 排空並等待玩家離開 (Blocking，於停止信號時呼叫)
+// 期限到達時踢除剩餘玩家，並再等待 drainKickGrace 讓 Connector 回呼 OnPlayerQuit
+func (s *Server) drainAndWait(ctx context.Context) {
+	s.drainMu.Lock()
+	draining, deadline := s.draining, s.drainDeadline
+	s.drainMu.Unlock()
+
+	// 已由 Drain RPC 進入排空時沿用原本的期限
+	if !draining {
+		if s.drainTimeout <= 0 {
+			return
+		}
+		deadline = s.StartDrain(ctx, time.Now().Add(s.drainTimeout))
+	}
+
+	waitCtx, cancel := context.WithDeadline(ctx, deadline)
+	err := s.waitDrained(waitCtx)
+	cancel()
+	if err == nil {
+		slog.Info("Server drained", "service", s.serviceName)
+		return
+	}
+
+	remaining := s.peers()
+	slog.Warn("Drain deadline reached, kicking remaining players", "service", s.serviceName, "peers", len(remaining))
+	for _, peer := range remaining {
+		if err := peer.Kick(ctx, kickReasonDrain); err != nil {
+			slog.Warn("Failed to kick peer", "session_id", peer.SessionID, "error", err)
+		}
+	}
+
+	graceCtx, cancel := context.WithTimeout(ctx, drainKickGrace)
+	defer cancel()
+	if err := s.waitDrained(graceCtx); err != nil {
+		slog.Warn("Shutting down with remaining players", "service", s.serviceName, "peers", len(s.peers()))
+	}
+}
+
+// waitDrained 等待排空完成，ctx 結束時回傳 ctx.Err()
+func (s *Server) waitDrained(ctx context.Context) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.Drained() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// peers 目前在線的玩家 (Stateless 為空)
+func (s *Server) peers() []*Peer {
+	if s.peerMgr == nil {
+		return nil
+	}
+	return s.peerMgr.Peers()
+}
@@ -0,0 +1,103 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
+)
+
+// drainGame 實作 GameHandler + DrainHandler，記錄 OnDrain 呼叫
+type drainGame struct {
+	*mock_engine.MockGameHandler
+
+	calls    int
+	peers    int
+	deadline time.Time
+}
+
+func (g *drainGame) OnDrain(_ context.Context, peers []*engine.Peer, deadline time.Time) {
+	g.calls++
+	g.peers = len(peers)
+	g.deadline = deadline
+}
+
+// TestServer_Drain 測試 Drain RPC: 通知 Handler、拒絕新玩家、已在線玩家離開後即排空
+func TestServer_Drain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	game := &drainGame{MockGameHandler: mock_engine.NewMockGameHandler(ctrl)}
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), "user-1").Return(&domain.User{ID: "user-1"}, nil)
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), "user-1").Return(decimal.NewFromInt(100), nil)
+	game.EXPECT().OnJoin(gomock.Any(), gomock.Any()).Return(nil)
+	game.EXPECT().OnQuit(gomock.Any(), gomock.Any()).Return(nil)
+
+	server := engine.NewServer(game, nil, true, "test-service", mockUserSvc, mockWalletSvc, engine.WithDrainTimeout(time.Minute))
+	ctx := context.Background()
+
+	_, err := server.OnPlayerJoin(ctx, &gameRPC.JoinReq{Header: &proto.PacketHeader{UserId: "user-1", SessionId: "sess-1"}})
+	require.NoError(t, err)
+
+	// 未指定期限時使用預設值
+	resp, err := server.Drain(ctx, &gameRPC.DrainReq{})
+	require.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_SUCCESS, resp.Code)
+	assert.Equal(t, int32(1), resp.PeerCount)
+	assert.True(t, server.Draining())
+	assert.False(t, server.Drained())
+	assert.Equal(t, 1, game.calls)
+	assert.Equal(t, 1, game.peers)
+	assert.Equal(t, game.deadline.Unix(), resp.Deadline)
+
+	// 再次呼叫只會提前期限，不會重複通知
+	resp, err = server.Drain(ctx, &gameRPC.DrainReq{DeadlineSec: 10})
+	require.NoError(t, err)
+	assert.Less(t, resp.Deadline, game.deadline.Unix())
+	assert.False(t, resp.DeadlineClamped)
+	assert.Equal(t, 1, game.calls)
+	earlier := resp.Deadline
+
+	// 較晚的期限不生效: 回傳既有期限並標記
+	resp, err = server.Drain(ctx, &gameRPC.DrainReq{DeadlineSec: 600})
+	require.NoError(t, err)
+	assert.Equal(t, earlier, resp.Deadline)
+	assert.True(t, resp.DeadlineClamped)
+
+	// 排空中拒絕新玩家
+	joinResp, err := server.OnPlayerJoin(ctx, &gameRPC.JoinReq{Header: &proto.PacketHeader{UserId: "user-2", SessionId: "sess-2"}})
+	require.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_DRAINING, joinResp.Code)
+
+	// 已在線玩家離開後即排空
+	_, err = server.OnPlayerQuit(ctx, &gameRPC.QuitReq{Header: &proto.PacketHeader{UserId: "user-1", SessionId: "sess-1"}})
+	require.NoError(t, err)
+	assert.True(t, server.Drained())
+}
+
+// TestServer_Drain_NoDeadline 測試未設定預設期限且未指定期限時拒絕排空
+func TestServer_Drain_NoDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := engine.NewServer(mock_engine.NewMockGameHandler(ctrl), nil, true, "test-service", nil, nil)
+
+	resp, err := server.Drain(context.Background(), &gameRPC.DrainReq{})
+	require.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_INVALID_PARAMS, resp.Code)
+	assert.False(t, server.Draining())
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrLoopStopped Tick Loop 已停止 (房間已關閉或服務關機中)
+var ErrLoopStopped = errors.New("engine: tick loop stopped")
+
+// TickHandler 可選介面: 啟用 WithServiceTick 時，每個 Tick 在服務的 Loop 上呼叫
+type TickHandler interface {
+	OnTick(ctx context.Context, dt time.Duration)
+}
+
+// RoomTickHandler 可選介面: 啟用 WithRoomTick 時，每個 Tick 在該房間的 Loop 上呼叫
+type RoomTickHandler interface {
+	OnRoomTick(ctx context.Context, room *Room, dt time.Duration)
+}
+
+// loopKey Context Key: 標記目前正在某個 Loop 上執行，避免重入時自我等待 (Deadlock)
+type loopKey struct{}
+
+// loop 單一 Goroutine 的 Actor: 依序執行排入的任務，並以固定頻率呼叫 onTick
+// Handler 回呼皆在同一個 Goroutine 上執行，業務邏輯不需要自行加鎖
+type loop struct {
+	interval time.Duration
+	onTick   func(ctx context.Context, dt time.Duration) // 可為 nil
+	tasks    chan func()
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// newLoop 建立並啟動 Loop
+func newLoop(interval time.Duration, onTick func(ctx context.Context, dt time.Duration)) *loop {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &loop{
+		interval: interval,
+		onTick:   onTick,
+		tasks:    make(chan func(), 256),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	l.ctx = context.WithValue(ctx, loopKey{}, l)
+	go l.run()
+	return l
+}
+
+// call 在 Loop 上執行 fn 並等待完成
+// 若 ctx 已在此 Loop 上 (Handler 內再次呼叫)，直接執行以避免自我等待
+func (l *loop) call(ctx context.Context, fn func(ctx context.Context)) error {
+	if current, _ := ctx.Value(loopKey{}).(*loop); current == l {
+		fn(ctx)
+		return nil
+	}
+
+	loopCtx := context.WithValue(ctx, loopKey{}, l)
+	finished := make(chan struct{})
+	task := func() {
+		defer close(finished)
+		fn(loopCtx)
+	}
+
+	select {
+	case l.tasks <- task:
+	case <-l.ctx.Done():
+		return ErrLoopStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-finished:
+		return nil
+	case <-l.done:
+		// Loop 停止前可能已執行完任務
+		select {
+		case <-finished:
+			return nil
+		default:
+			return ErrLoopStopped
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stop 停止 Loop (不等待執行中的任務)，可重複呼叫
+func (l *loop) stop() {
+	l.stopOnce.Do(l.cancel)
+}
+
+func (l *loop) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+	last := time.Now()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case task := <-l.tasks:
+			l.safe(task)
+		case now := <-ticker.C:
+			dt := now.Sub(last)
+			last = now
+			if l.onTick != nil {
+				l.safe(func() { l.onTick(l.ctx, dt) })
+			}
+		}
+	}
+}
+
+// safe 執行任務並攔截 Panic，避免單一 Handler 錯誤終止整個 Loop
+func (_ *loop) safe(fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("tick loop task panic recovered", "panic", r)
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,140 @@
+package engine_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
+)
+
+// tickGame 檢查 Handler 回呼是否在同一 Loop 上依序執行 (同時執行數 > 1 即為違規)
+type tickGame struct {
+	*mock_engine.MockGameHandler
+	engine.BaseRoomHandler
+
+	running    atomic.Int32
+	overlapped atomic.Bool
+	handled    atomic.Int32
+	ticks      atomic.Int32
+	roomTicks  atomic.Int32
+}
+
+func (g *tickGame) enter() func() {
+	if g.running.Add(1) > 1 {
+		g.overlapped.Store(true)
+	}
+	return func() { g.running.Add(-1) }
+}
+
+func (g *tickGame) OnRoomMessage(_ context.Context, _ *engine.Room, _ *engine.Peer, payload []byte) ([]byte, error) {
+	defer g.enter()()
+	time.Sleep(time.Millisecond)
+	g.handled.Add(1)
+	return payload, nil
+}
+
+func (g *tickGame) OnTick(_ context.Context, _ time.Duration) {
+	defer g.enter()()
+	g.ticks.Add(1)
+}
+
+func (g *tickGame) OnRoomTick(_ context.Context, _ *engine.Room, _ time.Duration) {
+	defer g.enter()()
+	g.roomTicks.Add(1)
+}
+
+func newTickServer(t *testing.T, opts ...engine.ServerOption) (*engine.Server, *tickGame) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id}, nil
+	}).AnyTimes()
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(decimal.Zero, nil).AnyTimes()
+
+	game := &tickGame{MockGameHandler: mock_engine.NewMockGameHandler(ctrl)}
+	// OnJoin 內同步加入房間: 已在 Loop 上時不可自我等待
+	game.EXPECT().OnJoin(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, peer *engine.Peer) error {
+		_, err := peer.Rooms().JoinOrCreate(ctx, peer)
+		return err
+	}).AnyTimes()
+	game.EXPECT().OnQuit(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	server := engine.NewServer(game, nil, true, "fishing", mockUserSvc, mockWalletSvc, opts...)
+	t.Cleanup(server.Close)
+	return server, game
+}
+
+// fire 多個 Session 同時送出訊息
+func fire(t *testing.T, server *engine.Server, sessions []string, perSession int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for _, sess := range sessions {
+		for i := 0; i < perSession; i++ {
+			wg.Add(1)
+			go func(sess string) {
+				defer wg.Done()
+				resp, err := server.OnMessage(context.Background(), &gameRPC.MsgReq{
+					Header:  &proto.PacketHeader{SessionId: sess},
+					Payload: []byte("fire"),
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, proto.ErrorCode_SUCCESS, resp.GetCode())
+			}(sess)
+		}
+	}
+	wg.Wait()
+}
+
+func TestServer_WithRoomTick(t *testing.T) {
+	server, game := newTickServer(t, engine.WithRoomTick(5*time.Millisecond))
+	sessions := []string{"s1", "s2", "s3"}
+	for _, sess := range sessions {
+		require.NoError(t, join(server, sess))
+	}
+
+	fire(t, server, sessions, 10)
+	assert.Equal(t, int32(30), game.handled.Load())
+	assert.Eventually(t, func() bool { return game.roomTicks.Load() > 0 }, time.Second, 5*time.Millisecond)
+	assert.False(t, game.overlapped.Load(), "room callbacks must run sequentially")
+	assert.Zero(t, game.ticks.Load())
+}
+
+func TestServer_WithServiceTick(t *testing.T) {
+	server, game := newTickServer(t, engine.WithServiceTick(5*time.Millisecond), engine.WithRoomTick(time.Millisecond))
+	sessions := []string{"s1", "s2"}
+	for _, sess := range sessions {
+		require.NoError(t, join(server, sess))
+	}
+
+	fire(t, server, sessions, 10)
+	assert.Equal(t, int32(20), game.handled.Load())
+	assert.Eventually(t, func() bool { return game.ticks.Load() > 0 }, time.Second, 5*time.Millisecond)
+	assert.False(t, game.overlapped.Load(), "service callbacks must run sequentially")
+	// 服務 Loop 優先，房間不另外建立 Loop
+	assert.Zero(t, game.roomTicks.Load())
+
+	// Close 後 Loop 停止，訊息回傳錯誤
+	server.Close()
+	resp, err := server.OnMessage(context.Background(), &gameRPC.MsgReq{
+		Header:  &proto.PacketHeader{SessionId: "s1"},
+		Payload: []byte("fire"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, proto.ErrorCode_SERVER_ERROR, resp.Code)
+	assert.Contains(t, resp.ErrorMessage, engine.ErrLoopStopped.Error())
+}
@@ -2,11 +2,15 @@ package engine
 
 import (
 	"context"
-	"log/slog"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 	connector_sdk "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/connector" // Client
 	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
 )
@@ -18,8 +22,27 @@ type Peer struct {
 	SessionID     string       // 網路層 Session ID (Connector 識別用)
 	ConnectorHost string       // 來源 Connector
 	rpcPool       *grpcpkg.Pool
+	wallet        ports.WalletService
+	rounds        *RoundManager
+	channels      *channelRegistry // Connector 雙向串流 (有串流時優先使用，否則走 Unary ConnectorRPC)
+	roomMgr       *RoomManager
+	balanceMu     sync.RWMutex // 保護 User.Balance (Debit/Credit 會更新餘額快照)
 }
 
+// ErrWalletUnavailable Peer 未綁定錢包服務 (e.g. 單元測試或未注入 WalletService)
+var ErrWalletUnavailable = errors.New("engine: wallet service not available")
+
+// Peer.Send 的投遞結果錯誤，Game 邏輯可用 errors.Is 判斷
+var (
+	// ErrSessionNotFound 玩家已不在 Connector 上 (斷線或已離開)
+	ErrSessionNotFound = connector_sdk.ErrSessionNotFound
+	// ErrSendQueueFull 玩家連線的發送佇列已滿 (慢速消費者)，訊息未送出
+	// 僅在 Connector 的 overflow_policy 為 kick 時回傳；預設的 drop_oldest 會丟棄佇列中最舊的訊息，Send 仍回傳 nil
+	ErrSendQueueFull = connector_sdk.ErrSendQueueFull
+	// ErrDeliveryFailed Connector 編碼或寫入失敗 (或未回報投遞結果)，訊息未送出
+	ErrDeliveryFailed = connector_sdk.ErrDeliveryFailed
+)
+
 // NewPeer 建立新的 Peer
 func NewPeer(user *domain.User, sessionID, connectorHost string, pool *grpcpkg.Pool) *Peer {
 	return &Peer{
@@ -31,27 +54,107 @@ func NewPeer(user *domain.User, sessionID, connectorHost string, pool *grpcpkg.P
 }
 
 // Send 發送訊息給玩家 (透過 Connector)
+// 玩家已斷線回傳 ErrSessionNotFound，發送佇列已滿回傳 ErrSendQueueFull，其他投遞失敗回傳 ErrDeliveryFailed
+// 未設定 Connector 連線 (Pool 與串流皆無) 時不發送，回傳 nil
 func (p *Peer) Send(ctx context.Context, payload []byte) error {
-	if p.rpcPool == nil {
+	if p.rpcPool == nil && p.channels == nil {
 		return nil
 	}
+	// 如果傳入的 ctx 是 Background，建議給個 Timeout
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultSendTimeout)
+		defer cancel()
+	}
 
-	conn, err := p.rpcPool.GetConnection(p.ConnectorHost)
+	statuses, err := pushToConnector(ctx, p.rpcPool, p.channels, p.ConnectorHost, []string{p.SessionID}, payload)
 	if err != nil {
+		if errors.Is(err, errNoConnector) {
+			return nil
+		}
 		return err
 	}
+	return connector_sdk.StatusError(statuses[p.SessionID])
+}
 
-	// 使用 SDK 封裝
-	client := connector_sdk.NewClient(conn)
+// Balance 取得玩家餘額快照 (Thread-Safe)
+// 進入遊戲時由 WalletService 取得，之後隨 Debit/Credit 更新
+func (p *Peer) Balance() decimal.Decimal {
+	p.balanceMu.RLock()
+	defer p.balanceMu.RUnlock()
+	return p.User.Balance
+}
 
-	// 如果傳入的 ctx 是 Background，建議給個 Timeout
-	if _, ok := ctx.Deadline(); !ok {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, 3*time.Second)
-		defer cancel()
+// Debit 從玩家錢包扣款 (下注)
+// txID 為冪等鍵，重試時需使用相同的 txID；餘額不足回傳 ports.ErrInsufficientFunds
+func (p *Peer) Debit(ctx context.Context, amount decimal.Decimal, txID, reason string) (*domain.Transaction, error) {
+	if p.wallet == nil {
+		return nil, ErrWalletUnavailable
+	}
+	tx, err := p.wallet.Withdraw(ctx, p.User.ID, amount, txID, reason)
+	if err != nil {
+		return nil, err
+	}
+	p.setBalance(tx.BalanceAfter)
+	return tx, nil
+}
+
+// Credit 存入玩家錢包 (派彩、退款)
+// txID 為冪等鍵，重試時需使用相同的 txID
+func (p *Peer) Credit(ctx context.Context, amount decimal.Decimal, txID, reason string) (*domain.Transaction, error) {
+	if p.wallet == nil {
+		return nil, ErrWalletUnavailable
+	}
+	tx, err := p.wallet.Deposit(ctx, p.User.ID, amount, txID, reason)
+	if err != nil {
+		return nil, err
+	}
+	p.setBalance(tx.BalanceAfter)
+	return tx, nil
+}
+
+// BeginRound 開始一個下注回合並扣除下注金額 (roundID 為空時自動產生)
+// 回合需以 Round.Settle 或 Round.Refund 結束，玩家離開時未結束的回合會自動退款
+func (p *Peer) BeginRound(ctx context.Context, roundID string, bet decimal.Decimal) (*Round, error) {
+	if p.rounds == nil {
+		return nil, ErrWalletUnavailable
+	}
+	return p.rounds.Begin(ctx, p, roundID, bet)
+}
+
+// RunRound 執行一個完整回合: 下注後呼叫 fn 計算派彩，fn 成功則結算，失敗則自動退款
+func (p *Peer) RunRound(ctx context.Context, roundID string, bet decimal.Decimal, fn func(r *Round) (decimal.Decimal, error)) (*Round, error) {
+	if p.rounds == nil {
+		return nil, ErrWalletUnavailable
+	}
+	return p.rounds.Run(ctx, p, roundID, bet, fn)
+}
+
+// OpenRounds 取得此 Peer 尚未結束的回合
+func (p *Peer) OpenRounds() []*Round {
+	if p.rounds == nil {
+		return nil
+	}
+	return p.rounds.Open(p.SessionID)
+}
+
+// Rooms 取得 Server 的 RoomManager (用於建立/加入房間)
+func (p *Peer) Rooms() *RoomManager {
+	return p.roomMgr
+}
+
+// Room 取得玩家目前所在的房間 (不在房間時回傳 nil)
+func (p *Peer) Room() *Room {
+	if p.roomMgr == nil {
+		return nil
 	}
+	return p.roomMgr.RoomOf(p.SessionID)
+}
 
-	return client.Push(ctx, p.SessionID, payload)
+func (p *Peer) setBalance(balance decimal.Decimal) {
+	p.balanceMu.Lock()
+	p.User.Balance = balance
+	p.balanceMu.Unlock()
 }
 
 // Kick 踢除玩家
@@ -78,6 +181,7 @@ func (p *Peer) Kick(ctx context.Context, reason string) error {
 // PeerManager 管理 Stateful 服務的 Peers
 type PeerManager struct {
 	peers sync.Map // map[string]*Peer (key: SessionID !! 注意是用 SessionID 當 Key, 若要用UserID需考慮多開)
+	count int64    // 在線 Peer 數量
 }
 
 func NewPeerManager() *PeerManager {
@@ -85,11 +189,22 @@ func NewPeerManager() *PeerManager {
 }
 
 func (m *PeerManager) Add(p *Peer) {
-	m.peers.Store(p.SessionID, p)
+	if _, loaded := m.peers.LoadOrStore(p.SessionID, p); loaded {
+		m.peers.Store(p.SessionID, p)
+		return
+	}
+	atomic.AddInt64(&m.count, 1)
 }
 
 func (m *PeerManager) Remove(sessionID string) {
-	m.peers.Delete(sessionID)
+	if _, loaded := m.peers.LoadAndDelete(sessionID); loaded {
+		atomic.AddInt64(&m.count, -1)
+	}
+}
+
+// Count 取得當前 Peer 數量
+func (m *PeerManager) Count() int64 {
+	return atomic.LoadInt64(&m.count)
 }
 
 func (m *PeerManager) Get(sessionID string) *Peer {
@@ -99,15 +214,18 @@ func (m *PeerManager) Get(sessionID string) *Peer {
 	return nil
 }
 
-// Broadcast 廣播給該 Pod 上所有玩家
-func (m *PeerManager) Broadcast(ctx context.Context, payload []byte) {
+// Peers 取得所有 Peer 的快照
+func (m *PeerManager) Peers() []*Peer {
+	var peers []*Peer
 	m.peers.Range(func(_ any, value any) bool {
-		p := value.(*Peer)
-		go func(peer *Peer) {
-			if err := peer.Send(ctx, payload); err != nil {
-				slog.Warn("Broadcast failed", "session_id", peer.SessionID, "error", err)
-			}
-		}(p)
+		peers = append(peers, value.(*Peer))
 		return true
 	})
+	return peers
+}
+
+// Broadcast 廣播給該 Pod 上所有玩家 (依 Connector 分組批次推播，等待全部完成)
+// 部分玩家未送達時回傳 *BroadcastError
+func (m *PeerManager) Broadcast(ctx context.Context, payload []byte) error {
+	return Broadcast(ctx, m.Peers(), payload)
 }
@@ -0,0 +1,471 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRoomNotFound 房間不存在 (或已關閉)
+	ErrRoomNotFound = errors.New("engine: room not found")
+	// ErrRoomExists 相同 Room ID 的房間已存在
+	ErrRoomExists = errors.New("engine: room already exists")
+	// ErrRoomFull 房間已達人數上限
+	ErrRoomFull = errors.New("engine: room is full")
+	// ErrAlreadyInRoom 玩家已在其他房間 (需先 Leave)
+	ErrAlreadyInRoom = errors.New("engine: peer already in a room")
+	// ErrNotInRoom 玩家不在任何房間
+	ErrNotInRoom = errors.New("engine: peer not in a room")
+)
+
+// RoomHandler 可選介面: GameHandler 若實作此介面，會收到房間生命週期事件
+// 玩家在房間內時，訊息會改由 OnRoomMessage 處理 (取代 GameHandler.OnMessage)
+// 所有 Hook 皆在未持有 RoomManager 鎖的情況下呼叫，可在其中操作 Room / RoomManager
+type RoomHandler interface {
+	// OnRoomCreate 房間建立後呼叫，回傳錯誤則取消建立
+	OnRoomCreate(ctx context.Context, room *Room) error
+	// OnRoomJoin 玩家加入房間後呼叫，回傳錯誤則拒絕加入
+	OnRoomJoin(ctx context.Context, room *Room, peer *Peer) error
+	// OnRoomLeave 玩家離開房間 (主動離開、斷線或房間關閉) 後呼叫
+	OnRoomLeave(ctx context.Context, room *Room, peer *Peer)
+	// OnRoomMessage 處理房間內玩家的訊息
+	OnRoomMessage(ctx context.Context, room *Room, peer *Peer, payload []byte) ([]byte, error)
+	// OnRoomClose 房間關閉後呼叫 (此時房間已無玩家)
+	OnRoomClose(ctx context.Context, room *Room)
+}
+
+// BaseRoomHandler 提供 RoomHandler 的預設空實作 (Optional)
+type BaseRoomHandler struct{}
+
+func (_ *BaseRoomHandler) OnRoomCreate(_ context.Context, _ *Room) error        { return nil }
+func (_ *BaseRoomHandler) OnRoomJoin(_ context.Context, _ *Room, _ *Peer) error { return nil }
+func (_ *BaseRoomHandler) OnRoomLeave(_ context.Context, _ *Room, _ *Peer)      {}
+func (_ *BaseRoomHandler) OnRoomMessage(_ context.Context, _ *Room, _ *Peer, _ []byte) ([]byte, error) {
+	return nil, nil
+}
+func (_ *BaseRoomHandler) OnRoomClose(_ context.Context, _ *Room) {}
+
+// RoomOption 定義了建立房間時的配置選項函數
+type RoomOption func(*Room)
+
+// WithCapacity 設定房間人數上限 (0 代表不限)
+func WithCapacity(capacity int) RoomOption {
+	return func(r *Room) {
+		r.capacity = capacity
+	}
+}
+
+// WithPersistent 房間在最後一位玩家離開後不自動關閉 (需呼叫 RoomManager.Close)
+func WithPersistent() RoomOption {
+	return func(r *Room) {
+		r.persistent = true
+	}
+}
+
+// Room 代表一個房間 (桌)，維護房內的 Peer 集合
+// 僅適用 Stateful 服務 (Stateless 的 Peer 不會跨請求保留)
+type Room struct {
+	ID string
+
+	capacity   int
+	persistent bool
+
+	mu    sync.RWMutex
+	peers map[string]*Peer // key: SessionID
+
+	loop *loop // 房間 Tick Loop (僅 WithRoomTick 時存在)
+}
+
+// Capacity 房間人數上限 (0 代表不限)
+func (r *Room) Capacity() int {
+	return r.capacity
+}
+
+// Count 取得房內玩家數
+func (r *Room) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.peers)
+}
+
+// Full 房間是否已滿
+func (r *Room) Full() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.full()
+}
+
+// Get 依 SessionID 取得房內的 Peer
+func (r *Room) Get(sessionID string) *Peer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.peers[sessionID]
+}
+
+// Peers 取得房內所有 Peer 的快照
+func (r *Room) Peers() []*Peer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	peers := make([]*Peer, 0, len(r.peers))
+	for _, p := range r.peers {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+// Broadcast 廣播給房內所有玩家 (可排除指定的 SessionID，例如訊息發送者)
+// 依 Connector 分組批次推播並等待完成，部分玩家未送達時回傳 *BroadcastError
+func (r *Room) Broadcast(ctx context.Context, payload []byte, excludeSessionIDs ...string) error {
+	peers := r.Peers()
+	if len(excludeSessionIDs) > 0 {
+		peers = slices.DeleteFunc(peers, func(p *Peer) bool { return slices.Contains(excludeSessionIDs, p.SessionID) })
+	}
+	return Broadcast(ctx, peers, payload)
+}
+
+func (r *Room) full() bool {
+	return r.capacity > 0 && len(r.peers) >= r.capacity
+}
+
+// RoomManager 管理 Stateful 服務的房間，並記錄每個玩家所在的房間
+// 一個玩家同時只能在一個房間；玩家離開遊戲 (OnPlayerQuit) 時由 Server 自動離開房間
+type RoomManager struct {
+	handler         RoomHandler // 可為 nil
+	defaultCapacity int
+	service         string // 所屬服務名稱 (指標標籤，由 Server 設定)
+
+	// Tick Loop (由 Server 依選項設定)
+	roomTick    time.Duration // > 0 時每個房間擁有自己的 Loop
+	serviceLoop *loop         // 服務層級 Loop，所有房間共用
+
+	mu        sync.Mutex
+	rooms     map[string]*Room
+	bySession map[string]*Room // SessionID -> 所在房間
+}
+
+// NewRoomManager 建立 RoomManager
+// handler 為 nil 時不觸發生命週期事件；defaultCapacity 為未指定 WithCapacity 時的人數上限 (0 代表不限)
+func NewRoomManager(handler RoomHandler, defaultCapacity int) *RoomManager {
+	return &RoomManager{
+		handler:         handler,
+		defaultCapacity: defaultCapacity,
+		rooms:           make(map[string]*Room),
+		bySession:       make(map[string]*Room),
+	}
+}
+
+// Create 建立房間 (roomID 為空時自動產生)
+func (m *RoomManager) Create(ctx context.Context, roomID string, opts ...RoomOption) (*Room, error) {
+	if roomID == "" {
+		roomID = uuid.NewString()
+	}
+	room := &Room{
+		ID:       roomID,
+		capacity: m.defaultCapacity,
+		peers:    make(map[string]*Peer),
+	}
+	for _, opt := range opts {
+		opt(room)
+	}
+
+	m.mu.Lock()
+	if _, exists := m.rooms[roomID]; exists {
+		m.mu.Unlock()
+		return nil, ErrRoomExists
+	}
+	m.rooms[roomID] = room
+	m.mu.Unlock()
+	m.startLoop(room)
+
+	if m.handler != nil {
+		var err error
+		if callErr := m.invoke(ctx, room, "OnRoomCreate", func(ctx context.Context) { err = m.handler.OnRoomCreate(ctx, room) }); callErr != nil {
+			err = callErr
+		}
+		if err != nil {
+			m.mu.Lock()
+			if m.rooms[roomID] == room {
+				delete(m.rooms, roomID)
+			}
+			m.mu.Unlock()
+			m.stopLoop(room)
+			return nil, err
+		}
+	}
+	return room, nil
+}
+
+// Get 取得房間 (不存在時回傳 nil)
+func (m *RoomManager) Get(roomID string) *Room {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rooms[roomID]
+}
+
+// RoomOf 取得玩家所在的房間 (不在房間時回傳 nil)
+func (m *RoomManager) RoomOf(sessionID string) *Room {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.bySession[sessionID]
+}
+
+// Rooms 取得所有房間的快照
+func (m *RoomManager) Rooms() []*Room {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rooms := make([]*Room, 0, len(m.rooms))
+	for _, r := range m.rooms {
+		rooms = append(rooms, r)
+	}
+	return rooms
+}
+
+// Count 取得房間數量
+func (m *RoomManager) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.rooms)
+}
+
+// Join 讓玩家加入指定房間
+func (m *RoomManager) Join(ctx context.Context, roomID string, peer *Peer) (*Room, error) {
+	m.mu.Lock()
+	room, ok := m.rooms[roomID]
+	if !ok {
+		m.mu.Unlock()
+		return nil, ErrRoomNotFound
+	}
+	if err := m.admit(room, peer); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	m.mu.Unlock()
+
+	return room, m.afterJoin(ctx, room, peer)
+}
+
+// JoinOrCreate 讓玩家加入任一未滿的房間，沒有可用房間時建立新房間 (簡易配桌)
+func (m *RoomManager) JoinOrCreate(ctx context.Context, peer *Peer, opts ...RoomOption) (*Room, error) {
+	m.mu.Lock()
+	for _, room := range m.rooms {
+		if err := m.admit(room, peer); err == nil {
+			m.mu.Unlock()
+			return room, m.afterJoin(ctx, room, peer)
+		} else if errors.Is(err, ErrAlreadyInRoom) {
+			m.mu.Unlock()
+			return nil, err
+		}
+	}
+	m.mu.Unlock()
+
+	room, err := m.Create(ctx, "", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.Join(ctx, room.ID, peer); err != nil {
+		// 為此玩家建立的房間，加入失敗時一併關閉
+		m.closeIfEmpty(ctx, room)
+		return nil, err
+	}
+	return room, nil
+}
+
+// Leave 讓玩家離開所在房間，非 Persistent 房間在最後一位玩家離開後自動關閉
+func (m *RoomManager) Leave(ctx context.Context, peer *Peer) error {
+	return m.leave(ctx, peer, false)
+}
+
+// leave 將玩家移出房間
+// rollback 為 true 代表加入被拒絕的回滾: 不觸發 OnRoomLeave，也不自動關閉房間 (房間維持加入前的狀態)
+func (m *RoomManager) leave(ctx context.Context, peer *Peer, rollback bool) error {
+	m.mu.Lock()
+	room, ok := m.bySession[peer.SessionID]
+	if !ok {
+		m.mu.Unlock()
+		return ErrNotInRoom
+	}
+	delete(m.bySession, peer.SessionID)
+	room.mu.Lock()
+	delete(room.peers, peer.SessionID)
+	empty := len(room.peers) == 0
+	room.mu.Unlock()
+
+	closing := empty && !rollback && !room.persistent && m.rooms[room.ID] == room
+	if closing {
+		delete(m.rooms, room.ID)
+	}
+	m.mu.Unlock()
+
+	if m.handler != nil {
+		m.notify(ctx, room, "OnRoomLeave", func(ctx context.Context) {
+			if !rollback {
+				m.handler.OnRoomLeave(ctx, room, peer)
+			}
+			if closing {
+				m.handler.OnRoomClose(ctx, room)
+			}
+		})
+	}
+	if closing {
+		m.stopLoop(room)
+	}
+	return nil
+}
+
+// Close 關閉房間，房內所有玩家會先離開房間 (觸發 OnRoomLeave)
+func (m *RoomManager) Close(ctx context.Context, roomID string) error {
+	m.mu.Lock()
+	room, ok := m.rooms[roomID]
+	if !ok {
+		m.mu.Unlock()
+		return ErrRoomNotFound
+	}
+	delete(m.rooms, roomID)
+
+	room.mu.Lock()
+	peers := make([]*Peer, 0, len(room.peers))
+	for sessionID, p := range room.peers {
+		peers = append(peers, p)
+		delete(m.bySession, sessionID)
+	}
+	room.peers = make(map[string]*Peer)
+	room.mu.Unlock()
+	m.mu.Unlock()
+
+	if m.handler != nil {
+		m.notify(ctx, room, "OnRoomClose", func(ctx context.Context) {
+			for _, p := range peers {
+				m.handler.OnRoomLeave(ctx, room, p)
+			}
+			m.handler.OnRoomClose(ctx, room)
+		})
+	}
+	m.stopLoop(room)
+	return nil
+}
+
+// closeIfEmpty 房間沒有玩家時關閉
+func (m *RoomManager) closeIfEmpty(ctx context.Context, room *Room) {
+	m.mu.Lock()
+	if m.rooms[room.ID] != room || room.Count() > 0 {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.rooms, room.ID)
+	m.mu.Unlock()
+
+	if m.handler != nil {
+		m.notify(ctx, room, "OnRoomClose", func(ctx context.Context) { m.handler.OnRoomClose(ctx, room) })
+	}
+	m.stopLoop(room)
+}
+
+// Rebind 斷線重連時將房內的舊 Peer 替換為新的 Peer
+func (m *RoomManager) Rebind(oldSessionID string, peer *Peer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	room, ok := m.bySession[oldSessionID]
+	if !ok {
+		return
+	}
+	delete(m.bySession, oldSessionID)
+	m.bySession[peer.SessionID] = room
+
+	room.mu.Lock()
+	delete(room.peers, oldSessionID)
+	room.peers[peer.SessionID] = peer
+	room.mu.Unlock()
+}
+
+// admit 在持有 m.mu 的情況下將玩家放入房間
+func (m *RoomManager) admit(room *Room, peer *Peer) error {
+	if _, ok := m.bySession[peer.SessionID]; ok {
+		return ErrAlreadyInRoom
+	}
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	if room.full() {
+		return ErrRoomFull
+	}
+	room.peers[peer.SessionID] = peer
+	m.bySession[peer.SessionID] = room
+	return nil
+}
+
+// afterJoin 觸發 OnRoomJoin，被拒絕時將玩家移出房間
+func (m *RoomManager) afterJoin(ctx context.Context, room *Room, peer *Peer) error {
+	if m.handler == nil {
+		return nil
+	}
+	var err error
+	if callErr := m.invoke(ctx, room, "OnRoomJoin", func(ctx context.Context) { err = m.handler.OnRoomJoin(ctx, room, peer) }); callErr != nil {
+		err = callErr
+	}
+	if err != nil {
+		_ = m.leave(ctx, peer, true)
+		return err
+	}
+	return nil
+}
+
+// Message 將玩家訊息交給 OnRoomMessage (在房間所屬的 Loop 上執行)
+func (m *RoomManager) Message(ctx context.Context, room *Room, peer *Peer, payload []byte) ([]byte, error) {
+	var resp []byte
+	var err error
+	if callErr := m.invoke(ctx, room, "OnRoomMessage", func(ctx context.Context) { resp, err = m.handler.OnRoomMessage(ctx, room, peer, payload) }); callErr != nil {
+		return nil, callErr
+	}
+	return resp, err
+}
+
+// invoke 在房間所屬的 Loop 上執行 fn (服務 Loop > 房間 Loop > 直接執行)
+func (m *RoomManager) invoke(ctx context.Context, room *Room, hook string, fn func(ctx context.Context)) error {
+	fn = timeHook(m.service, hook, fn)
+	switch {
+	case m.serviceLoop != nil:
+		return m.serviceLoop.call(ctx, fn)
+	case room.loop != nil:
+		return room.loop.call(ctx, fn)
+	default:
+		fn(ctx)
+		return nil
+	}
+}
+
+// notify 以 invoke 執行沒有回傳值的 Hook，無法執行時記錄錯誤
+func (m *RoomManager) notify(ctx context.Context, room *Room, hook string, fn func(ctx context.Context)) {
+	if err := m.invoke(ctx, room, hook, fn); err != nil {
+		slog.Warn("Room hook skipped", "room_id", room.ID, "hook", hook, "error", err)
+	}
+}
+
+// startLoop 為房間建立 Tick Loop (啟用 WithRoomTick 且未使用服務 Loop 時)
+func (m *RoomManager) startLoop(room *Room) {
+	if m.roomTick <= 0 || m.serviceLoop != nil {
+		return
+	}
+	var onTick func(ctx context.Context, dt time.Duration)
+	if th, ok := m.handler.(RoomTickHandler); ok {
+		onTick = func(ctx context.Context, dt time.Duration) { th.OnRoomTick(ctx, room, dt) }
+	}
+	room.loop = newLoop(m.roomTick, onTick)
+}
+
+func (_ *RoomManager) stopLoop(room *Room) {
+	if room.loop != nil {
+		room.loop.stop()
+	}
+}
+
+// stopAll 停止所有房間的 Loop (服務關機)
+func (m *RoomManager) stopAll() {
+	for _, room := range m.Rooms() {
+		m.stopLoop(room)
+	}
+}
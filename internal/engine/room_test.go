@@ -0,0 +1,173 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
+)
+
+// roomGame 實作 GameHandler + RoomHandler，記錄房間事件
+type roomGame struct {
+	*mock_engine.MockGameHandler
+	engine.BaseRoomHandler
+
+	mu     sync.Mutex
+	events []string
+	reject string // 拒絕此 SessionID 加入
+}
+
+func (g *roomGame) record(event string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.events = append(g.events, event)
+}
+
+func (g *roomGame) Events() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.events...)
+}
+
+func (g *roomGame) OnRoomCreate(_ context.Context, room *engine.Room) error {
+	g.record("create:" + room.ID)
+	return nil
+}
+
+func (g *roomGame) OnRoomJoin(_ context.Context, room *engine.Room, peer *engine.Peer) error {
+	if peer.SessionID == g.reject {
+		return errors.New("rejected")
+	}
+	g.record("join:" + room.ID + ":" + peer.SessionID)
+	return nil
+}
+
+func (g *roomGame) OnRoomLeave(_ context.Context, room *engine.Room, peer *engine.Peer) {
+	g.record("leave:" + room.ID + ":" + peer.SessionID)
+}
+
+func (g *roomGame) OnRoomMessage(_ context.Context, room *engine.Room, _ *engine.Peer, payload []byte) ([]byte, error) {
+	return []byte(room.ID + ":" + string(payload)), nil
+}
+
+func (g *roomGame) OnRoomClose(_ context.Context, room *engine.Room) {
+	g.record("close:" + room.ID)
+}
+
+// newRoomServer 建立 Stateful Server，OnJoin 時將玩家加入 table-1
+func newRoomServer(t *testing.T, capacity int) (*engine.Server, *roomGame) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*domain.User, error) {
+		return &domain.User{ID: id}, nil
+	}).AnyTimes()
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), gomock.Any()).Return(decimal.Zero, nil).AnyTimes()
+
+	game := &roomGame{MockGameHandler: mock_engine.NewMockGameHandler(ctrl)}
+	game.EXPECT().OnJoin(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, peer *engine.Peer) error {
+		_, err := peer.Rooms().Join(ctx, "table-1", peer)
+		return err
+	}).AnyTimes()
+	game.EXPECT().OnQuit(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	server := engine.NewServer(game, nil, true, "fishing", mockUserSvc, mockWalletSvc, engine.WithRoomCapacity(capacity))
+	_, err := server.Rooms().Create(context.Background(), "table-1")
+	require.NoError(t, err)
+	return server, game
+}
+
+func join(server *engine.Server, sessionID string) error {
+	_, err := server.OnPlayerJoin(context.Background(), &gameRPC.JoinReq{
+		Header: &proto.PacketHeader{UserId: "user-" + sessionID, SessionId: sessionID},
+	})
+	return err
+}
+
+func TestRoomManager_JoinCapacityAndLeave(t *testing.T) {
+	server, game := newRoomServer(t, 2)
+	ctx := context.Background()
+
+	require.NoError(t, join(server, "s1"))
+	require.NoError(t, join(server, "s2"))
+	assert.ErrorIs(t, join(server, "s3"), engine.ErrRoomFull)
+
+	room := server.Rooms().Get("table-1")
+	require.NotNil(t, room)
+	assert.Equal(t, 2, room.Count())
+	assert.True(t, room.Full())
+
+	// 已在房間內不可再加入其他房間
+	p1 := server.PeerManager().Get("s1")
+	_, err := server.Rooms().Create(ctx, "table-2")
+	require.NoError(t, err)
+	_, err = server.Rooms().Join(ctx, "table-2", p1)
+	assert.ErrorIs(t, err, engine.ErrAlreadyInRoom)
+	assert.Same(t, room, p1.Room())
+
+	// 玩家離開遊戲時自動離開房間，最後一人離開後房間自動關閉
+	for _, sess := range []string{"s1", "s2"} {
+		_, err := server.OnPlayerQuit(ctx, &gameRPC.QuitReq{Header: &proto.PacketHeader{SessionId: sess}})
+		require.NoError(t, err)
+	}
+	assert.Nil(t, server.Rooms().Get("table-1"))
+	assert.NotNil(t, server.Rooms().Get("table-2"))
+
+	assert.Equal(t, []string{
+		"create:table-1",
+		"join:table-1:s1",
+		"join:table-1:s2",
+		"create:table-2",
+		"leave:table-1:s1",
+		"leave:table-1:s2",
+		"close:table-1",
+	}, game.Events())
+}
+
+func TestRoomManager_JoinRejected(t *testing.T) {
+	server, game := newRoomServer(t, 0)
+	game.reject = "s1"
+
+	assert.Error(t, join(server, "s1"))
+	// 被拒絕的玩家不會留在房間內，也不會觸發 OnRoomLeave
+	room := server.Rooms().Get("table-1")
+	require.NotNil(t, room)
+	assert.Equal(t, 0, room.Count())
+	assert.Equal(t, []string{"create:table-1"}, game.Events())
+}
+
+func TestServer_OnMessage_RoutesToRoom(t *testing.T) {
+	server, game := newRoomServer(t, 0)
+	ctx := context.Background()
+	require.NoError(t, join(server, "s1"))
+
+	resp, err := server.OnMessage(ctx, &gameRPC.MsgReq{
+		Header:  &proto.PacketHeader{SessionId: "s1"},
+		Payload: []byte("fire"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "table-1:fire", string(resp.Payload))
+
+	// 離開房間後回到 GameHandler.OnMessage
+	require.NoError(t, server.Rooms().Leave(ctx, server.PeerManager().Get("s1")))
+	game.EXPECT().OnMessage(gomock.Any(), gomock.Any(), []byte("fire")).Return([]byte("lobby"), nil)
+	resp, err = server.OnMessage(ctx, &gameRPC.MsgReq{
+		Header:  &proto.PacketHeader{SessionId: "s1"},
+		Payload: []byte("fire"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "lobby", string(resp.Payload))
+}
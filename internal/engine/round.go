@@ -0,0 +1,308 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RoundStatus 下注回合狀態
+type RoundStatus int
+
+const (
+	RoundOpen     RoundStatus = iota // 已下注，等待結算
+	RoundSettled                     // 已結算 (派彩完成)
+	RoundRefunded                    // 已退款
+)
+
+func (s RoundStatus) String() string {
+	switch s {
+	case RoundOpen:
+		return "open"
+	case RoundSettled:
+		return "settled"
+	case RoundRefunded:
+		return "refunded"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	// ErrRoundClosed 回合已結算或已退款，無法再變更
+	ErrRoundClosed = errors.New("engine: round already closed")
+	// ErrRoundConflict 相同 Round ID 已用於不同的下注金額
+	ErrRoundConflict = errors.New("engine: round id conflict")
+)
+
+// closedRoundRetention 已結束回合 ID 的保留時間
+// 期間內以相同 ID 重試 Begin 會回傳 ErrRoundClosed，避免重新開啟回合後再次退款
+const closedRoundRetention = time.Hour
+
+// Round 代表一次下注回合 (Bet -> Settle / Refund)
+//
+// 每個步驟以 Round ID 衍生出固定的錢包交易 ID (冪等鍵):
+//   - round:{id}:bet    扣除下注金額
+//   - round:{id}:settle 派彩 (win > 0 時)
+//   - round:{id}:refund 退還下注金額
+//
+// 因此任何步驟在逾時或錯誤後都可以安全重試，不會重複扣款或派彩。
+type Round struct {
+	ID  string          // 回合 ID
+	Bet decimal.Decimal // 下注金額
+
+	mu      sync.Mutex // 序列化 Settle / Refund
+	status  RoundStatus
+	win     decimal.Decimal
+	peer    atomic.Pointer[Peer] // 斷線重連時會被替換為新的 Peer
+	manager *RoundManager
+
+	sessionID string // 所屬 Session (受 RoundManager.mu 保護)
+}
+
+// Status 取得回合狀態
+func (r *Round) Status() RoundStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Win 取得派彩金額 (僅在已結算時有意義)
+func (r *Round) Win() decimal.Decimal {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.win
+}
+
+// Settle 結算回合並派彩 (win 為 0 代表未中獎)
+// 重複結算相同金額視為成功 (冪等)；派彩失敗時回合維持 Open，可重試
+func (r *Round) Settle(ctx context.Context, win decimal.Decimal) error {
+	if win.IsNegative() {
+		return fmt.Errorf("engine: negative win amount %s", win)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.status {
+	case RoundSettled:
+		if r.win.Equal(win) {
+			return nil
+		}
+		return ErrRoundClosed
+	case RoundRefunded:
+		return ErrRoundClosed
+	}
+
+	if win.IsPositive() {
+		if _, err := r.peer.Load().Credit(ctx, win, r.txID("settle"), r.manager.reason("win", r.ID)); err != nil {
+			return err
+		}
+	}
+
+	r.status = RoundSettled
+	r.win = win
+	r.manager.remove(r)
+	return nil
+}
+
+// Refund 退還下注金額並關閉回合
+// 重複退款視為成功 (冪等)；退款失敗時回合維持 Open，可重試
+func (r *Round) Refund(ctx context.Context, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.status {
+	case RoundRefunded:
+		return nil
+	case RoundSettled:
+		return ErrRoundClosed
+	}
+
+	if _, err := r.peer.Load().Credit(ctx, r.Bet, r.txID("refund"), r.manager.reason("refund", r.ID)+" ("+reason+")"); err != nil {
+		return err
+	}
+
+	r.status = RoundRefunded
+	r.manager.remove(r)
+	return nil
+}
+
+func (r *Round) txID(step string) string {
+	return fmt.Sprintf("round:%s:%s", r.ID, step)
+}
+
+// RoundManager 追蹤每個 Session 尚未結束的下注回合
+// 玩家離開 (OnPlayerQuit) 時由 Server 自動退還所有未結算的回合。
+//
+// 注意: Stateless 服務的 Quit 可能由其他實例處理，回合應在單次 OnMessage 內完成。
+type RoundManager struct {
+	serviceName string
+	mu          sync.Mutex
+	open        map[string]map[string]*Round // sessionID -> roundID -> Round
+	closed      map[string]struct{}          // 已結算或已退款的 roundID (保留 closedRoundRetention)
+	closedOrder []closedRound                // 依結束時間排序，用於清除過期的 closed
+}
+
+type closedRound struct {
+	id       string
+	closedAt time.Time
+}
+
+// NewRoundManager 建立 RoundManager
+func NewRoundManager(serviceName string) *RoundManager {
+	return &RoundManager{
+		serviceName: serviceName,
+		open:        make(map[string]map[string]*Round),
+		closed:      make(map[string]struct{}),
+	}
+}
+
+// Begin 開始一個回合並扣除下注金額
+// roundID 為空時自動產生；相同 roundID 的回合仍在進行中時回傳該回合 (冪等)
+// 回合已結算或已退款時回傳 ErrRoundClosed (不會重新扣款或開啟新回合)
+func (m *RoundManager) Begin(ctx context.Context, peer *Peer, roundID string, bet decimal.Decimal) (*Round, error) {
+	if roundID == "" {
+		roundID = uuid.NewString()
+	}
+
+	m.mu.Lock()
+	if _, ok := m.closed[roundID]; ok {
+		m.mu.Unlock()
+		return nil, ErrRoundClosed
+	}
+	if existing, ok := m.open[peer.SessionID][roundID]; ok {
+		m.mu.Unlock()
+		if !existing.Bet.Equal(bet) {
+			return nil, ErrRoundConflict
+		}
+		return existing, nil
+	}
+	m.mu.Unlock()
+
+	round := &Round{
+		ID:        roundID,
+		Bet:       bet,
+		status:    RoundOpen,
+		manager:   m,
+		sessionID: peer.SessionID,
+	}
+	round.peer.Store(peer)
+	if _, err := peer.Debit(ctx, bet, round.txID("bet"), m.reason("bet", roundID)); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.open[peer.SessionID][roundID]; ok {
+		// 並發 Begin 相同回合: 錢包交易為冪等，只需保留先登記的回合
+		return existing, nil
+	}
+	if _, ok := m.closed[roundID]; ok {
+		// 並發 Begin 的另一方已完成並結束回合，本次扣款為冪等重放
+		return nil, ErrRoundClosed
+	}
+	if m.open[peer.SessionID] == nil {
+		m.open[peer.SessionID] = make(map[string]*Round)
+	}
+	m.open[peer.SessionID][roundID] = round
+	return round, nil
+}
+
+// Run 執行一個完整回合: 下注後呼叫 fn 計算派彩，fn 成功則結算，失敗則自動退款
+func (m *RoundManager) Run(ctx context.Context, peer *Peer, roundID string, bet decimal.Decimal, fn func(r *Round) (decimal.Decimal, error)) (*Round, error) {
+	round, err := m.Begin(ctx, peer, roundID, bet)
+	if err != nil {
+		return nil, err
+	}
+
+	win, err := fn(round)
+	if err != nil {
+		if refundErr := round.Refund(ctx, "error"); refundErr != nil {
+			slog.Error("Round refund failed", "round_id", round.ID, "user_id", peer.User.ID, "error", refundErr)
+			return round, errors.Join(err, refundErr)
+		}
+		return round, err
+	}
+
+	if err := round.Settle(ctx, win); err != nil {
+		return round, err
+	}
+	return round, nil
+}
+
+// Open 取得 Session 尚未結束的回合
+func (m *RoundManager) Open(sessionID string) []*Round {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rounds := make([]*Round, 0, len(m.open[sessionID]))
+	for _, r := range m.open[sessionID] {
+		rounds = append(rounds, r)
+	}
+	return rounds
+}
+
+// RefundAll 退還 Session 所有未結束的回合，回傳退款失敗的錯誤 (可重試)
+func (m *RoundManager) RefundAll(ctx context.Context, sessionID string, reason string) error {
+	var errs []error
+	for _, r := range m.Open(sessionID) {
+		if err := r.Refund(ctx, reason); err != nil && !errors.Is(err, ErrRoundClosed) {
+			slog.Error("Round refund failed", "round_id", r.ID, "session_id", sessionID, "error", err)
+			errs = append(errs, fmt.Errorf("round %s: %w", r.ID, err))
+			continue
+		}
+		slog.Info("Round refunded", "round_id", r.ID, "session_id", sessionID, "reason", reason)
+	}
+	return errors.Join(errs...)
+}
+
+// Rebind 將舊 Session 的回合轉移到新的 Peer (斷線重連)
+func (m *RoundManager) Rebind(oldSessionID string, peer *Peer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rounds, ok := m.open[oldSessionID]
+	if !ok {
+		return
+	}
+	delete(m.open, oldSessionID)
+	for _, r := range rounds {
+		r.sessionID = peer.SessionID
+		r.peer.Store(peer)
+	}
+	m.open[peer.SessionID] = rounds
+}
+
+// remove 從追蹤中移除已結束的回合，並記錄其 ID 避免被重新開啟
+func (m *RoundManager) remove(r *Round) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessionID := r.sessionID
+	delete(m.open[sessionID], r.ID)
+	if len(m.open[sessionID]) == 0 {
+		delete(m.open, sessionID)
+	}
+
+	now := time.Now()
+	m.closed[r.ID] = struct{}{}
+	m.closedOrder = append(m.closedOrder, closedRound{id: r.ID, closedAt: now})
+	expired := 0
+	for expired < len(m.closedOrder) && now.Sub(m.closedOrder[expired].closedAt) > closedRoundRetention {
+		delete(m.closed, m.closedOrder[expired].id)
+		expired++
+	}
+	m.closedOrder = m.closedOrder[expired:]
+}
+
+func (m *RoundManager) reason(step, roundID string) string {
+	return fmt.Sprintf("%s %s round=%s", m.serviceName, step, roundID)
+}
@@ -0,0 +1,146 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
+	wallet "github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/mock"
+	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
+	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
+)
+
+// joinPeer 建立 Stateful Server 並讓玩家加入，回傳 Handler 取得的 Peer
+func joinPeer(t *testing.T, handler *mock_engine.MockGameHandler, w *wallet.MockWallet) (*engine.Server, *engine.Peer) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), "user-1").Return(&domain.User{ID: "user-1"}, nil)
+
+	var joined *engine.Peer
+	handler.EXPECT().OnJoin(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, peer *engine.Peer) error {
+		joined = peer
+		return nil
+	})
+
+	server := engine.NewServer(handler, nil, true, "fishing", mockUserSvc, w)
+	_, err := server.OnPlayerJoin(context.Background(), &gameRPC.JoinReq{
+		Header: &proto.PacketHeader{UserId: "user-1", SessionId: "sess-1"},
+	})
+	require.NoError(t, err)
+	return server, joined
+}
+
+func TestRound_BetAndSettle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	w := wallet.NewMockWallet()
+	_, peer := joinPeer(t, mock_engine.NewMockGameHandler(ctrl), w)
+	ctx := context.Background()
+	initial := wallet.DefaultInitialBalance
+
+	round, err := peer.BeginRound(ctx, "r-1", decimal.NewFromInt(100))
+	require.NoError(t, err)
+	assert.Equal(t, engine.RoundOpen, round.Status())
+	assert.True(t, peer.Balance().Equal(initial.Sub(decimal.NewFromInt(100))))
+
+	// Begin again with the same ID is idempotent (no double debit)
+	again, err := peer.BeginRound(ctx, "r-1", decimal.NewFromInt(100))
+	require.NoError(t, err)
+	assert.Same(t, round, again)
+
+	_, err = peer.BeginRound(ctx, "r-1", decimal.NewFromInt(50))
+	assert.ErrorIs(t, err, engine.ErrRoundConflict)
+
+	require.NoError(t, round.Settle(ctx, decimal.NewFromInt(250)))
+	require.NoError(t, round.Settle(ctx, decimal.NewFromInt(250))) // idempotent
+	assert.ErrorIs(t, round.Refund(ctx, "late"), engine.ErrRoundClosed)
+
+	balance, _ := w.GetBalance(ctx, "user-1")
+	assert.True(t, balance.Equal(initial.Add(decimal.NewFromInt(150))))
+	assert.Empty(t, peer.OpenRounds())
+}
+
+func TestRound_RunRefundsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	w := wallet.NewMockWallet()
+	_, peer := joinPeer(t, mock_engine.NewMockGameHandler(ctrl), w)
+	ctx := context.Background()
+
+	gameErr := errors.New("rng failure")
+	round, err := peer.RunRound(ctx, "", decimal.NewFromInt(100), func(r *engine.Round) (decimal.Decimal, error) {
+		assert.NotEmpty(t, r.ID)
+		return decimal.Zero, gameErr
+	})
+	assert.ErrorIs(t, err, gameErr)
+	assert.Equal(t, engine.RoundRefunded, round.Status())
+
+	balance, _ := w.GetBalance(ctx, "user-1")
+	assert.True(t, balance.Equal(wallet.DefaultInitialBalance))
+}
+
+func TestServer_OnPlayerQuit_RefundsOpenRounds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := mock_engine.NewMockGameHandler(ctrl)
+	w := wallet.NewMockWallet()
+	server, peer := joinPeer(t, handler, w)
+	ctx := context.Background()
+
+	_, err := peer.BeginRound(ctx, "r-1", decimal.NewFromInt(100))
+	require.NoError(t, err)
+	_, err = peer.BeginRound(ctx, "r-2", decimal.NewFromInt(40))
+	require.NoError(t, err)
+	assert.Len(t, peer.OpenRounds(), 2)
+
+	handler.EXPECT().OnQuit(gomock.Any(), gomock.Any()).Return(nil)
+	_, err = server.OnPlayerQuit(ctx, &gameRPC.QuitReq{
+		Header: &proto.PacketHeader{UserId: "user-1", SessionId: "sess-1"},
+	})
+	require.NoError(t, err)
+
+	balance, _ := w.GetBalance(ctx, "user-1")
+	assert.True(t, balance.Equal(wallet.DefaultInitialBalance))
+	assert.Empty(t, server.Rounds().Open("sess-1"))
+}
+
+// TestRound_BeginAfterClose 測試已結束的回合無法以相同 ID 重新開啟 (避免離開時重複退款)
+func TestRound_BeginAfterClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := mock_engine.NewMockGameHandler(ctrl)
+	w := wallet.NewMockWallet()
+	server, peer := joinPeer(t, handler, w)
+	ctx := context.Background()
+
+	round, err := peer.BeginRound(ctx, "r-1", decimal.NewFromInt(100))
+	require.NoError(t, err)
+	require.NoError(t, round.Settle(ctx, decimal.NewFromInt(300)))
+
+	// 重試 Begin 不會開啟新回合
+	_, err = peer.BeginRound(ctx, "r-1", decimal.NewFromInt(100))
+	assert.ErrorIs(t, err, engine.ErrRoundClosed)
+	assert.Empty(t, peer.OpenRounds())
+
+	refunded, err := peer.BeginRound(ctx, "r-2", decimal.NewFromInt(40))
+	require.NoError(t, err)
+	require.NoError(t, refunded.Refund(ctx, "cancel"))
+	_, err = peer.BeginRound(ctx, "r-2", decimal.NewFromInt(40))
+	assert.ErrorIs(t, err, engine.ErrRoundClosed)
+
+	handler.EXPECT().OnQuit(gomock.Any(), gomock.Any()).Return(nil)
+	_, err = server.OnPlayerQuit(ctx, &gameRPC.QuitReq{
+		Header: &proto.PacketHeader{UserId: "user-1", SessionId: "sess-1"},
+	})
+	require.NoError(t, err)
+
+	// 下注 100、派彩 300: 淨贏 200，不會多出退款
+	balance, _ := w.GetBalance(ctx, "user-1")
+	assert.True(t, balance.Equal(wallet.DefaultInitialBalance.Add(decimal.NewFromInt(200))), balance.String())
+}
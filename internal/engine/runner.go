@@ -17,7 +17,11 @@ import (
 	"github.com/JoeShih716/go-k8s-game-server/internal/di"
 	central_client "github.com/JoeShih716/go-k8s-game-server/internal/grpc_client/central"
 	"github.com/JoeShih716/go-k8s-game-server/internal/kit/bootstrap"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/health"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
 	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/mysql"
 )
 
 // GameServerConfig 定義 Game Server 的專屬配置
@@ -26,6 +30,14 @@ type GameServerConfig struct {
 	ServiceType     proto.ServiceType
 	GameIDs         []int32
 	DefaultGrpcPort int
+	Options         []ServerOption // 額外的 Server 選項 (e.g. WithRoomCapacity)
+	// DrainTimeout 收到停止信號時的排空期限: 期間不再接受新玩家，等待玩家離開後才關閉 (0 代表不排空)
+	// K8s 的 terminationGracePeriodSeconds 需大於此值
+	DrainTimeout time.Duration
+	// Version 實例版本 (Canary / Blue-Green 路由使用)，設定檔 app.version (APP_VERSION) 優先
+	Version string
+	// Labels 實例標籤，隨註冊回報給 Central
+	Labels map[string]string
 }
 
 // RunGameServer 啟動通用的 Game Server 流程
@@ -43,26 +55,35 @@ func RunGameServer(cfg GameServerConfig, handler GameHandler) {
 		host = app.Config.App.PodIP
 	}
 
+	version := cfg.Version
+	if app.Config.App.Version != "" {
+		version = app.Config.App.Version
+	}
+
+	// 2.1 分散式追蹤 (延續 Connector 帶來的追蹤上下文)
+	tracerProvider, err := tracing.Init(context.Background(), cfg.ServiceName, version, app.Config.Tracing)
+	if err != nil {
+		slog.Error("Failed to initialize tracing", "error", err)
+	}
+
 	// 3. 連線 Central
 	centralAddr := app.Config.Services["central"]
-	conn, err := grpc.NewClient(centralAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(centralAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(metrics.UnaryClientInterceptor()),
+		tracing.DialOption(),
+	)
 	if err != nil {
 		slog.Error("Failed to connect to central", "error", err)
 	}
 
-	// 4. 初始化 Registrar
-	registrar := central_client.NewRegistrar(conn, &central_client.Config{
-		ServiceName: cfg.ServiceName,
-		ServiceType: cfg.ServiceType,
-		Endpoint:    fmt.Sprintf("%s:%d", host, port),
-		CentralAddr: centralAddr,
-		GameIDs:     cfg.GameIDs,
-	})
-
-	// 5. gRPC Pool (共用組件)
-	grpcPool := grpcpkg.NewPool()
+	// 4. gRPC Pool (共用組件)
+	grpcPool := grpcpkg.NewPool(
+		grpcpkg.WithInterceptor(metrics.UnaryClientInterceptor()),
+		grpcpkg.WithDialOptions(tracing.DialOption()),
+	)
 
-	// 5.1 Initialize Redis Provider (Use DI)
+	// 4.1 Initialize Redis Provider (Use DI)
 	// 使用共用的 DI 初始化邏輯
 	// 注意: Game Server 通常需要 User DB (for UserRepo) 和 Central DB (若有需要)
 	// InitializeRedisProvider 會檢查 Config 並建立連線
@@ -73,15 +94,40 @@ func RunGameServer(cfg GameServerConfig, handler GameHandler) {
 	}
 	defer redisProvider.Close()
 
-	// 5.2 Initialize Services
+	// 4.2 Initialize MySQL (僅在有服務使用 MySQL 時)
+	var mysqlClient *mysql.Client
+	if di.NeedsMySQL(app.Config) {
+		mysqlClient, err = di.InitializeMySQLClient(context.Background(), app.Config)
+		if err != nil {
+			slog.Error("Failed to initialize MySQL client", "error", err)
+			return
+		}
+		defer func() { _ = mysqlClient.Close() }()
+	}
+
+	// 4.3 Initialize Services
 	// 使用 Generic DI Providers
-	userSvc := di.ProvideUserService(app.Config, redisProvider)
-	walletSvc := di.ProvideWalletService(app.Config, redisProvider)
+	userSvc := di.ProvideUserService(app.Config, redisProvider, mysqlClient)
+	walletSvc := di.ProvideWalletService(app.Config, redisProvider, mysqlClient)
 
-	// 6. Framework Server Setup
+	// 5. Framework Server Setup
 	// 判斷是否為 Stateful (根據 ServiceType)
 	isStateful := cfg.ServiceType == proto.ServiceType_STATEFUL
-	gameServer := NewServer(handler, grpcPool, isStateful, cfg.ServiceName, userSvc, walletSvc)
+	opts := append([]ServerOption{WithDrainTimeout(cfg.DrainTimeout)}, cfg.Options...)
+	gameServer := NewServer(handler, grpcPool, isStateful, cfg.ServiceName, userSvc, walletSvc, opts...)
+
+	// 6. 初始化 Registrar (心跳回報 gameServer 的實際負載)
+	registrar := central_client.NewRegistrar(conn, &central_client.Config{
+		ServiceName: cfg.ServiceName,
+		ServiceType: cfg.ServiceType,
+		Endpoint:    fmt.Sprintf("%s:%d", host, port),
+		CentralAddr: centralAddr,
+		GameIDs:     cfg.GameIDs,
+		Version:     version,
+		Labels:      cfg.Labels,
+		LoadFunc:    gameServer.Load,
+	})
+	gameServer.markDraining = registrar.Drain
 
 	// 7. gRPC Server Setup
 	grpcServer := grpc.NewServer(
@@ -89,13 +135,29 @@ func RunGameServer(cfg GameServerConfig, handler GameHandler) {
 			MinTime:             5 * time.Second,
 			PermitWithoutStream: true,
 		}),
+		tracing.ServerOption(),
 	)
 
 	// 註冊 Framework Server 到 gRPC
 	gameRPC.RegisterGameRPCServer(grpcServer, gameServer)
+	app.Health.RegisterGRPC(grpcServer)
 	reflection.Register(grpcServer)
 
+	// 7.1 健康檢查: 持有 Central 租約且依賴可用時才就緒，排空中 (GameRPC.Drain 或停止信號) 不再就緒
+	app.Health.AddReadiness("redis", redisProvider.Ping)
+	if conn != nil {
+		app.Health.AddReadiness("central", health.GRPCCheck(conn))
+	}
+	app.Health.AddReadiness("registrar", registrar.Check)
+	app.Health.AddReadiness("drain", func(context.Context) error {
+		if gameServer.Draining() {
+			return health.ErrDraining
+		}
+		return nil
+	})
+
 	// 8. 執行
+	metricsServer := metrics.Serve(app.Config.App.MetricsPort, app.Health.Mount)
 	app.Run(func() error {
 		// 8.1 Listener
 		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
@@ -120,8 +182,13 @@ func RunGameServer(cfg GameServerConfig, handler GameHandler) {
 		return grpcServer.Serve(lis)
 	}, func() {
 		// Cleanup
+		// 先排空 (租約標記為 draining，Central 不再分配新玩家)，待玩家離開或期限到達後才登出
+		gameServer.drainAndWait(context.Background())
 		registrar.Stop(context.Background())
 		grpcPool.Close()
+		gameServer.Close() // 串流不會自行結束，需先關閉才能 GracefulStop (同時停止 Tick Loop)
 		grpcServer.GracefulStop()
+		metrics.Shutdown(metricsServer)
+		tracing.Shutdown(tracerProvider)
 	})
 }
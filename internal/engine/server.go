@@ -4,11 +4,19 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
 	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
 )
 
@@ -21,6 +29,22 @@ type GameHandler interface {
 	OnMessage(ctx context.Context, peer *Peer, payload []byte) ([]byte, error)
 }
 
+// actionKey Context Key: 目前處理中訊息的客戶端指令代碼
+type actionKey struct{}
+
+// Action 取得 OnMessage / OnRoomMessage 處理中訊息的客戶端指令代碼 (ex: "spin")
+// payload 為客戶端封包的 payload (不含外層 Envelope)，依指令代碼決定如何解析
+func Action(ctx context.Context) string {
+	action, _ := ctx.Value(actionKey{}).(string)
+	return action
+}
+
+// ReconnectHandler 可選介面: GameHandler 若實作此介面，玩家斷線重連時會被呼叫
+// peer 為綁定新 Session/Connector 的 Peer，舊的 Peer 實例已失效，業務邏輯若有持有需自行替換
+type ReconnectHandler interface {
+	OnReconnect(ctx context.Context, peer *Peer, oldSessionID string) error
+}
+
 // BaseHandler 提供 GameHandler 的預設空實作 (Optional)
 type BaseHandler struct{}
 
@@ -41,6 +65,54 @@ type Server struct {
 	// Injected Services
 	userSvc   ports.UserService
 	walletSvc ports.WalletService
+	// rounds 下注回合 (玩家離開時自動退款)
+	rounds *RoundManager
+	// inFlight 處理中的 RPC 數量 (負載回報用)
+	inFlight atomic.Int64
+	// channels Connector 雙向串流 (Key: ConnectorHost)
+	channels *channelRegistry
+	// lanes 串流訊息依 SessionID 序列化處理
+	lanes *sessionLanes
+	// rooms 房間管理 (Stateful)
+	rooms        *RoomManager
+	roomCapacity int
+	// Tick Loop (Optional)
+	serviceTick time.Duration
+	roomTick    time.Duration
+	serviceLoop *loop
+	// 排空模式 (見 drain.go)
+	drainTimeout  time.Duration
+	drainMu       sync.Mutex
+	draining      bool
+	drainDeadline time.Time
+	markDraining  func(ctx context.Context) error // 標記 Central 租約為排空中 (由 RunGameServer 設定)
+}
+
+// ServerOption 定義了 Server 的配置選項函數
+type ServerOption func(*Server)
+
+// WithRoomCapacity 設定房間預設人數上限 (0 代表不限，可用 WithCapacity 針對單一房間覆寫)
+func WithRoomCapacity(capacity int) ServerOption {
+	return func(s *Server) {
+		s.roomCapacity = capacity
+	}
+}
+
+// WithServiceTick 整個服務以單一 Tick Loop 執行: 所有 Handler 回呼 (Join/Quit/Message/Reconnect 與房間事件)
+// 依序在同一個 Goroutine 上處理，GameHandler 若實作 TickHandler 則每個 Tick 呼叫 OnTick
+func WithServiceTick(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.serviceTick = interval
+	}
+}
+
+// WithRoomTick 每個房間擁有自己的 Tick Loop: 房間事件與房間訊息依序在該房間的 Goroutine 上處理，
+// GameHandler 若實作 RoomTickHandler 則每個 Tick 呼叫 OnRoomTick (與 WithServiceTick 同時設定時以服務 Loop 為準)
+// 注意: 在房間 Hook 內同步操作其他房間 (e.g. Join) 時，兩個房間互相等待會造成 Deadlock
+func WithRoomTick(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.roomTick = interval
+	}
 }
 
 // NewServer 建立 Framework Server
@@ -52,13 +124,14 @@ func NewServer(
 	serviceName string,
 	userSvc ports.UserService,
 	walletSvc ports.WalletService,
+	opts ...ServerOption,
 ) *Server {
 	var mgr *PeerManager
 	if isStateful {
 		mgr = NewPeerManager()
 	}
 
-	return &Server{
+	s := &Server{
 		handler:     handler,
 		peerMgr:     mgr,
 		grpcPool:    pool,
@@ -66,6 +139,58 @@ func NewServer(
 		serviceName: serviceName,
 		userSvc:     userSvc,
 		walletSvc:   walletSvc,
+		rounds:      NewRoundManager(serviceName),
+		channels:    newChannelRegistry(),
+		lanes:       newSessionLanes(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// GameHandler 若實作 RoomHandler 則接收房間生命週期事件
+	roomHandler, _ := handler.(RoomHandler)
+	s.rooms = NewRoomManager(roomHandler, s.roomCapacity)
+	s.rooms.service = serviceName
+
+	if s.serviceTick > 0 {
+		var onTick func(ctx context.Context, dt time.Duration)
+		if th, ok := handler.(TickHandler); ok {
+			onTick = th.OnTick
+		}
+		s.serviceLoop = newLoop(s.serviceTick, onTick)
+		s.rooms.serviceLoop = s.serviceLoop
+	}
+	s.rooms.roomTick = s.roomTick
+	return s
+}
+
+// Close 釋放 Server 資源: 關閉 Connector 串流並停止所有 Tick Loop (須於 grpc.Server.GracefulStop 前呼叫)
+func (s *Server) Close() {
+	s.CloseChannels()
+	s.rooms.stopAll()
+	if s.serviceLoop != nil {
+		s.serviceLoop.stop()
+	}
+}
+
+// invoke 執行 Handler 回呼 (hook 為指標標籤): 啟用 WithServiceTick 時排入服務 Loop 依序處理，否則直接執行
+func (s *Server) invoke(ctx context.Context, hook string, fn func(ctx context.Context)) error {
+	fn = timeHook(s.serviceName, hook, fn)
+	if s.serviceLoop == nil {
+		fn(ctx)
+		return nil
+	}
+	return s.serviceLoop.call(ctx, fn)
+}
+
+// timeHook 包裝 Hook 回呼，記錄實際執行時間 (不含排入 Loop 的等待時間) 並為每次執行建立 Span
+func timeHook(service, hook string, fn func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ctx, span := tracing.Start(ctx, "GameHandler."+hook, tracing.AttrService.String(service))
+		defer span.End()
+		start := time.Now()
+		fn(ctx)
+		metrics.EngineHookDuration.WithLabelValues(service, hook).Observe(time.Since(start).Seconds())
 	}
 }
 
@@ -74,14 +199,61 @@ func (s *Server) PeerManager() *PeerManager {
 	return s.peerMgr
 }
 
+// Rounds 回傳 RoundManager
+func (s *Server) Rounds() *RoundManager {
+	return s.rounds
+}
+
+// Rooms 回傳 RoomManager
+func (s *Server) Rooms() *RoomManager {
+	return s.rooms
+}
+
+// Load 回傳當前負載: 在線 Peer 數 (Stateful) + 處理中的 RPC 數
+// 透過 Registrar 心跳回報給 Central 做負載均衡
+func (s *Server) Load() int32 {
+	load := s.inFlight.Load()
+	if s.peerMgr != nil {
+		load += s.peerMgr.Count()
+	}
+	if load > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(load)
+}
+
+// newPeer 建立綁定 Server 依賴 (gRPC Pool, WalletService, Connector 串流) 的 Peer
+func (s *Server) newPeer(user *domain.User, sessionID, connectorHost string) *Peer {
+	peer := NewPeer(user, sessionID, connectorHost, s.grpcPool)
+	peer.wallet = s.walletSvc
+	peer.rounds = s.rounds
+	peer.channels = s.channels
+	peer.roomMgr = s.rooms
+	return peer
+}
+
+// trackInFlight 記錄處理中的 RPC，回傳的函式需於處理結束時呼叫
+func (s *Server) trackInFlight() func() {
+	s.inFlight.Add(1)
+	return func() { s.inFlight.Add(-1) }
+}
+
 // OnPlayerJoin 玩家進入
 func (s *Server) OnPlayerJoin(ctx context.Context, req *gameRPC.JoinReq) (*gameRPC.JoinResp, error) {
+	defer s.trackInFlight()()
+
 	userID := req.Header.UserId
 	sessID := req.Header.SessionId
 	connHost := req.ConnectorHost
 
 	slog.Info("OnPlayerJoin", "service", s.serviceName, "user_id", userID, "session_id", sessID)
 
+	// 排空中不接受新玩家 (Central 已不再分配，僅處理路由競態)
+	if s.Draining() {
+		slog.Warn("OnPlayerJoin rejected: server draining", "user_id", userID, "session_id", sessID)
+		return &gameRPC.JoinResp{Code: proto.ErrorCode_DRAINING, ErrorMessage: ErrDraining.Error()}, nil
+	}
+
 	// 1. Fetch User Data (using UserService)
 	user, err := s.userSvc.GetUserByID(ctx, userID)
 	if err != nil {
@@ -97,19 +269,23 @@ func (s *Server) OnPlayerJoin(ctx context.Context, req *gameRPC.JoinReq) (*gameR
 		slog.Warn("Failed to fetch balance in OnPlayerJoin", "user_id", userID, "error", err)
 	}
 
-	peer := NewPeer(user, sessID, connHost, s.grpcPool)
+	peer := s.newPeer(user, sessID, connHost)
 
 	if s.isStateful {
 		s.peerMgr.Add(peer)
 	}
 
 	// 呼叫業務邏輯
-	if err := s.handler.OnJoin(ctx, peer); err != nil {
-		slog.Error("Handler.OnJoin failed", "error", err)
+	var joinErr error
+	if callErr := s.invoke(ctx, "OnJoin", func(ctx context.Context) { joinErr = s.handler.OnJoin(ctx, peer) }); callErr != nil {
+		joinErr = callErr
+	}
+	if joinErr != nil {
+		slog.Error("Handler.OnJoin failed", "error", joinErr)
 		if s.isStateful {
 			s.peerMgr.Remove(sessID)
 		}
-		return nil, err
+		return nil, joinErr
 	}
 
 	return &gameRPC.JoinResp{Code: proto.ErrorCode_SUCCESS}, nil
@@ -117,6 +293,8 @@ func (s *Server) OnPlayerJoin(ctx context.Context, req *gameRPC.JoinReq) (*gameR
 
 // OnPlayerQuit 玩家離開
 func (s *Server) OnPlayerQuit(ctx context.Context, req *gameRPC.QuitReq) (*gameRPC.QuitResp, error) {
+	defer s.trackInFlight()()
+
 	sessID := req.Header.SessionId
 	slog.Info("OnPlayerQuit", "service", s.serviceName, "session_id", sessID)
 
@@ -131,14 +309,28 @@ func (s *Server) OnPlayerQuit(ctx context.Context, req *gameRPC.QuitReq) (*gameR
 	} else {
 		// Stateless: 建立 Partial Peer
 		mockUser := &domain.User{ID: req.Header.UserId}
-		peer = NewPeer(mockUser, sessID, "", s.grpcPool)
+		peer = s.newPeer(mockUser, sessID, "")
 	}
 
 	// 呼叫業務邏輯
-	if err := s.handler.OnQuit(ctx, peer); err != nil {
+	var err error
+	if callErr := s.invoke(ctx, "OnQuit", func(ctx context.Context) { err = s.handler.OnQuit(ctx, peer) }); callErr != nil {
+		err = callErr
+	}
+	if err != nil {
 		slog.Error("Handler.OnQuit failed", "error", err)
 	}
 
+	// 離開所在房間 (Handler 可在 OnQuit 中自行離開)
+	if err := s.rooms.Leave(ctx, peer); err != nil && !errors.Is(err, ErrNotInRoom) {
+		slog.Error("Failed to leave room", "session_id", sessID, "error", err)
+	}
+
+	// 退還尚未結算的回合 (Handler 可在 OnQuit 中自行結算)
+	if err := s.rounds.RefundAll(ctx, sessID, "player quit"); err != nil {
+		slog.Error("Failed to refund open rounds", "session_id", sessID, "error", err)
+	}
+
 	if s.isStateful {
 		s.peerMgr.Remove(sessID)
 	}
@@ -146,9 +338,69 @@ func (s *Server) OnPlayerQuit(ctx context.Context, req *gameRPC.QuitReq) (*gameR
 	return &gameRPC.QuitResp{Code: proto.ErrorCode_SUCCESS}, nil
 }
 
+// OnPlayerReconnect 玩家斷線重連 (Session Resume)
+func (s *Server) OnPlayerReconnect(ctx context.Context, req *gameRPC.ReconnectReq) (*gameRPC.ReconnectResp, error) {
+	defer s.trackInFlight()()
+
+	sessID := req.Header.SessionId
+	oldSessID := req.OldSessionId
+	slog.Info("OnPlayerReconnect", "service", s.serviceName, "user_id", req.Header.UserId, "session_id", sessID, "old_session_id", oldSessID)
+
+	var peer *Peer
+
+	if s.isStateful {
+		oldPeer := s.peerMgr.Get(oldSessID)
+		if oldPeer == nil {
+			// 保留期間 Game Server 已移除玩家 (e.g. 房間結束)，由 Connector 引導重新進入
+			slog.Warn("Peer not found during Reconnect", "old_session_id", oldSessID)
+			return &gameRPC.ReconnectResp{
+				Code:         proto.ErrorCode_INVALID_PARAMS,
+				ErrorMessage: "Peer not found",
+			}, nil
+		}
+		// 建立新的 Peer (保留 User 資料)，避免修改舊 Peer 造成 Data Race
+		peer = s.newPeer(oldPeer.User, sessID, req.ConnectorHost)
+		s.peerMgr.Remove(oldSessID)
+		s.peerMgr.Add(peer)
+		s.rounds.Rebind(oldSessID, peer)
+		s.rooms.Rebind(oldSessID, peer)
+	} else {
+		// Stateless: 建立 Partial Peer
+		mockUser := &domain.User{ID: req.Header.UserId}
+		peer = s.newPeer(mockUser, sessID, req.ConnectorHost)
+	}
+
+	// 呼叫業務邏輯 (Optional)
+	if rh, ok := s.handler.(ReconnectHandler); ok {
+		var err error
+		if callErr := s.invoke(ctx, "OnReconnect", func(ctx context.Context) { err = rh.OnReconnect(ctx, peer, oldSessID) }); callErr != nil {
+			err = callErr
+		}
+		if err != nil {
+			slog.Error("Handler.OnReconnect failed", "error", err)
+		}
+	}
+
+	return &gameRPC.ReconnectResp{Code: proto.ErrorCode_SUCCESS}, nil
+}
+
 // OnMessage 處理訊息
-func (s *Server) OnMessage(ctx context.Context, req *gameRPC.MsgReq) (*gameRPC.MsgResp, error) {
+func (s *Server) OnMessage(ctx context.Context, req *gameRPC.MsgReq) (resp *gameRPC.MsgResp, err error) {
+	defer s.trackInFlight()()
+
 	sessID := req.Header.SessionId
+	ctx, span := tracing.Start(ctx, "GameServer.OnMessage",
+		tracing.AttrService.String(s.serviceName),
+		tracing.AttrAction.String(req.Action),
+		tracing.AttrUserID.String(req.Header.UserId),
+		tracing.AttrSessionID.String(sessID),
+	)
+	defer func() {
+		if err == nil && resp != nil && resp.Code != proto.ErrorCode_SUCCESS {
+			span.SetStatus(codes.Error, resp.ErrorMessage)
+		}
+		tracing.End(span, err)
+	}()
 
 	var peer *Peer
 
@@ -163,10 +415,11 @@ func (s *Server) OnMessage(ctx context.Context, req *gameRPC.MsgReq) (*gameRPC.M
 	} else {
 		// Stateless: 建立 Partial Peer
 		mockUser := &domain.User{ID: req.Header.UserId}
-		peer = NewPeer(mockUser, sessID, "", s.grpcPool)
+		peer = s.newPeer(mockUser, sessID, "")
 	}
 
-	respPayload, err := s.handler.OnMessage(ctx, peer, req.Payload)
+	ctx = context.WithValue(ctx, actionKey{}, req.Action)
+	respPayload, err := s.dispatchMessage(ctx, peer, req.Payload)
 	if err != nil {
 		slog.Error("Handler.OnMessage failed", "error", err)
 		return &gameRPC.MsgResp{
@@ -180,3 +433,18 @@ func (s *Server) OnMessage(ctx context.Context, req *gameRPC.MsgReq) (*gameRPC.M
 		Payload: respPayload,
 	}, nil
 }
+
+// dispatchMessage 將訊息交給業務邏輯: 玩家在房間內且 Handler 實作 RoomHandler 時路由至房間
+func (s *Server) dispatchMessage(ctx context.Context, peer *Peer, payload []byte) ([]byte, error) {
+	if s.rooms.handler != nil {
+		if room := s.rooms.RoomOf(peer.SessionID); room != nil {
+			return s.rooms.Message(ctx, room, peer, payload)
+		}
+	}
+	var resp []byte
+	var err error
+	if callErr := s.invoke(ctx, "OnMessage", func(ctx context.Context) { resp, err = s.handler.OnMessage(ctx, peer, payload) }); callErr != nil {
+		return nil, callErr
+	}
+	return resp, err
+}
@@ -2,6 +2,7 @@ package engine_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/shopspring/decimal"
@@ -10,6 +11,7 @@ import (
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
 	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 	"github.com/JoeShih716/go-k8s-game-server/internal/engine"
 	mock_ports "github.com/JoeShih716/go-k8s-game-server/test/mocks/core/ports"
 	mock_engine "github.com/JoeShih716/go-k8s-game-server/test/mocks/engine"
@@ -64,3 +66,76 @@ func TestServer_OnPlayerJoin_Success(t *testing.T) {
 		t.Errorf("expected success code, got %v", resp.Code)
 	}
 }
+
+// TestPeer_Debit 測試 Handler 透過 Peer 扣款並更新餘額快照
+func TestPeer_Debit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock_engine.NewMockGameHandler(ctrl)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+
+	userID := "user-123"
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), userID).Return(&domain.User{ID: userID}, nil)
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), userID).Return(decimal.NewFromInt(100), nil)
+
+	// 下注 30 成功，再下注 100 餘額不足
+	mockWalletSvc.EXPECT().Withdraw(gomock.Any(), userID, decimal.NewFromInt(30), "bet-1", "bet").
+		Return(&domain.Transaction{ID: "bet-1", BalanceAfter: decimal.NewFromInt(70)}, nil)
+	mockWalletSvc.EXPECT().Withdraw(gomock.Any(), userID, decimal.NewFromInt(100), "bet-2", "bet").
+		Return(nil, ports.ErrInsufficientFunds)
+
+	mockHandler.EXPECT().OnJoin(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, peer *engine.Peer) error {
+		if _, err := peer.Debit(ctx, decimal.NewFromInt(30), "bet-1", "bet"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !peer.Balance().Equal(decimal.NewFromInt(70)) {
+			t.Errorf("expected balance 70, got %v", peer.Balance())
+		}
+
+		if _, err := peer.Debit(ctx, decimal.NewFromInt(100), "bet-2", "bet"); !errors.Is(err, ports.ErrInsufficientFunds) {
+			t.Errorf("expected ErrInsufficientFunds, got %v", err)
+		}
+		if !peer.Balance().Equal(decimal.NewFromInt(70)) {
+			t.Errorf("balance must not change on failed debit, got %v", peer.Balance())
+		}
+		return nil
+	})
+
+	server := engine.NewServer(mockHandler, nil, true, "test-service", mockUserSvc, mockWalletSvc)
+	_, err := server.OnPlayerJoin(context.Background(), &gameRPC.JoinReq{
+		Header: &proto.PacketHeader{UserId: userID, SessionId: "sess-abc"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestServer_PeerSend_NoConnectorPool 未設定 Pool 的 Server，Peer.Send 不發送也不回報失敗
+func TestServer_PeerSend_NoConnectorPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHandler := mock_engine.NewMockGameHandler(ctrl)
+	mockUserSvc := mock_ports.NewMockUserService(ctrl)
+	mockWalletSvc := mock_ports.NewMockWalletService(ctrl)
+
+	mockUserSvc.EXPECT().GetUserByID(gomock.Any(), "user-1").Return(&domain.User{ID: "user-1"}, nil)
+	mockWalletSvc.EXPECT().GetBalance(gomock.Any(), "user-1").Return(decimal.Zero, nil)
+	mockHandler.EXPECT().OnJoin(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, peer *engine.Peer) error {
+		return peer.Send(ctx, []byte("welcome"))
+	})
+
+	server := engine.NewServer(mockHandler, nil, false, "test-service", mockUserSvc, mockWalletSvc)
+	resp, err := server.OnPlayerJoin(context.Background(), &gameRPC.JoinReq{
+		Header:        &proto.PacketHeader{UserId: "user-1", SessionId: "sess-1"},
+		ConnectorHost: "connector-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		t.Errorf("expected success code, got %v", resp.Code)
+	}
+}
@@ -2,13 +2,32 @@ package central_sdk
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"google.golang.org/grpc"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 )
 
+// ErrUserOffline 使用者沒有符合條件的在線會話
+var ErrUserOffline = errors.New("central: user offline")
+
+// MaintenanceError 遊戲 (或全服) 維護中，無法加入遊戲
+type MaintenanceError struct {
+	Info *centralRPC.MaintenanceInfo
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.Info.GetGameId() == 0 {
+		return "central: server is under maintenance"
+	}
+	return fmt.Sprintf("central: game %d is under maintenance", e.Info.GetGameId())
+}
+
 // Client 封裝了 Connector 對 Central 的 RPC 呼叫
 // 提供 Login, GetRoute 等功能
 type Client struct {
@@ -22,20 +41,121 @@ func NewClient(conn *grpc.ClientConn) *Client {
 	}
 }
 
-// Login 呼叫 Central 進行登入
-func (c *Client) Login(ctx context.Context, token string) (*centralRPC.LoginResponse, error) {
+// Login 呼叫 Central 進行登入 (sessionID 與 connectorHost 用於同時在線會話數限制)
+func (c *Client) Login(ctx context.Context, token, sessionID, connectorHost string) (*centralRPC.LoginResponse, error) {
 	return c.rpcClient.Login(ctx, &centralRPC.LoginRequest{
-		Token: token,
+		Token:         token,
+		SessionId:     sessionID,
+		ConnectorHost: connectorHost,
+	})
+}
+
+// GetRoute 呼叫 Central 取得路由 (不檢查維護狀態，用於已在遊戲中的玩家)
+// req.Version 不為空時固定路由到該版本的實例
+func (c *Client) GetRoute(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error) {
+	resp, err := c.rpcClient.GetRoute(ctx, routeRequest(req, false))
+	if err != nil {
+		return nil, err
+	}
+	return &ports.Route{Endpoint: resp.TargetEndpoint, ServiceType: resp.Type, Version: resp.Version}, nil
+}
+
+// JoinRoute 呼叫 Central 取得新加入遊戲的路由 (依版本路由規則挑選實例版本)
+// 遊戲 (或全服) 維護中且使用者不在白名單時回傳 *MaintenanceError
+func (c *Client) JoinRoute(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error) {
+	resp, err := c.rpcClient.GetRoute(ctx, routeRequest(req, true))
+	if err != nil {
+		return nil, err
+	}
+	switch resp.Code {
+	case proto.ErrorCode_SUCCESS:
+		return &ports.Route{Endpoint: resp.TargetEndpoint, ServiceType: resp.Type, Version: resp.Version}, nil
+	case proto.ErrorCode_MAINTENANCE:
+		return nil, &MaintenanceError{Info: resp.Maintenance}
+	default:
+		return nil, errors.New(resp.ErrorMessage)
+	}
+}
+
+func routeRequest(req *domain.RouteRequest, join bool) *centralRPC.GetRouteRequest {
+	return &centralRPC.GetRouteRequest{
+		GameId:        req.GameID,
+		Join:          join,
+		UserId:        req.UserID,
+		ClientVersion: req.ClientVersion,
+		Version:       req.Version,
+	}
+}
+
+// ListMaintenance 列出所有尚未結束的維護排程
+func (c *Client) ListMaintenance(ctx context.Context) ([]*centralRPC.MaintenanceInfo, error) {
+	resp, err := c.rpcClient.ListMaintenance(ctx, &centralRPC.ListMaintenanceRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		return nil, errors.New(resp.ErrorMessage)
+	}
+	return resp.Schedules, nil
+}
+
+// PushToUser 推播訊息給使用者的所有在線會話 (gameID 不為 0 時僅限在該遊戲中的會話)
+// 回傳符合條件的會話數，使用者不在線回傳 ErrUserOffline
+func (c *Client) PushToUser(ctx context.Context, userID string, payload []byte, gameID int32) (int32, error) {
+	resp, err := c.rpcClient.PushToUser(ctx, &centralRPC.PushToUserRequest{
+		UserId:  userID,
+		Payload: payload,
+		GameId:  gameID,
 	})
+	if err != nil {
+		return 0, err
+	}
+	switch resp.Code {
+	case proto.ErrorCode_SUCCESS:
+		return resp.SessionCount, nil
+	case proto.ErrorCode_INVALID_PARAMS:
+		return 0, ErrUserOffline
+	default:
+		return 0, errors.New(resp.ErrorMessage)
+	}
 }
 
-// GetRoute 呼叫 Central 取得路由
-func (c *Client) GetRoute(ctx context.Context, gameID int32) (string, proto.ServiceType, error) {
-	resp, err := c.rpcClient.GetRoute(ctx, &centralRPC.GetRouteRequest{
-		GameId: gameID,
+// BroadcastAll 全服廣播 (gameID / connectorHost 為可選的篩選條件)
+func (c *Client) BroadcastAll(ctx context.Context, payload []byte, gameID int32, connectorHost string) error {
+	resp, err := c.rpcClient.BroadcastAll(ctx, &centralRPC.BroadcastAllRequest{
+		Payload:       payload,
+		GameId:        gameID,
+		ConnectorHost: connectorHost,
 	})
 	if err != nil {
-		return "", proto.ServiceType_UNKNOWN_SERVICE, err
+		return err
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		return errors.New(resp.ErrorMessage)
+	}
+	return nil
+}
+
+// GetPresence 查詢使用者所有在線會話 (不在線時回傳空列表)
+func (c *Client) GetPresence(ctx context.Context, userID string) ([]*centralRPC.PresenceInfo, error) {
+	resp, err := c.rpcClient.GetPresence(ctx, &centralRPC.GetPresenceRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		return nil, errors.New(resp.ErrorMessage)
+	}
+	return resp.Sessions, nil
+}
+
+// ListOnline 列出在線會話 (gameID 為 0 代表所有在線會話)
+func (c *Client) ListOnline(ctx context.Context, gameID int32) (*centralRPC.ListOnlineResponse, error) {
+	resp, err := c.rpcClient.ListOnline(ctx, &centralRPC.ListOnlineRequest{GameId: gameID})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		return nil, errors.New(resp.ErrorMessage)
 	}
-	return resp.TargetEndpoint, resp.Type, nil
+	return resp, nil
 }
@@ -2,7 +2,10 @@ package central_sdk
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
@@ -16,16 +19,26 @@ type Registrar struct {
 	rpcClient centralRPC.CentralRPCClient
 	config    *Config
 	conn      *grpc.ClientConn
+	leaseMu   sync.RWMutex // 保護 leaseID (心跳重新註冊時寫入，Drain 由 RPC goroutine 讀取)
 	leaseID   string
 	stopChan  chan struct{}
+	draining  atomic.Bool // 已進入排空模式 (重新註冊後需再次標記)
+	leased    atomic.Bool // 持有有效租約 (註冊成功後為 true，心跳失敗或登出後為 false)
 }
 
+// ErrNoLease 尚未向 Central 註冊成功，或心跳失敗正在重新註冊
+var ErrNoLease = errors.New("central: no active lease")
+
 type Config struct {
 	ServiceName string
 	ServiceType proto.ServiceType
 	Endpoint    string // Kubernetes Pod IP + Port
 	CentralAddr string
-	GameIDs     []int32 // [NEW] Supported Game IDs
+	GameIDs     []int32           // [NEW] Supported Game IDs
+	Version     string            // 實例版本 (版本路由規則依此挑選實例，空字串代表未區分版本)
+	Labels      map[string]string // 實例標籤 (ex: {"track": "canary"})，僅供管理介面查詢
+	// LoadFunc 回報當前負載 (連線數 + 處理中請求)，隨心跳送出供 Central 做負載均衡 (Optional)
+	LoadFunc func() int32
 }
 
 // NewRegistrar 建立註冊器
@@ -48,8 +61,17 @@ func (r *Registrar) StartHeartbeat(ctx context.Context) {
 	r.heartbeatLoop(ctx)
 }
 
+// Check 回報租約狀態 (健康檢查使用)，未持有有效租約時回傳 ErrNoLease
+func (r *Registrar) Check(context.Context) error {
+	if !r.leased.Load() {
+		return ErrNoLease
+	}
+	return nil
+}
+
 // Stop 停止心跳並登出
 func (r *Registrar) Stop(ctx context.Context) {
+	r.leased.Store(false)
 	select {
 	case <-r.stopChan:
 		// Already closed
@@ -57,13 +79,53 @@ func (r *Registrar) Stop(ctx context.Context) {
 		close(r.stopChan)
 	}
 
-	if r.leaseID != "" {
+	if leaseID := r.lease(); leaseID != "" {
 		_, _ = r.rpcClient.Deregister(ctx, &centralRPC.DeregisterRequest{
-			LeaseId: r.leaseID,
+			LeaseId: leaseID,
 		})
 	}
 }
 
+// Drain 將租約標記為排空中，Central 不再分配新玩家到此實例
+// 心跳失敗重新註冊取得新租約後會自動再次標記
+func (r *Registrar) Drain(ctx context.Context) error {
+	r.draining.Store(true)
+	leaseID := r.lease()
+	if leaseID == "" {
+		return nil
+	}
+	return r.markDraining(ctx, leaseID)
+}
+
+// lease 取得目前的租約 ID (尚未註冊時為空字串)
+func (r *Registrar) lease() string {
+	r.leaseMu.RLock()
+	defer r.leaseMu.RUnlock()
+	return r.leaseID
+}
+
+// setLease 更新租約 ID (註冊成功時呼叫)
+func (r *Registrar) setLease(leaseID string) {
+	r.leaseMu.Lock()
+	defer r.leaseMu.Unlock()
+	r.leaseID = leaseID
+}
+
+// markDraining 向 Central 標記租約為排空中
+func (r *Registrar) markDraining(ctx context.Context, leaseID string) error {
+	resp, err := r.rpcClient.SetDraining(ctx, &centralRPC.SetDrainingRequest{
+		LeaseId:  leaseID,
+		Draining: true,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New("central: failed to mark lease as draining")
+	}
+	return nil
+}
+
 // Close 關閉連線
 func (r *Registrar) Close() {
 	if r.conn != nil {
@@ -83,10 +145,19 @@ func (r *Registrar) registerWithRetry(ctx context.Context) error {
 				Type:        r.config.ServiceType,
 				Endpoint:    r.config.Endpoint,
 				GameIds:     r.config.GameIDs,
+				Version:     r.config.Version,
+				Labels:      r.config.Labels,
 			})
 			if err == nil {
-				r.leaseID = resp.LeaseId
-				slog.Info("Service registered successfully", "lease_id", r.leaseID)
+				// 先更新租約再檢查排空狀態；Drain 先標記排空再讀取租約，兩者至少有一方會標記新租約
+				r.setLease(resp.LeaseId)
+				r.leased.Store(true)
+				slog.Info("Service registered successfully", "lease_id", resp.LeaseId)
+				if r.draining.Load() {
+					if err := r.markDraining(ctx, resp.LeaseId); err != nil {
+						slog.Warn("Failed to mark new lease as draining", "lease_id", resp.LeaseId, "error", err)
+					}
+				}
 				return nil
 			}
 			slog.Error("Registration failed, retrying...", "error", err)
@@ -109,15 +180,24 @@ func (r *Registrar) heartbeatLoop(ctx context.Context) {
 		case <-ticker.C:
 			// 發送心跳
 			resp, err := r.rpcClient.Heartbeat(ctx, &centralRPC.HeartbeatRequest{
-				LeaseId:     r.leaseID,
-				CurrentLoad: 0, // TODO: 整合實際負載
+				LeaseId:     r.lease(),
+				CurrentLoad: r.currentLoad(),
 			})
 
 			if err != nil || (resp != nil && !resp.Success) {
 				slog.Warn("Heartbeat failed, re-registering...", "error", err)
+				r.leased.Store(false)
 				// 重新註冊
 				_ = r.registerWithRetry(ctx)
 			}
 		}
 	}
 }
+
+// currentLoad 取得當前負載，未設定 LoadFunc 時回報 0
+func (r *Registrar) currentLoad() int32 {
+	if r.config.LoadFunc == nil {
+		return 0
+	}
+	return r.config.LoadFunc()
+}
@@ -0,0 +1,83 @@
+package central_sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+)
+
+// fakeCentral 記錄註冊與排空的租約 (Register 可暫停，模擬重新註冊進行中)
+type fakeCentral struct {
+	centralRPC.CentralRPCClient
+
+	registering chan struct{} // Register 開始時通知
+	release     chan struct{} // 關閉後 Register 才回傳
+
+	mu      sync.Mutex
+	seq     int
+	drained map[string]bool
+}
+
+func (f *fakeCentral) Register(context.Context, *centralRPC.RegisterRequest, ...grpc.CallOption) (*centralRPC.RegisterResponse, error) {
+	f.registering <- struct{}{}
+	<-f.release
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seq++
+	return &centralRPC.RegisterResponse{LeaseId: fmt.Sprintf("lease-%d", f.seq)}, nil
+}
+
+func (f *fakeCentral) SetDraining(_ context.Context, req *centralRPC.SetDrainingRequest, _ ...grpc.CallOption) (*centralRPC.SetDrainingResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.drained[req.LeaseId] = true
+	return &centralRPC.SetDrainingResponse{Success: true}, nil
+}
+
+func TestRegistrar_DrainDuringReregister(t *testing.T) {
+	fake := &fakeCentral{
+		registering: make(chan struct{}, 1),
+		release:     make(chan struct{}),
+		drained:     make(map[string]bool),
+	}
+	r := &Registrar{
+		rpcClient: fake,
+		config:    &Config{ServiceName: "test"},
+		stopChan:  make(chan struct{}),
+	}
+	r.setLease("lease-0")
+
+	// 心跳失敗後重新註冊 (與 Drain 同時進行)
+	done := make(chan error, 1)
+	go func() { done <- r.registerWithRetry(context.Background()) }()
+	<-fake.registering
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = r.Drain(context.Background())
+		}()
+	}
+	close(fake.release)
+	wg.Wait()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 新租約也必須被標記為排空中
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if lease := r.lease(); lease != "lease-1" {
+		t.Fatalf("expected lease-1, got %s", lease)
+	}
+	if !fake.drained["lease-1"] {
+		t.Errorf("new lease was not marked as draining: %v", fake.drained)
+	}
+}
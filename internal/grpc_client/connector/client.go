@@ -2,12 +2,23 @@ package connector_sdk
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc"
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
 )
 
+var (
+	// ErrSessionNotFound the target session is not connected to this connector
+	ErrSessionNotFound = errors.New("connector: session not found")
+	// ErrSendQueueFull the target session's outbound queue is full (slow consumer).
+	// Only reported when the connector runs with overflow_policy "kick"; "drop_oldest" evicts the oldest queued message instead
+	ErrSendQueueFull = errors.New("connector: send queue full")
+	// ErrDeliveryFailed the connector failed to encode or write the message, or reported no status for the session
+	ErrDeliveryFailed = errors.New("connector: delivery failed")
+)
+
 // Client wraps connectorRPC.ConnectorRPCClient
 type Client struct {
 	cli connectorRPC.ConnectorRPCClient
@@ -21,21 +32,52 @@ func NewClient(cc grpc.ClientConnInterface) *Client {
 }
 
 // Push sends a message to a specific session (player)
+// Returns ErrSessionNotFound, ErrSendQueueFull or ErrDeliveryFailed when the connector could not deliver it
 func (c *Client) Push(ctx context.Context, sessionID string, payload []byte) error {
-	_, err := c.cli.SendMessage(ctx, &connectorRPC.SendMessageReq{
+	resp, err := c.cli.SendMessage(ctx, &connectorRPC.SendMessageReq{
 		SessionIds: []string{sessionID},
 		Payload:    payload,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	for _, r := range resp.Results {
+		if r.SessionId == sessionID {
+			return StatusError(r.Status)
+		}
+	}
+	return ErrDeliveryFailed
 }
 
 // Broadcast sends a message to multiple sessions
-func (c *Client) Broadcast(ctx context.Context, sessionIDs []string, payload []byte) error {
-	_, err := c.cli.SendMessage(ctx, &connectorRPC.SendMessageReq{
+// Returns the delivery status of each session, keyed by session ID
+func (c *Client) Broadcast(ctx context.Context, sessionIDs []string, payload []byte) (map[string]connectorRPC.DeliveryStatus, error) {
+	resp, err := c.cli.SendMessage(ctx, &connectorRPC.SendMessageReq{
 		SessionIds: sessionIDs,
 		Payload:    payload,
 	})
-	return err
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]connectorRPC.DeliveryStatus, len(resp.Results))
+	for _, r := range resp.Results {
+		results[r.SessionId] = r.Status
+	}
+	return results, nil
+}
+
+// StatusError converts a delivery status into the matching SDK error (nil when delivered)
+func StatusError(status connectorRPC.DeliveryStatus) error {
+	switch status {
+	case connectorRPC.DeliveryStatus_NOT_FOUND:
+		return ErrSessionNotFound
+	case connectorRPC.DeliveryStatus_QUEUE_FULL:
+		return ErrSendQueueFull
+	case connectorRPC.DeliveryStatus_DELIVERED:
+		return nil
+	default:
+		return ErrDeliveryFailed
+	}
 }
 
 // ForceKick kicks a player with a reason
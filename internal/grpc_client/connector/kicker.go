@@ -0,0 +1,27 @@
+package connector_sdk
+
+import (
+	"context"
+
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+)
+
+// Kicker kicks sessions on any connector through a shared connection pool
+// It implements ports.SessionKicker
+type Kicker struct {
+	pool *grpcpkg.Pool
+}
+
+// NewKicker creates a Kicker using the given connection pool
+func NewKicker(pool *grpcpkg.Pool) *Kicker {
+	return &Kicker{pool: pool}
+}
+
+// Kick kicks a session on the connector at connectorHost
+func (k *Kicker) Kick(ctx context.Context, connectorHost, sessionID, reason string) error {
+	conn, err := k.pool.GetConnection(connectorHost)
+	if err != nil {
+		return err
+	}
+	return NewClient(conn).ForceKick(ctx, sessionID, reason)
+}
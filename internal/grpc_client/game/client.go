@@ -9,6 +9,7 @@ import (
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
 )
 
 // Client wraps gameRPC.GameRPCClient
@@ -26,7 +27,7 @@ func NewClient(cc grpc.ClientConnInterface) *Client {
 // Join sends OnPlayerJoin request to Game Server
 func (c *Client) Join(ctx context.Context, userID, sessionID, connectorHost string) (*gameRPC.JoinResp, error) {
 	return c.cli.OnPlayerJoin(ctx, &gameRPC.JoinReq{
-		Header:        c.newHeader(userID, sessionID),
+		Header:        newHeader(ctx, userID, sessionID),
 		ConnectorHost: connectorHost,
 	})
 }
@@ -34,24 +35,54 @@ func (c *Client) Join(ctx context.Context, userID, sessionID, connectorHost stri
 // Quit sends OnPlayerQuit request to Game Server
 func (c *Client) Quit(ctx context.Context, userID, sessionID string) (*gameRPC.QuitResp, error) {
 	return c.cli.OnPlayerQuit(ctx, &gameRPC.QuitReq{
-		Header: c.newHeader(userID, sessionID),
+		Header: newHeader(ctx, userID, sessionID),
 	})
 }
 
-// SendMessage sends a message (payload) to Game Server
-func (c *Client) SendMessage(ctx context.Context, userID, sessionID string, payload []byte) (*gameRPC.MsgResp, error) {
+// Reconnect sends OnPlayerReconnect request to Game Server
+func (c *Client) Reconnect(ctx context.Context, userID, sessionID, oldSessionID, connectorHost string) (*gameRPC.ReconnectResp, error) {
+	return c.cli.OnPlayerReconnect(ctx, &gameRPC.ReconnectReq{
+		Header:        newHeader(ctx, userID, sessionID),
+		ConnectorHost: connectorHost,
+		OldSessionId:  oldSessionID,
+	})
+}
+
+// SendMessage sends a client action and its payload (without the client envelope) to Game Server
+func (c *Client) SendMessage(ctx context.Context, userID, sessionID, action string, payload []byte) (*gameRPC.MsgResp, error) {
 	return c.cli.OnMessage(ctx, &gameRPC.MsgReq{
-		Header:  c.newHeader(userID, sessionID),
+		Header:  newHeader(ctx, userID, sessionID),
+		Action:  action,
 		Payload: payload,
 	})
 }
 
+// Drain puts the Game Server into drain mode (deadlineSec 0 uses the server default)
+// A non-SUCCESS code is returned as an error
+func (c *Client) Drain(ctx context.Context, deadlineSec int32) (*gameRPC.DrainResp, error) {
+	resp, err := c.cli.Drain(ctx, &gameRPC.DrainReq{DeadlineSec: deadlineSec})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != proto.ErrorCode_SUCCESS {
+		return resp, fmt.Errorf("drain failed: %s (%s)", resp.Code, resp.ErrorMessage)
+	}
+	return resp, nil
+}
+
 // newHeader creates a new packet header with current timestamp
-func (c *Client) newHeader(userID, sessionID string) *proto.PacketHeader {
+// The trace context of ctx is carried in the header (streams have no per-message gRPC metadata),
+// and its trace ID doubles as the request ID so logs can be joined with traces
+func newHeader(ctx context.Context, userID, sessionID string) *proto.PacketHeader {
+	reqID := tracing.TraceID(ctx)
+	if reqID == "" {
+		reqID = fmt.Sprintf("%d", time.Now().UnixNano())
+	}
 	return &proto.PacketHeader{
-		ReqId:     fmt.Sprintf("%d", time.Now().UnixNano()),
-		UserId:    userID,
-		SessionId: sessionID,
-		Timestamp: time.Now().UnixMilli(),
+		ReqId:        reqID,
+		UserId:       userID,
+		SessionId:    sessionID,
+		Timestamp:    time.Now().UnixMilli(),
+		TraceContext: tracing.Inject(ctx),
 	}
 }
@@ -0,0 +1,28 @@
+package game_sdk
+
+import (
+	"context"
+
+	grpcpkg "github.com/JoeShih716/go-k8s-game-server/pkg/grpc"
+)
+
+// Drainer puts game servers into drain mode through a shared connection pool
+// It implements ports.ServiceDrainer
+type Drainer struct {
+	pool *grpcpkg.Pool
+}
+
+// NewDrainer creates a Drainer using the given connection pool
+func NewDrainer(pool *grpcpkg.Pool) *Drainer {
+	return &Drainer{pool: pool}
+}
+
+// Drain puts the game server at endpoint into drain mode using its default deadline
+func (d *Drainer) Drain(ctx context.Context, endpoint string) error {
+	conn, err := d.pool.GetConnection(endpoint)
+	if err != nil {
+		return err
+	}
+	_, err = NewClient(conn).Drain(ctx, 0)
+	return err
+}
@@ -0,0 +1,208 @@
+package game_sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/connectorRPC"
+	"github.com/JoeShih716/go-k8s-game-server/api/proto/gameRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+)
+
+var (
+	// ErrStreamClosed the stream is closed and the message was NOT sent (safe to fall back to unary)
+	ErrStreamClosed = errors.New("game: stream closed")
+	// ErrStreamReplyLost the message was sent but the stream closed before the reply arrived
+	ErrStreamReplyLost = errors.New("game: stream closed before reply")
+)
+
+// PushHandler delivers a downstream push to local sessions and returns per-session results
+// ctx carries the trace context of the game server that sent the push
+// It is called from the stream's receive loop, so it must not block
+type PushHandler func(ctx context.Context, sessionIDs []string, payload []byte) []*connectorRPC.DeliveryResult
+
+// Stream is the connector side of GameRPC.Channel
+// One Stream multiplexes every session between this connector and a game server
+type Stream struct {
+	stream gameRPC.GameRPC_ChannelClient
+	cancel context.CancelFunc
+	onPush PushHandler
+
+	sendMu sync.Mutex // grpc streams do not allow concurrent Send
+
+	mu      sync.Mutex
+	pending map[uint64]chan *gameRPC.MsgResp
+	seq     atomic.Uint64
+
+	done chan struct{}
+}
+
+// OpenStream opens a Channel stream and announces connectorHost to the game server
+// It waits for the server's ready frame (bounded by ctx), so servers without Channel support fail here
+func OpenStream(ctx context.Context, cc grpc.ClientConnInterface, connectorHost string, onPush PushHandler) (*Stream, error) {
+	// The stream outlives any single request, so it gets its own context
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream, err := gameRPC.NewGameRPCClient(cc).Channel(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s := &Stream{
+		stream:  stream,
+		cancel:  cancel,
+		onPush:  onPush,
+		pending: make(map[uint64]chan *gameRPC.MsgResp),
+		done:    make(chan struct{}),
+	}
+	if err := s.send(&gameRPC.ChannelUp{Frame: &gameRPC.ChannelUp_Hello{
+		Hello: &gameRPC.ChannelHello{ConnectorHost: connectorHost},
+	}}); err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := s.awaitReady(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go s.recvLoop()
+	return s, nil
+}
+
+// SendMessage sends a player message over the stream and waits for the reply
+// Returns ErrStreamClosed if the stream was already closed before sending
+func (s *Stream) SendMessage(ctx context.Context, userID, sessionID, action string, payload []byte) (resp *gameRPC.MsgResp, err error) {
+	ctx, span := tracing.Start(ctx, "GameRPC.Channel/Message",
+		tracing.AttrUserID.String(userID),
+		tracing.AttrSessionID.String(sessionID),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	seq := s.seq.Add(1)
+	ch := make(chan *gameRPC.MsgResp, 1)
+
+	s.mu.Lock()
+	if s.pending == nil {
+		s.mu.Unlock()
+		return nil, ErrStreamClosed
+	}
+	s.pending[seq] = ch
+	s.mu.Unlock()
+	defer s.forget(seq)
+
+	err = s.send(&gameRPC.ChannelUp{Frame: &gameRPC.ChannelUp_Message{
+		Message: &gameRPC.ChannelMessage{
+			Seq: seq,
+			Req: &gameRPC.MsgReq{
+				Header:  newHeader(ctx, userID, sessionID),
+				Action:  action,
+				Payload: payload,
+			},
+		},
+	}})
+	if err != nil {
+		return nil, ErrStreamClosed
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, ErrStreamReplyLost
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Done is closed when the stream ends
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close terminates the stream
+func (s *Stream) Close() {
+	s.cancel()
+}
+
+// awaitReady waits for the first downstream frame, which must be ready
+func (s *Stream) awaitReady(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		frame, err := s.stream.Recv()
+		if err == nil && frame.GetReady() == nil {
+			err = errors.New("game: unexpected first channel frame")
+		}
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		s.cancel() // unblocks Recv
+		return ctx.Err()
+	}
+}
+
+// recvLoop dispatches downstream frames until the stream ends
+// Pushes are delivered inline, which keeps them in order
+func (s *Stream) recvLoop() {
+	defer s.shutdown()
+	for {
+		frame, err := s.stream.Recv()
+		if err != nil {
+			return
+		}
+
+		switch f := frame.Frame.(type) {
+		case *gameRPC.ChannelDown_Reply:
+			s.mu.Lock()
+			ch := s.pending[f.Reply.Seq]
+			s.mu.Unlock()
+			if ch != nil {
+				ch <- f.Reply.Resp
+			}
+		case *gameRPC.ChannelDown_Push:
+			var results []*connectorRPC.DeliveryResult
+			if s.onPush != nil {
+				ctx := tracing.Extract(context.Background(), f.Push.TraceContext)
+				results = s.onPush(ctx, f.Push.SessionIds, f.Push.Payload)
+			}
+			_ = s.send(&gameRPC.ChannelUp{Frame: &gameRPC.ChannelUp_PushAck{
+				PushAck: &gameRPC.ChannelPushAck{PushId: f.Push.PushId, Results: results},
+			}})
+		}
+	}
+}
+
+// shutdown fails every pending request and marks the stream as closed
+func (s *Stream) shutdown() {
+	s.cancel()
+	s.mu.Lock()
+	for _, ch := range s.pending {
+		close(ch)
+	}
+	s.pending = nil
+	s.mu.Unlock()
+	close(s.done)
+}
+
+func (s *Stream) forget(seq uint64) {
+	s.mu.Lock()
+	if s.pending != nil {
+		delete(s.pending, seq)
+	}
+	s.mu.Unlock()
+}
+
+func (s *Stream) send(frame *gameRPC.ChannelUp) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.stream.Send(frame)
+}
@@ -0,0 +1,164 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+// 支援的簽章演算法
+const (
+	AlgorithmHS256 = "HS256" // HMAC + SHA256 (共享金鑰)
+	AlgorithmRS256 = "RS256" // RSA + SHA256 (公鑰驗證，支援 JWKS)
+)
+
+// DefaultNameClaim 預設的暱稱 Claim
+const DefaultNameClaim = "name"
+
+// Verifier 使用 JWT 實作 ports.TokenVerifier
+//
+// Claims 對應規則:
+//   - sub -> User.ID (必填)
+//   - name (可透過 WithNameClaim 調整) -> User.Name，未提供則使用 sub
+//
+// exp 為必填，nbf 若存在則一併檢查。
+type Verifier struct {
+	algorithm string
+	keyFunc   gojwt.Keyfunc
+	nameClaim string
+	issuer    string
+	audience  string
+	leeway    time.Duration
+}
+
+var _ ports.TokenVerifier = (*Verifier)(nil)
+
+// Option 定義了 Verifier 的配置選項函數
+type Option func(*Verifier)
+
+// WithIssuer 要求 iss 必須符合
+func WithIssuer(issuer string) Option {
+	return func(v *Verifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithAudience 要求 aud 必須包含指定值
+func WithAudience(audience string) Option {
+	return func(v *Verifier) {
+		v.audience = audience
+	}
+}
+
+// WithLeeway 設定 exp / nbf 容許的時鐘誤差
+func WithLeeway(leeway time.Duration) Option {
+	return func(v *Verifier) {
+		v.leeway = leeway
+	}
+}
+
+// WithNameClaim 設定對應到 User.Name 的 Claim 名稱
+func WithNameClaim(claim string) Option {
+	return func(v *Verifier) {
+		if claim != "" {
+			v.nameClaim = claim
+		}
+	}
+}
+
+// NewHS256Verifier 建立使用共享金鑰 (HMAC) 驗證的 Verifier
+func NewHS256Verifier(secret []byte, opts ...Option) (*Verifier, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("jwt: HS256 secret is empty")
+	}
+	keyFunc := func(_ *gojwt.Token) (any, error) {
+		return secret, nil
+	}
+	return newVerifier(AlgorithmHS256, keyFunc, opts...), nil
+}
+
+// NewRS256Verifier 建立使用 RSA 公鑰驗證的 Verifier
+// keys 為 kid -> 公鑰，Token Header 帶有 kid 時依 kid 選擇公鑰；
+// 未帶 kid 時僅在只有一把公鑰的情況下使用該公鑰。
+func NewRS256Verifier(keys map[string]*rsa.PublicKey, opts ...Option) (*Verifier, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("jwt: RS256 requires at least one public key")
+	}
+	keyFunc := func(token *gojwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return newVerifier(AlgorithmRS256, keyFunc, opts...), nil
+}
+
+func newVerifier(algorithm string, keyFunc gojwt.Keyfunc, opts ...Option) *Verifier {
+	v := &Verifier{
+		algorithm: algorithm,
+		keyFunc:   keyFunc,
+		nameClaim: DefaultNameClaim,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Verify implements ports.TokenVerifier.
+func (v *Verifier) Verify(_ context.Context, token string) (*domain.User, error) {
+	parserOpts := []gojwt.ParserOption{
+		gojwt.WithValidMethods([]string{v.algorithm}),
+		gojwt.WithExpirationRequired(),
+		gojwt.WithLeeway(v.leeway),
+	}
+	if v.issuer != "" {
+		parserOpts = append(parserOpts, gojwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		parserOpts = append(parserOpts, gojwt.WithAudience(v.audience))
+	}
+
+	claims := gojwt.MapClaims{}
+	if _, err := gojwt.ParseWithClaims(token, claims, v.keyFunc, parserOpts...); err != nil {
+		return nil, mapError(err)
+	}
+
+	sub, err := claims.GetSubject()
+	if err != nil || sub == "" {
+		return nil, fmt.Errorf("%w: missing sub claim", ports.ErrInvalidToken)
+	}
+
+	name, _ := claims[v.nameClaim].(string)
+	if name == "" {
+		name = sub
+	}
+	return domain.NewUser(sub, name), nil
+}
+
+// mapError 將 JWT 函式庫的錯誤轉換為 ports 層級的錯誤
+func mapError(err error) error {
+	switch {
+	case errors.Is(err, gojwt.ErrTokenMalformed):
+		return ports.ErrTokenMalformed
+	case errors.Is(err, gojwt.ErrTokenExpired):
+		return ports.ErrTokenExpired
+	case errors.Is(err, gojwt.ErrTokenNotValidYet), errors.Is(err, gojwt.ErrTokenUsedBeforeIssued):
+		return ports.ErrTokenNotValidYet
+	default:
+		return fmt.Errorf("%w: %v", ports.ErrInvalidToken, err)
+	}
+}
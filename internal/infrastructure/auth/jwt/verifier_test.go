@@ -0,0 +1,132 @@
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+var testSecret = []byte("test-secret")
+
+func signHS256(t *testing.T, claims gojwt.MapClaims) string {
+	t.Helper()
+	token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString(testSecret)
+	require.NoError(t, err)
+	return token
+}
+
+func TestVerifier_HS256_Success(t *testing.T) {
+	v, err := NewHS256Verifier(testSecret, WithIssuer("auth.example.com"))
+	require.NoError(t, err)
+
+	token := signHS256(t, gojwt.MapClaims{
+		"sub":  "user-100",
+		"name": "Alice",
+		"iss":  "auth.example.com",
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	})
+
+	user, err := v.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-100", user.ID)
+	assert.Equal(t, "Alice", user.Name)
+}
+
+func TestVerifier_HS256_Errors(t *testing.T) {
+	v, err := NewHS256Verifier(testSecret, WithIssuer("auth.example.com"))
+	require.NoError(t, err)
+
+	valid := gojwt.MapClaims{
+		"sub": "user-100",
+		"iss": "auth.example.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	with := func(key string, value any) gojwt.MapClaims {
+		c := gojwt.MapClaims{}
+		for k, v := range valid {
+			c[k] = v
+		}
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return c
+	}
+
+	wrongKey, _ := gojwt.NewWithClaims(gojwt.SigningMethodHS256, valid).SignedString([]byte("other-secret"))
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"malformed", "guest-token", ports.ErrTokenMalformed},
+		{"expired", signHS256(t, with("exp", time.Now().Add(-time.Minute).Unix())), ports.ErrTokenExpired},
+		{"not before", signHS256(t, with("nbf", time.Now().Add(time.Hour).Unix())), ports.ErrTokenNotValidYet},
+		{"missing exp", signHS256(t, with("exp", nil)), ports.ErrInvalidToken},
+		{"missing sub", signHS256(t, with("sub", nil)), ports.ErrInvalidToken},
+		{"wrong issuer", signHS256(t, with("iss", "evil.example.com")), ports.ErrInvalidToken},
+		{"wrong signature", wrongKey, ports.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(context.Background(), tt.token)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestVerifier_RS256_JWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwksJSON := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"k1","use":"sig","alg":"RS256","n":%q,"e":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	)
+	keys, err := ParseJWKS([]byte(jwksJSON))
+	require.NoError(t, err)
+
+	v, err := NewRS256Verifier(keys, WithNameClaim("nickname"))
+	require.NoError(t, err)
+
+	sign := func(kid string) string {
+		token := gojwt.NewWithClaims(gojwt.SigningMethodRS256, gojwt.MapClaims{
+			"sub":      "user-200",
+			"nickname": "Bob",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	user, err := v.Verify(context.Background(), sign("k1"))
+	require.NoError(t, err)
+	assert.Equal(t, "user-200", user.ID)
+	assert.Equal(t, "Bob", user.Name)
+
+	// Unknown kid
+	_, err = v.Verify(context.Background(), sign("k2"))
+	assert.ErrorIs(t, err, ports.ErrInvalidToken)
+
+	// HS256 token must be rejected by an RS256 verifier (algorithm confusion)
+	_, err = v.Verify(context.Background(), signHS256(t, gojwt.MapClaims{
+		"sub": "user-200",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}))
+	assert.ErrorIs(t, err, ports.ErrInvalidToken)
+}
@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/redis"
+)
+
+const (
+	// Key Pattern: maintenance:{GameID} -> Maintenance (JSON，GameID 0 為全服維護)
+	// 有結束時間的排程於結束後自動過期，未設定結束時間則需手動結束
+	KeyMaintenance = "maintenance:%d"
+)
+
+// Store 使用 Redis 實作 ports.MaintenanceStore
+type Store struct {
+	rds *redis.Client
+}
+
+var _ ports.MaintenanceStore = (*Store)(nil)
+
+func NewStore(client *redis.Client) *Store {
+	return &Store{rds: client}
+}
+
+// Set implements ports.MaintenanceStore.
+func (s *Store) Set(ctx context.Context, maintenance *domain.Maintenance) error {
+	now := time.Now()
+	maintenance.UpdatedAt = now.Unix()
+	var expiration []time.Duration
+	if maintenance.EndAt > 0 {
+		ttl := time.Unix(maintenance.EndAt, 0).Sub(now)
+		if ttl <= 0 {
+			return fmt.Errorf("maintenance already ended at %d", maintenance.EndAt)
+		}
+		expiration = append(expiration, ttl)
+	}
+	if err := s.rds.SetStruct(ctx, fmt.Sprintf(KeyMaintenance, maintenance.GameID), maintenance, expiration...); err != nil {
+		return fmt.Errorf("failed to save maintenance: %w", err)
+	}
+	return nil
+}
+
+// Delete implements ports.MaintenanceStore.
+func (s *Store) Delete(ctx context.Context, gameID int32) error {
+	return s.rds.Del(ctx, fmt.Sprintf(KeyMaintenance, gameID))
+}
+
+// Get implements ports.MaintenanceStore.
+func (s *Store) Get(ctx context.Context, gameID int32) (*domain.Maintenance, error) {
+	val, err := s.rds.Get(ctx, fmt.Sprintf(KeyMaintenance, gameID))
+	if err != nil {
+		if redis.IsNil(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var maintenance domain.Maintenance
+	if err := json.Unmarshal([]byte(val), &maintenance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal maintenance: %w", err)
+	}
+	return &maintenance, nil
+}
+
+// List implements ports.MaintenanceStore.
+func (s *Store) List(ctx context.Context) ([]*domain.Maintenance, error) {
+	keys, err := s.rds.Keys(ctx, "maintenance:*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan maintenance: %w", err)
+	}
+	list := make([]*domain.Maintenance, 0, len(keys))
+	for _, key := range keys {
+		var maintenance domain.Maintenance
+		if err := s.rds.GetStruct(ctx, key, &maintenance); err == nil {
+			list = append(list, &maintenance)
+		}
+	}
+	return list, nil
+}
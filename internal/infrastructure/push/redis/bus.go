@@ -0,0 +1,113 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/redis"
+)
+
+const (
+	// ChannelBroadcast 全服廣播頻道 (所有 Connector 皆訂閱)
+	ChannelBroadcast = "push:all"
+	// Channel Pattern: push:user:{UserID} (僅玩家所在的 Connector 訂閱)
+	ChannelUser = "push:user:%s"
+)
+
+// ErrNotListening 尚未呼叫 Listen
+var ErrNotListening = errors.New("push bus: not listening")
+
+// Bus 使用 Redis Pub/Sub 實作 ports.PushBus
+type Bus struct {
+	rds *redis.Client
+
+	mu  sync.Mutex
+	sub *redis.Subscription
+}
+
+var _ ports.PushBus = (*Bus)(nil)
+
+func NewBus(client *redis.Client) *Bus {
+	return &Bus{rds: client}
+}
+
+// Publish implements ports.PushBus.
+func (b *Bus) Publish(ctx context.Context, msg *domain.PushMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal push message: %w", err)
+	}
+	channel := ChannelBroadcast
+	if msg.UserID != "" {
+		channel = fmt.Sprintf(ChannelUser, msg.UserID)
+	}
+	return b.rds.Publish(ctx, channel, data)
+}
+
+// Listen implements ports.PushBus.
+func (b *Bus) Listen(ctx context.Context, handler ports.PushHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.sub != nil {
+		return errors.New("push bus: already listening")
+	}
+
+	sub, err := b.rds.SubscribeChannels(ctx, func(channel, payload string) {
+		var msg domain.PushMessage
+		if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+			slog.Warn("Invalid push message", "channel", channel, "error", err)
+			return
+		}
+		handler(&msg)
+	}, ChannelBroadcast)
+	if err != nil {
+		return err
+	}
+	b.sub = sub
+	return nil
+}
+
+// Watch implements ports.PushBus.
+func (b *Bus) Watch(ctx context.Context, userID string) error {
+	sub, err := b.subscription()
+	if err != nil {
+		return err
+	}
+	return sub.Add(ctx, fmt.Sprintf(ChannelUser, userID))
+}
+
+// Unwatch implements ports.PushBus.
+func (b *Bus) Unwatch(ctx context.Context, userID string) error {
+	sub, err := b.subscription()
+	if err != nil {
+		return err
+	}
+	return sub.Remove(ctx, fmt.Sprintf(ChannelUser, userID))
+}
+
+// Close implements ports.PushBus.
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.sub == nil {
+		return nil
+	}
+	err := b.sub.Close()
+	b.sub = nil
+	return err
+}
+
+func (b *Bus) subscription() (*redis.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.sub == nil {
+		return nil, ErrNotListening
+	}
+	return b.sub, nil
+}
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
@@ -13,6 +14,7 @@ type DBName string
 const (
 	DBNameUser    DBName = "user"
 	DBNameCentral DBName = "central"
+	DBNameSession DBName = "session" // 跨 Connector 共享的會話資料 (Resume...)
 	// Future: DBNameGame, DBNameJackpot...
 )
 
@@ -20,6 +22,7 @@ const (
 type DBSupplier interface {
 	GetUser() *pkgRedis.Client
 	GetCentral() *pkgRedis.Client
+	GetSession() *pkgRedis.Client
 	Close() error
 }
 
@@ -70,6 +73,25 @@ func (p *Provider) GetCentral() *pkgRedis.Client {
 	return nil
 }
 
+// GetSession 取得 Session DB，未設定時回傳 nil (呼叫端應視為功能停用)
+func (p *Provider) GetSession() *pkgRedis.Client {
+	if client, ok := p.databases[DBNameSession]; ok {
+		return client
+	}
+	slog.Warn("Redis Session DB not found in config")
+	return nil
+}
+
+// Ping 檢查所有已設定的 Redis DB 是否可用 (用於健康檢查)
+func (p *Provider) Ping(ctx context.Context) error {
+	for name, client := range p.databases {
+		if err := client.Ping(ctx); err != nil {
+			return fmt.Errorf("redis db '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
 func (p *Provider) Close() error {
 	for _, client := range p.databases {
 		client.Close()
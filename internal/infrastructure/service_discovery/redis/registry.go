@@ -11,12 +11,33 @@ import (
 
 	"github.com/JoeShih716/go-k8s-game-server/api/proto"
 	"github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 	"github.com/JoeShih716/go-k8s-game-server/pkg/redis"
 )
 
 // Registry 負責管理所有活躍的遊戲服務
 type Registry struct {
-	rds *redis.Client
+	rds             *redis.Client
+	defaultStrategy SelectionStrategy
+	gameStrategies  map[int32]SelectionStrategy
+}
+
+// RegistryOption 定義了 Registry 的配置選項函數
+type RegistryOption func(*Registry)
+
+// WithDefaultStrategy 設定未指定策略的遊戲所使用的預設負載均衡策略
+func WithDefaultStrategy(strategy SelectionStrategy) RegistryOption {
+	return func(r *Registry) {
+		r.defaultStrategy = strategy
+	}
+}
+
+// WithGameStrategy 為特定 GameID 設定負載均衡策略
+func WithGameStrategy(gameID int32, strategy SelectionStrategy) RegistryOption {
+	return func(r *Registry) {
+		r.gameStrategies[gameID] = strategy
+	}
 }
 
 const (
@@ -26,19 +47,38 @@ const (
 	KeyLease = "services:lease:%s"
 	// Key Pattern: game:{GameID} -> Set of Endpoints
 	KeyGameSet = "game:%d"
+	// Key Pattern: services:load:{Endpoint} -> 最近一次心跳回報的負載 (與 Lease 同 TTL)
+	KeyLoad = "services:load:%s"
+	// Key Pattern: services:version:{Endpoint} -> 實例版本 (與 Lease 同 TTL，用於版本路由)
+	KeyVersion = "services:version:%s"
+	// Key: services:draining -> Set of Endpoints (排空中，不再分配新玩家；失效的 Endpoint 由 CleanupDeadServices 清除)
+	KeyDraining = "services:draining"
 
 	DefaultTTL = 10 * time.Second
 )
 
 // LeaseData 儲存於 Redis 的租約資訊
 type LeaseData struct {
+	ServiceName string            `json:"service_name"`
 	Endpoint    string            `json:"endpoint"`
 	ServiceType proto.ServiceType `json:"service_type"`
 	GameIDs     []int32           `json:"game_ids"`
+	Version     string            `json:"version,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
 }
 
-func NewRedisRegistry(rds *redis.Client) *Registry {
-	return &Registry{rds: rds}
+var _ ports.RegistryService = (*Registry)(nil)
+
+func NewRedisRegistry(rds *redis.Client, opts ...RegistryOption) *Registry {
+	r := &Registry{
+		rds:             rds,
+		defaultStrategy: SelectionStrategyFunc(selectRandom),
+		gameStrategies:  make(map[int32]SelectionStrategy),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Register 註冊一個新服務
@@ -48,9 +88,12 @@ func (r *Registry) Register(ctx context.Context, req *centralRPC.RegisterRequest
 	// 1. 儲存 Lease Metadata (包含 Endpoint 與 ServiceType)
 	leaseKey := fmt.Sprintf(KeyLease, leaseID)
 	data := &LeaseData{
+		ServiceName: req.ServiceName,
 		Endpoint:    req.Endpoint,
 		ServiceType: req.Type,
 		GameIDs:     req.GameIds,
+		Version:     req.Version,
+		Labels:      req.Labels,
 	}
 
 	err := r.rds.SetStruct(ctx, leaseKey, data, DefaultTTL)
@@ -58,6 +101,18 @@ func (r *Registry) Register(ctx context.Context, req *centralRPC.RegisterRequest
 		return "", fmt.Errorf("failed to set lease: %w", err)
 	}
 
+	// 1.1 初始化負載 (新實例尚未回報心跳，視為 0)
+	loadKey := fmt.Sprintf(KeyLoad, req.Endpoint)
+	if err := r.rds.Set(ctx, loadKey, 0, DefaultTTL); err != nil {
+		return "", fmt.Errorf("failed to set load: %w", err)
+	}
+
+	// 1.2 記錄版本 (挑選實例時與負載一併批次讀取)
+	versionKey := fmt.Sprintf(KeyVersion, req.Endpoint)
+	if err := r.rds.Set(ctx, versionKey, req.Version, DefaultTTL); err != nil {
+		return "", fmt.Errorf("failed to set version: %w", err)
+	}
+
 	// 2. 加入 Service Type 的集合 (方便做 Discovery)
 	setKey := fmt.Sprintf(KeyServiceSet, req.Type.String())
 	err = r.rds.SAdd(ctx, setKey, req.Endpoint)
@@ -81,22 +136,28 @@ func (r *Registry) Register(ctx context.Context, req *centralRPC.RegisterRequest
 	return leaseID, nil
 }
 
-// Heartbeat 更新 Lease TTL
+// Heartbeat 更新 Lease TTL 並記錄實例回報的負載
 func (r *Registry) Heartbeat(ctx context.Context, leaseID string, load int32) error {
 	leaseKey := fmt.Sprintf(KeyLease, leaseID)
 
-	// 檢查 Key 是否存在 (如果 Central 重啟，Redis SET 還在，就可以續命)
+	// 取得 Lease (如果 Central 重啟，Redis 資料還在，就可以續命)
 	// 如果 Key 不見了 (例如過期)，回傳錯誤讓 Client 重新註冊
-	exists, err := r.rds.Exists(ctx, leaseKey)
-	if err != nil {
-		return err
-	}
-	if !exists {
+	var data LeaseData
+	if err := r.rds.GetStruct(ctx, leaseKey, &data); err != nil {
 		return fmt.Errorf("lease not found")
 	}
 
 	// 續命
-	return r.rds.Expire(ctx, leaseKey, DefaultTTL)
+	if err := r.rds.Expire(ctx, leaseKey, DefaultTTL); err != nil {
+		return err
+	}
+
+	// 記錄負載與版本 (與 Lease 同 TTL，實例消失後自然過期)
+	loadKey := fmt.Sprintf(KeyLoad, data.Endpoint)
+	if err := r.rds.Set(ctx, loadKey, load, DefaultTTL); err != nil {
+		return err
+	}
+	return r.rds.Set(ctx, fmt.Sprintf(KeyVersion, data.Endpoint), data.Version, DefaultTTL)
 }
 
 // Deregister 主動移除服務
@@ -111,8 +172,9 @@ func (r *Registry) Deregister(ctx context.Context, leaseID string) error {
 		return nil
 	}
 
-	// 2. 刪除 Lease
-	_ = r.rds.Del(ctx, leaseKey)
+	// 2. 刪除 Lease、負載與版本紀錄
+	_ = r.rds.Del(ctx, leaseKey, fmt.Sprintf(KeyLoad, data.Endpoint), fmt.Sprintf(KeyVersion, data.Endpoint))
+	_ = r.rds.SRem(ctx, KeyDraining, data.Endpoint)
 
 	// 3. 從 Set 移除 Endpoint
 	setKey := fmt.Sprintf(KeyServiceSet, data.ServiceType.String())
@@ -147,17 +209,16 @@ func (r *Registry) SelectService(ctx context.Context, serviceType proto.ServiceT
 	return res, nil
 }
 
-// SelectServiceByGame 根據 GameID 隨機挑選一個服務實例
-func (r *Registry) SelectServiceByGame(ctx context.Context, gameID int32) (string, proto.ServiceType, error) {
-	key := fmt.Sprintf(KeyGameSet, gameID)
-
-	// 1. 隨機取出一個 Endpoint
-	res, err := r.rds.SRandMember(ctx, key)
+// SelectServiceByGame 根據 GameID 與該遊戲的負載均衡策略挑選一個服務實例
+// 僅從符合 selector 的版本中挑選，沒有符合的實例時退回不限版本
+func (r *Registry) SelectServiceByGame(ctx context.Context, gameID int32, selector domain.VersionSelector) (*ports.Route, error) {
+	// 1. 依版本與策略挑選 Endpoint
+	res, version, err := r.selectEndpoint(ctx, gameID, selector)
 	if err != nil {
-		if redis.IsNil(err) {
-			return "", proto.ServiceType_UNKNOWN_SERVICE, nil // Not found
-		}
-		return "", proto.ServiceType_UNKNOWN_SERVICE, err
+		return nil, err
+	}
+	if res == "" {
+		return &ports.Route{}, nil // Not found
 	}
 
 	// 2. 取得 ServiceType
@@ -168,7 +229,112 @@ func (r *Registry) SelectServiceByGame(ctx context.Context, gameID int32) (strin
 		_, _ = fmt.Sscanf(val, "%d", &sType)
 	}
 
-	return res, proto.ServiceType(sType), nil
+	return &ports.Route{Endpoint: res, ServiceType: proto.ServiceType(sType), Version: version}, nil
+}
+
+// selectEndpoint 取出該遊戲所有 Endpoint 與其負載、版本，篩選版本後交由策略挑選
+// 回傳空字串代表沒有可用實例
+func (r *Registry) selectEndpoint(ctx context.Context, gameID int32, selector domain.VersionSelector) (string, string, error) {
+	key := fmt.Sprintf(KeyGameSet, gameID)
+
+	strategy, ok := r.gameStrategies[gameID]
+	if !ok {
+		strategy = r.defaultStrategy
+	}
+
+	members, err := r.rds.SMembers(ctx, key)
+	if err != nil {
+		if redis.IsNil(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	if len(members) == 0 {
+		return "", "", nil
+	}
+
+	// 批次取得負載與版本
+	keys := make([]string, 0, len(members)*2)
+	for _, m := range members {
+		keys = append(keys, fmt.Sprintf(KeyLoad, m))
+	}
+	for _, m := range members {
+		keys = append(keys, fmt.Sprintf(KeyVersion, m))
+	}
+	values, err := r.rds.MGet(ctx, keys...)
+	if err != nil {
+		return "", "", err
+	}
+	loads, versions := values[:len(members)], values[len(members):]
+
+	draining, err := r.drainingSet(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	// 排空中的實例不再分配新玩家，不列入候選
+	var all []instance
+	for i, m := range members {
+		if draining[m] {
+			continue
+		}
+		inst := instance{endpoint: m}
+		inst.version, _ = versions[i].(string)
+		if s, ok := loads[i].(string); ok {
+			inst.hasLoad = true
+			_, _ = fmt.Sscanf(s, "%d", &inst.load)
+		}
+		all = append(all, inst)
+	}
+	matched := filterByVersion(all, selector)
+	if len(matched) == 0 {
+		return "", "", nil
+	}
+
+	// 負載紀錄已過期的實例視為失聯 (等待 CleanupDeadServices 清除)，不列入候選
+	candidates := make([]Candidate, 0, len(matched))
+	fallback := make([]Candidate, 0, len(matched))
+	versionOf := make(map[string]string, len(matched))
+	for _, inst := range matched {
+		versionOf[inst.endpoint] = inst.version
+		fallback = append(fallback, Candidate{Endpoint: inst.endpoint})
+		if inst.hasLoad {
+			candidates = append(candidates, Candidate{Endpoint: inst.endpoint, Load: inst.load})
+		}
+	}
+
+	// 若全部都沒有負載紀錄 (例如舊版實例)，退回隨機挑選
+	if len(candidates) == 0 {
+		endpoint := selectRandom(fallback)
+		return endpoint, versionOf[endpoint], nil
+	}
+
+	endpoint := strategy.Select(candidates)
+	return endpoint, versionOf[endpoint], nil
+}
+
+// filterByVersion 篩選符合版本的實例
+// 沒有符合版本的實例時 (ex: Canary 實例全數下線) 退回不限版本，避免玩家無法進入遊戲；
+// 但已分配版本的會話 (Pinned) 不退回，以免被分配到不同版本
+func filterByVersion(all []instance, selector domain.VersionSelector) []instance {
+	var matched []instance
+	for _, inst := range all {
+		if selector.Match(inst.version) {
+			matched = append(matched, inst)
+		}
+	}
+	if len(matched) == 0 && !selector.Pinned {
+		return all
+	}
+	return matched
+}
+
+// instance 挑選實例時的候選資訊
+type instance struct {
+	endpoint string
+	version  string
+	load     int32
+	hasLoad  bool
 }
 
 // CleanupDeadServices 清理無效的服務節點 (Zombie Endpoints)
@@ -195,8 +361,8 @@ func (r *Registry) CleanupDeadServices(ctx context.Context) error {
 		slog.Warn("Failed to scan service sets", "error", err)
 	} else {
 		for _, key := range serviceKeys {
-			// 排除 lease, metadata 等非 Set 的 Key
-			if strings.Contains(key, ":lease:") {
+			// 排除 lease, load, version 等非 Set 的 Key
+			if strings.Contains(key, ":lease:") || strings.Contains(key, ":load:") || strings.Contains(key, ":version:") {
 				continue
 			}
 
@@ -241,3 +407,79 @@ func (r *Registry) CleanupDeadServices(ctx context.Context) error {
 
 	return nil
 }
+
+// ListServices 列出所有有效租約的服務實例 (含負載與排空狀態)
+func (r *Registry) ListServices(ctx context.Context) ([]*domain.ServiceInstance, error) {
+	// 注意: 在生產環境請使用 Scan 代替 Keys 避免阻塞
+	leaseKeys, err := r.rds.Keys(ctx, "services:lease:*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan leases: %w", err)
+	}
+
+	draining, err := r.drainingSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]*domain.ServiceInstance, 0, len(leaseKeys))
+	for _, key := range leaseKeys {
+		var data LeaseData
+		if err := r.rds.GetStruct(ctx, key, &data); err != nil {
+			continue // 剛好過期
+		}
+		instances = append(instances, &domain.ServiceInstance{
+			LeaseID:     strings.TrimPrefix(key, "services:lease:"),
+			ServiceName: data.ServiceName,
+			ServiceType: data.ServiceType.String(),
+			Endpoint:    data.Endpoint,
+			GameIDs:     data.GameIDs,
+			Draining:    draining[data.Endpoint],
+			Version:     data.Version,
+			Labels:      data.Labels,
+		})
+	}
+	if len(instances) == 0 {
+		return instances, nil
+	}
+
+	// 批次取得負載
+	loadKeys := make([]string, len(instances))
+	for i, inst := range instances {
+		loadKeys[i] = fmt.Sprintf(KeyLoad, inst.Endpoint)
+	}
+	loads, err := r.rds.MGet(ctx, loadKeys...)
+	if err != nil {
+		return nil, err
+	}
+	for i, inst := range instances {
+		if s, ok := loads[i].(string); ok {
+			_, _ = fmt.Sscanf(s, "%d", &inst.Load)
+		}
+	}
+	return instances, nil
+}
+
+// SetDraining 設定服務實例是否排空中
+func (r *Registry) SetDraining(ctx context.Context, leaseID string, draining bool) error {
+	var data LeaseData
+	if err := r.rds.GetStruct(ctx, fmt.Sprintf(KeyLease, leaseID), &data); err != nil {
+		return ports.ErrLeaseNotFound
+	}
+	if draining {
+		return r.rds.SAdd(ctx, KeyDraining, data.Endpoint)
+	}
+	return r.rds.SRem(ctx, KeyDraining, data.Endpoint)
+}
+
+// drainingSet 取得排空中的 Endpoint 集合
+func (r *Registry) drainingSet(ctx context.Context) (map[string]bool, error) {
+	members, err := r.rds.SMembers(ctx, KeyDraining)
+	if err != nil && !redis.IsNil(err) {
+		return nil, err
+	}
+	set := make(map[string]bool, len(members))
+	for _, m := range members {
+		set[m] = true
+	}
+	return set, nil
+}
@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+func TestFilterByVersion(t *testing.T) {
+	all := []instance{{endpoint: "a", version: "v1"}, {endpoint: "b", version: "v1"}}
+
+	// 規則指定的版本沒有實例時退回所有實例
+	assert.Equal(t, all, filterByVersion(all, domain.VersionSelector{Version: "v2"}))
+	assert.Equal(t, all, filterByVersion(all, domain.VersionSelector{Exclude: []string{"v1"}}))
+
+	// 已分配版本的會話不退回其他版本
+	assert.Empty(t, filterByVersion(all, domain.VersionSelector{Version: "v2", Pinned: true}))
+	assert.Equal(t, all, filterByVersion(all, domain.VersionSelector{Version: "v1", Pinned: true}))
+}
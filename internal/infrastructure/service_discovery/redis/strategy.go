@@ -0,0 +1,105 @@
+package redis
+
+import (
+	"math/rand/v2"
+)
+
+// 內建的負載均衡策略名稱 (用於 Config)
+const (
+	StrategyRandom         = "random"          // 隨機 (預設，等同 SRANDMEMBER)
+	StrategyLeastLoaded    = "least_loaded"    // 最低負載優先
+	StrategyPowerOfTwo     = "p2c"             // Power of Two Choices: 隨機取兩個，選負載較低者
+	StrategyWeightedRandom = "weighted_random" // 依負載反比加權隨機
+)
+
+// Candidate 代表一個可被選擇的服務實例
+type Candidate struct {
+	Endpoint string // 服務地址
+	Load     int32  // 最近一次心跳回報的負載
+}
+
+// SelectionStrategy 定義從候選實例中挑選目標的策略
+// 實作需為 Thread-Safe，且 candidates 保證不為空
+type SelectionStrategy interface {
+	Select(candidates []Candidate) string
+}
+
+// SelectionStrategyFunc 讓一般函式可作為 SelectionStrategy 使用
+type SelectionStrategyFunc func(candidates []Candidate) string
+
+// Select 實作 SelectionStrategy
+func (f SelectionStrategyFunc) Select(candidates []Candidate) string {
+	return f(candidates)
+}
+
+// NewStrategy 根據名稱建立內建策略，未知名稱回傳 nil, false
+func NewStrategy(name string) (SelectionStrategy, bool) {
+	switch name {
+	case StrategyRandom:
+		return SelectionStrategyFunc(selectRandom), true
+	case StrategyLeastLoaded:
+		return SelectionStrategyFunc(selectLeastLoaded), true
+	case StrategyPowerOfTwo:
+		return SelectionStrategyFunc(selectPowerOfTwo), true
+	case StrategyWeightedRandom:
+		return SelectionStrategyFunc(selectWeightedRandom), true
+	default:
+		return nil, false
+	}
+}
+
+// selectRandom 隨機挑選
+func selectRandom(candidates []Candidate) string {
+	return candidates[rand.IntN(len(candidates))].Endpoint
+}
+
+// selectLeastLoaded 挑選負載最低者 (負載相同時取第一個)
+func selectLeastLoaded(candidates []Candidate) string {
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.Load < best.Load {
+			best = c
+		}
+	}
+	return best.Endpoint
+}
+
+// selectPowerOfTwo 隨機取兩個候選，選負載較低者
+// 相較 Least Loaded 可避免大量請求同時湧向同一個最低負載實例 (Herd Effect)
+func selectPowerOfTwo(candidates []Candidate) string {
+	if len(candidates) == 1 {
+		return candidates[0].Endpoint
+	}
+	i := rand.IntN(len(candidates))
+	j := rand.IntN(len(candidates) - 1)
+	if j >= i {
+		j++
+	}
+	if candidates[j].Load < candidates[i].Load {
+		return candidates[j].Endpoint
+	}
+	return candidates[i].Endpoint
+}
+
+// selectWeightedRandom 依負載反比加權隨機 (weight = 1 / (load + 1))
+func selectWeightedRandom(candidates []Candidate) string {
+	weights := make([]float64, len(candidates))
+	var total float64
+	for i, c := range candidates {
+		load := c.Load
+		if load < 0 {
+			load = 0
+		}
+		weights[i] = 1 / float64(load+1)
+		total += weights[i]
+	}
+
+	r := rand.Float64() * total
+	for i, w := range weights {
+		r -= w
+		if r < 0 {
+			return candidates[i].Endpoint
+		}
+	}
+	return candidates[len(candidates)-1].Endpoint
+}
@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy_Unknown(t *testing.T) {
+	_, ok := NewStrategy("round_robin")
+	assert.False(t, ok)
+}
+
+func TestStrategy_LeastLoaded(t *testing.T) {
+	strategy, ok := NewStrategy(StrategyLeastLoaded)
+	assert.True(t, ok)
+
+	candidates := []Candidate{
+		{Endpoint: "node-1:8090", Load: 30},
+		{Endpoint: "node-2:8090", Load: 5},
+		{Endpoint: "node-3:8090", Load: 12},
+	}
+	assert.Equal(t, "node-2:8090", strategy.Select(candidates))
+}
+
+func TestStrategy_PowerOfTwo(t *testing.T) {
+	strategy, ok := NewStrategy(StrategyPowerOfTwo)
+	assert.True(t, ok)
+
+	// Single candidate
+	assert.Equal(t, "node-1:8090", strategy.Select([]Candidate{{Endpoint: "node-1:8090"}}))
+
+	// With two candidates, the less loaded one must always win
+	candidates := []Candidate{
+		{Endpoint: "node-1:8090", Load: 100},
+		{Endpoint: "node-2:8090", Load: 1},
+	}
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, "node-2:8090", strategy.Select(candidates))
+	}
+
+	// The most loaded instance can never be chosen among 3+
+	candidates = append(candidates, Candidate{Endpoint: "node-3:8090", Load: 50})
+	for i := 0; i < 50; i++ {
+		assert.NotEqual(t, "node-1:8090", strategy.Select(candidates))
+	}
+}
+
+func TestStrategy_WeightedRandom(t *testing.T) {
+	strategy, ok := NewStrategy(StrategyWeightedRandom)
+	assert.True(t, ok)
+
+	candidates := []Candidate{
+		{Endpoint: "idle:8090", Load: 0},
+		{Endpoint: "busy:8090", Load: 999},
+	}
+
+	hits := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		hits[strategy.Select(candidates)]++
+	}
+	// weight ratio is 1 : 1/1000, the idle node should dominate
+	assert.Greater(t, hits["idle:8090"], hits["busy:8090"]*10)
+}
+
+func TestStrategy_Random(t *testing.T) {
+	strategy, ok := NewStrategy(StrategyRandom)
+	assert.True(t, ok)
+
+	candidates := []Candidate{{Endpoint: "a"}, {Endpoint: "b"}}
+	for i := 0; i < 20; i++ {
+		assert.Contains(t, []string{"a", "b"}, strategy.Select(candidates))
+	}
+}
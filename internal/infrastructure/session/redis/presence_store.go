@@ -0,0 +1,218 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/redis"
+)
+
+const (
+	// Key Pattern: presence:session:{SessionID} -> Presence (JSON, 具 TTL)
+	KeyPresence = "presence:session:%s"
+	// Key Pattern: presence:user:{UserID} -> Set of SessionIDs
+	KeyPresenceUser = "presence:user:%s"
+	// Key Pattern: presence:game:{GameID} -> Set of SessionIDs (GameID 0 代表所有在線會話)
+	KeyPresenceGame = "presence:game:%d"
+
+	// DefaultPresenceTTL 在線狀態的存活時間，Connector 需在此期限內 Refresh
+	// 索引集合不設 TTL，讀取時會順便清理已過期或已換遊戲的 SessionID
+	DefaultPresenceTTL = 90 * time.Second
+	// DefaultReservationTTL 登入時保留名額的存活時間，Connector 需在此期限內以 Set 確認 (登入中斷時名額自動釋放)
+	DefaultReservationTTL = 10 * time.Second
+)
+
+// reserveScript 清理使用者索引中已過期的會話，在線會話數未達上限時寫入新會話並加入索引
+// KEYS: [1] 使用者索引 [2] 新會話 [3] 所有在線會話索引
+// ARGV: [1] SessionID [2] 上限 [3] Presence (JSON) [4] 存活時間 (毫秒) [5] 會話 Key 前綴
+// 回傳 1 代表已保留名額，0 代表已達上限
+const reserveScript = `
+local count = 0
+for _, id in ipairs(redis.call("smembers", KEYS[1])) do
+	if id ~= ARGV[1] then
+		if redis.call("exists", ARGV[5] .. id) == 1 then
+			count = count + 1
+		else
+			redis.call("srem", KEYS[1], id)
+		end
+	end
+end
+if count >= tonumber(ARGV[2]) then
+	return 0
+end
+redis.call("set", KEYS[2], ARGV[3], "px", ARGV[4])
+redis.call("sadd", KEYS[1], ARGV[1])
+redis.call("sadd", KEYS[3], ARGV[1])
+return 1
+`
+
+// PresenceStore 使用 Redis 實作 ports.PresenceStore
+type PresenceStore struct {
+	rds *redis.Client
+	ttl time.Duration
+}
+
+var _ ports.PresenceStore = (*PresenceStore)(nil)
+
+func NewPresenceStore(client *redis.Client) *PresenceStore {
+	return &PresenceStore{rds: client, ttl: DefaultPresenceTTL}
+}
+
+// Set implements ports.PresenceStore.
+func (s *PresenceStore) Set(ctx context.Context, presence *domain.Presence) error {
+	presence.UpdatedAt = time.Now().Unix()
+	if err := s.rds.SetStruct(ctx, fmt.Sprintf(KeyPresence, presence.SessionID), presence, s.ttl); err != nil {
+		return fmt.Errorf("failed to save presence: %w", err)
+	}
+	return s.index(ctx, presence)
+}
+
+// Reserve implements ports.PresenceStore.
+// 以 Lua Script 在同一次操作內計數與寫入，避免多個 Central 同時放行同一使用者的登入
+func (s *PresenceStore) Reserve(ctx context.Context, presence *domain.Presence, limit int) ([]*domain.Presence, error) {
+	presence.UpdatedAt = time.Now().Unix()
+	data, err := json.Marshal(presence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal presence: %w", err)
+	}
+
+	keys := []string{
+		fmt.Sprintf(KeyPresenceUser, presence.UserID),
+		fmt.Sprintf(KeyPresence, presence.SessionID),
+		fmt.Sprintf(KeyPresenceGame, 0),
+	}
+	res, err := s.rds.Eval(ctx, reserveScript, keys,
+		presence.SessionID, limit, string(data), DefaultReservationTTL.Milliseconds(), fmt.Sprintf(KeyPresence, ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve presence: %w", err)
+	}
+	if n, _ := res.(int64); n == 1 {
+		return nil, nil
+	}
+
+	sessions, err := s.ListByUser(ctx, presence.UserID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]*domain.Presence, 0, len(sessions))
+	for _, p := range sessions {
+		if p.SessionID != presence.SessionID {
+			existing = append(existing, p)
+		}
+	}
+	return existing, ports.ErrSessionLimitExceeded
+}
+
+// Remove implements ports.PresenceStore.
+func (s *PresenceStore) Remove(ctx context.Context, userID, sessionID string) error {
+	key := fmt.Sprintf(KeyPresence, sessionID)
+	var presence domain.Presence
+	if err := s.rds.GetStruct(ctx, key, &presence); err == nil && presence.GameID != 0 {
+		_ = s.rds.SRem(ctx, fmt.Sprintf(KeyPresenceGame, presence.GameID), sessionID)
+	}
+	if err := s.rds.SRem(ctx, fmt.Sprintf(KeyPresenceUser, userID), sessionID); err != nil {
+		return err
+	}
+	if err := s.rds.SRem(ctx, fmt.Sprintf(KeyPresenceGame, 0), sessionID); err != nil {
+		return err
+	}
+	return s.rds.Del(ctx, key)
+}
+
+// Refresh implements ports.PresenceStore.
+func (s *PresenceStore) Refresh(ctx context.Context, presences []*domain.Presence) error {
+	now := time.Now().Unix()
+	values := make(map[string]any, len(presences))
+	for _, p := range presences {
+		p.UpdatedAt = now
+		values[fmt.Sprintf(KeyPresence, p.SessionID)] = p
+	}
+	if err := s.rds.SetStructs(ctx, values, s.ttl); err != nil {
+		return fmt.Errorf("failed to refresh presence: %w", err)
+	}
+	// 補回索引 (e.g. Redis 重啟或資料過期後)
+	for _, p := range presences {
+		if err := s.index(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListByUser implements ports.PresenceStore.
+func (s *PresenceStore) ListByUser(ctx context.Context, userID string) ([]*domain.Presence, error) {
+	return s.list(ctx, fmt.Sprintf(KeyPresenceUser, userID), func(p *domain.Presence) bool {
+		return p.UserID == userID
+	})
+}
+
+// ListByGame implements ports.PresenceStore.
+func (s *PresenceStore) ListByGame(ctx context.Context, gameID int32) ([]*domain.Presence, error) {
+	return s.list(ctx, fmt.Sprintf(KeyPresenceGame, gameID), func(p *domain.Presence) bool {
+		return gameID == 0 || p.GameID == gameID
+	})
+}
+
+// index 將會話加入使用者與遊戲索引
+func (s *PresenceStore) index(ctx context.Context, p *domain.Presence) error {
+	if err := s.rds.SAdd(ctx, fmt.Sprintf(KeyPresenceUser, p.UserID), p.SessionID); err != nil {
+		return fmt.Errorf("failed to index presence: %w", err)
+	}
+	if err := s.rds.SAdd(ctx, fmt.Sprintf(KeyPresenceGame, 0), p.SessionID); err != nil {
+		return fmt.Errorf("failed to index presence: %w", err)
+	}
+	if p.GameID != 0 {
+		if err := s.rds.SAdd(ctx, fmt.Sprintf(KeyPresenceGame, p.GameID), p.SessionID); err != nil {
+			return fmt.Errorf("failed to index presence: %w", err)
+		}
+	}
+	return nil
+}
+
+// list 讀取索引集合中的會話，並清理已過期或不再符合 (match 為 false) 的 SessionID
+func (s *PresenceStore) list(ctx context.Context, indexKey string, match func(p *domain.Presence) bool) ([]*domain.Presence, error) {
+	sessionIDs, err := s.rds.SMembers(ctx, indexKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(sessionIDs))
+	for i, id := range sessionIDs {
+		keys[i] = fmt.Sprintf(KeyPresence, id)
+	}
+	values, err := s.rds.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	presences := make([]*domain.Presence, 0, len(values))
+	var stale []any
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			// 已過期 (Connector 未正常移除)
+			stale = append(stale, sessionIDs[i])
+			continue
+		}
+		var p domain.Presence
+		if err := json.Unmarshal([]byte(str), &p); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal presence: %w", err)
+		}
+		if !match(&p) {
+			stale = append(stale, sessionIDs[i])
+			continue
+		}
+		presences = append(presences, &p)
+	}
+	if len(stale) > 0 {
+		_ = s.rds.SRem(ctx, indexKey, stale...)
+	}
+	return presences, nil
+}
@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/redis"
+)
+
+const (
+	// Key Pattern: resume:{Token} -> ResumeState (JSON)
+	KeyResumeState = "resume:%s"
+	// Key: 所有保留中 Token 的到期時間 (Sorted Set, score = 到期 Unix 毫秒)
+	KeyResumeDeadlines = "resume:deadlines"
+
+	// stateRetention 保留狀態在到期後額外存活的時間
+	// 讓 Sweeper 有足夠時間認領並通知 Game Server，之後由 Redis 自動回收
+	stateRetention = time.Minute
+)
+
+// ResumeStore 使用 Redis 實作 ports.ResumeStore
+type ResumeStore struct {
+	rds *redis.Client
+}
+
+var _ ports.ResumeStore = (*ResumeStore)(nil)
+
+func NewResumeStore(client *redis.Client) *ResumeStore {
+	return &ResumeStore{rds: client}
+}
+
+// Park implements ports.ResumeStore.
+func (s *ResumeStore) Park(ctx context.Context, token string, state *domain.ResumeState, grace time.Duration) error {
+	key := fmt.Sprintf(KeyResumeState, token)
+	if err := s.rds.SetStruct(ctx, key, state, grace+stateRetention); err != nil {
+		return fmt.Errorf("failed to park session: %w", err)
+	}
+
+	deadline := time.Now().Add(grace).UnixMilli()
+	if err := s.rds.ZAdd(ctx, KeyResumeDeadlines, float64(deadline), token); err != nil {
+		_ = s.rds.Del(ctx, key)
+		return fmt.Errorf("failed to schedule resume deadline: %w", err)
+	}
+	return nil
+}
+
+// Claim implements ports.ResumeStore.
+func (s *ResumeStore) Claim(ctx context.Context, token string) (*domain.ResumeState, error) {
+	// 1. 從到期排程中移除，回傳 1 代表認領成功 (避免 Resume 與 Sweeper 同時處理)
+	removed, err := s.rds.ZRem(ctx, KeyResumeDeadlines, token)
+	if err != nil {
+		return nil, err
+	}
+	if removed == 0 {
+		return nil, ports.ErrResumeNotFound
+	}
+
+	// 2. 取出並刪除狀態
+	val, err := s.rds.GetDel(ctx, fmt.Sprintf(KeyResumeState, token))
+	if err != nil {
+		if redis.IsNil(err) {
+			return nil, ports.ErrResumeNotFound
+		}
+		return nil, err
+	}
+
+	var state domain.ResumeState
+	if err := json.Unmarshal([]byte(val), &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resume state: %w", err)
+	}
+	return &state, nil
+}
+
+// ListExpired implements ports.ResumeStore.
+func (s *ResumeStore) ListExpired(ctx context.Context, before time.Time) ([]string, error) {
+	return s.rds.ZRangeByScore(ctx, KeyResumeDeadlines, "-inf", fmt.Sprintf("%d", before.UnixMilli()))
+}
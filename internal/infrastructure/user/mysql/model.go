@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// userModel 使用者資料表
+type userModel struct {
+	ID        string    `gorm:"primaryKey;size:64"`
+	Name      string    `gorm:"size:64;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+func (userModel) TableName() string { return "users" }
+
+// userTokenModel Token -> 使用者對應表 (訪客登入使用)
+// 只儲存 Token 的 SHA-256，避免明文 Token 外洩且長度固定
+type userTokenModel struct {
+	TokenHash string    `gorm:"primaryKey;size:64"`
+	UserID    string    `gorm:"size:64;not null;index"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (userTokenModel) TableName() string { return "user_tokens" }
+
+func (m *userModel) toDomain() *domain.User {
+	return &domain.User{
+		ID:        m.ID,
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func newUserModel(user *domain.User) *userModel {
+	createdAt := user.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return &userModel{
+		ID:        user.ID,
+		Name:      user.Name,
+		CreatedAt: createdAt,
+	}
+}
+
+// Migrate 建立或更新使用者相關資料表 (GORM Auto Migration)
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&userModel{}, &userTokenModel{})
+}
@@ -0,0 +1,178 @@
+package mysql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/pkg/redis"
+)
+
+const (
+	// Key Pattern: cache:user:{UserID} -> User (JSON)
+	KeyCacheUser = "cache:user:%s"
+	// Key Pattern: cache:token:{TokenHash} -> UserID
+	KeyCacheToken = "cache:token:%s"
+
+	// DefaultCacheTTL 預設快取存活時間
+	DefaultCacheTTL = 10 * time.Minute
+)
+
+// UserService 使用 MySQL 實作 ports.UserService
+// 可選擇搭配 Redis 作為 Read-Through 快取 (WithCache)，快取失敗不影響查詢結果。
+type UserService struct {
+	db       *gorm.DB
+	cache    *redis.Client
+	cacheTTL time.Duration
+}
+
+var _ ports.UserService = (*UserService)(nil)
+
+// Option 定義了 UserService 的配置選項函數
+type Option func(*UserService)
+
+// WithCache 啟用 Redis Read-Through 快取 (ttl <= 0 使用 DefaultCacheTTL)
+func WithCache(client *redis.Client, ttl time.Duration) Option {
+	return func(s *UserService) {
+		s.cache = client
+		if ttl > 0 {
+			s.cacheTTL = ttl
+		}
+	}
+}
+
+func NewUserService(db *gorm.DB, opts ...Option) *UserService {
+	s := &UserService{
+		db:       db,
+		cacheTTL: DefaultCacheTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetUserByID implements ports.UserService.
+func (s *UserService) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
+	cacheKey := fmt.Sprintf(KeyCacheUser, id)
+	if s.cache != nil {
+		var user domain.User
+		if err := s.cache.GetStruct(ctx, cacheKey, &user); err == nil {
+			return &user, nil
+		} else if !redis.IsNil(err) {
+			slog.Warn("User cache read failed", "user_id", id, "error", err)
+		}
+	}
+
+	var model userModel
+	if err := s.db.WithContext(ctx).Take(&model, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	user := model.toDomain()
+	s.cacheUser(ctx, user)
+	return user, nil
+}
+
+// GetUser implements ports.UserService.
+func (s *UserService) GetUser(ctx context.Context, token string) (*domain.User, error) {
+	tokenHash := hashToken(token)
+	cacheKey := fmt.Sprintf(KeyCacheToken, tokenHash)
+	if s.cache != nil {
+		if userID, err := s.cache.Get(ctx, cacheKey); err == nil {
+			return s.GetUserByID(ctx, userID)
+		} else if !redis.IsNil(err) {
+			slog.Warn("Token cache read failed", "error", err)
+		}
+	}
+
+	var tokenRow userTokenModel
+	if err := s.db.WithContext(ctx).Take(&tokenRow, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ports.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	s.cacheToken(ctx, tokenHash, tokenRow.UserID)
+	return s.GetUserByID(ctx, tokenRow.UserID)
+}
+
+// CreateGuestUser implements ports.UserService.
+// 在同一個 Transaction 中建立使用者與 Token 對應
+func (s *UserService) CreateGuestUser(ctx context.Context, token string, user *domain.User) error {
+	model := newUserModel(user)
+	tokenRow := &userTokenModel{
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		CreatedAt: model.CreatedAt,
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if err := tx.Create(tokenRow).Error; err != nil {
+			return fmt.Errorf("failed to create user token: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cacheUser(ctx, model.toDomain())
+	s.cacheToken(ctx, tokenRow.TokenHash, user.ID)
+	return nil
+}
+
+// EnsureUser implements ports.UserService.
+// 以 INSERT ... ON DUPLICATE KEY 建立使用者，已存在時保留原資料
+func (s *UserService) EnsureUser(ctx context.Context, user *domain.User) error {
+	model := newUserModel(user)
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to ensure user: %w", result.Error)
+	}
+	// 僅在新建時寫入快取，已存在的資料以資料庫為準
+	if result.RowsAffected > 0 {
+		s.cacheUser(ctx, model.toDomain())
+	}
+	return nil
+}
+
+func (s *UserService) cacheUser(ctx context.Context, user *domain.User) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.SetStruct(ctx, fmt.Sprintf(KeyCacheUser, user.ID), user, s.cacheTTL); err != nil {
+		slog.Warn("User cache write failed", "user_id", user.ID, "error", err)
+	}
+}
+
+func (s *UserService) cacheToken(ctx context.Context, tokenHash, userID string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Set(ctx, fmt.Sprintf(KeyCacheToken, tokenHash), userID, s.cacheTTL); err != nil {
+		slog.Warn("Token cache write failed", "user_id", userID, "error", err)
+	}
+}
+
+// hashToken 計算 Token 的 SHA-256 (Hex)
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
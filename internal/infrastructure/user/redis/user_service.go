@@ -63,6 +63,14 @@ func (service *UserService) CreateGuestUser(ctx context.Context, token string, u
 	return nil
 }
 
+// EnsureUser implements ports.UserService.
+// 外部驗證的使用者資料不設過期時間；已存在時保留原資料
+func (service *UserService) EnsureUser(ctx context.Context, user *domain.User) error {
+	userKey := fmt.Sprintf(KeyUserID, user.ID)
+	_, err := service.rds.SetStructNX(ctx, userKey, user, 0)
+	return err
+}
+
 var _ ports.UserService = (*UserService)(nil)
 
 func NewUserService(client *redis.Client) *UserService {
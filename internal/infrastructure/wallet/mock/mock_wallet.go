@@ -2,35 +2,119 @@ package mock
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/shopspring/decimal"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 )
 
-// MockWallet 模擬錢包
+// DefaultInitialBalance 新使用者的初始餘額
+var DefaultInitialBalance = decimal.NewFromInt(1000000)
+
+// MockWallet 模擬錢包 (In-Memory, 開發測試用)
+// 行為與正式實作一致: 冪等交易、餘額不足檢查、交易紀錄，資料於重啟後消失
 type MockWallet struct {
+	mu           sync.Mutex
 	userBalances map[string]decimal.Decimal
+	transactions map[string]*domain.Transaction   // txID -> Transaction
+	history      map[string][]*domain.Transaction // userID -> Transactions (舊到新)
 }
 
+var _ ports.WalletService = (*MockWallet)(nil)
+
 func NewMockWallet() *MockWallet {
 	return &MockWallet{
 		userBalances: make(map[string]decimal.Decimal),
+		transactions: make(map[string]*domain.Transaction),
+		history:      make(map[string][]*domain.Transaction),
 	}
 }
 
-func (m *MockWallet) GetBalance(ctx context.Context, userID string) (decimal.Decimal, error) {
-	if balance, exists := m.userBalances[userID]; exists {
-		return balance, nil
+func (m *MockWallet) GetBalance(_ context.Context, userID string) (decimal.Decimal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.balanceLocked(userID), nil
+}
+
+func (m *MockWallet) Deposit(_ context.Context, userID string, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error) {
+	return m.apply(userID, domain.TransactionDeposit, amount, txID, reason)
+}
+
+func (m *MockWallet) Withdraw(_ context.Context, userID string, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error) {
+	return m.apply(userID, domain.TransactionWithdraw, amount, txID, reason)
+}
+
+func (m *MockWallet) ListTransactions(_ context.Context, userID string, limit, offset int) ([]*domain.Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 與正式實作相同: limit 超出範圍時以上限為準，offset 小於 0 視為 0
+	if limit <= 0 || limit > ports.MaxTransactionListLimit {
+		limit = ports.MaxTransactionListLimit
+	}
+	offset = max(offset, 0)
+
+	history := m.history[userID]
+	result := make([]*domain.Transaction, 0, min(limit, len(history)))
+	for i := len(history) - 1 - offset; i >= 0 && len(result) < limit; i-- {
+		tx := *history[i]
+		result = append(result, &tx)
 	}
-	m.userBalances[userID] = decimal.NewFromInt(1000000)
-	return m.userBalances[userID], nil
+	return result, nil
 }
 
-func (m *MockWallet) Deposit(ctx context.Context, userID string, amount decimal.Decimal, reason string) error {
-	m.userBalances[userID] = m.userBalances[userID].Add(amount)
-	return nil
+func (m *MockWallet) apply(userID string, txType domain.TransactionType, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error) {
+	if !amount.IsPositive() {
+		return nil, ports.ErrInvalidAmount
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// 冪等: 相同 txID 回傳第一次的結果
+	if existing, ok := m.transactions[txID]; ok {
+		if existing.UserID != userID || existing.Type != txType || !existing.Amount.Equal(amount) {
+			return nil, ports.ErrTransactionConflict
+		}
+		tx := *existing
+		return &tx, nil
+	}
+
+	balance := m.balanceLocked(userID)
+	if txType == domain.TransactionWithdraw {
+		if balance.LessThan(amount) {
+			return nil, ports.ErrInsufficientFunds
+		}
+		balance = balance.Sub(amount)
+	} else {
+		balance = balance.Add(amount)
+	}
+	m.userBalances[userID] = balance
+
+	tx := &domain.Transaction{
+		ID:           txID,
+		UserID:       userID,
+		Type:         txType,
+		Amount:       amount,
+		BalanceAfter: balance,
+		Reason:       reason,
+		CreatedAt:    time.Now(),
+	}
+	m.transactions[txID] = tx
+	m.history[userID] = append(m.history[userID], tx)
+
+	result := *tx
+	return &result, nil
 }
 
-func (m *MockWallet) Withdraw(ctx context.Context, userID string, amount decimal.Decimal, reason string) error {
-	m.userBalances[userID] = m.userBalances[userID].Sub(amount)
-	return nil
+// balanceLocked 取得餘額，新使用者給予初始餘額 (需持有鎖)
+func (m *MockWallet) balanceLocked(userID string) decimal.Decimal {
+	if balance, exists := m.userBalances[userID]; exists {
+		return balance
+	}
+	m.userBalances[userID] = DefaultInitialBalance
+	return DefaultInitialBalance
 }
@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	"github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/wallettest"
+)
+
+func TestMockWallet_Idempotency(t *testing.T) {
+	w := NewMockWallet()
+	ctx := context.Background()
+
+	tx1, err := w.Withdraw(ctx, "u1", decimal.NewFromInt(100), "bet-1", "bet")
+	require.NoError(t, err)
+	tx2, err := w.Withdraw(ctx, "u1", decimal.NewFromInt(100), "bet-1", "bet")
+	require.NoError(t, err)
+	assert.Equal(t, tx1.BalanceAfter, tx2.BalanceAfter)
+
+	balance, _ := w.GetBalance(ctx, "u1")
+	assert.True(t, balance.Equal(DefaultInitialBalance.Sub(decimal.NewFromInt(100))))
+
+	// Same txID, different amount
+	_, err = w.Withdraw(ctx, "u1", decimal.NewFromInt(50), "bet-1", "bet")
+	assert.ErrorIs(t, err, ports.ErrTransactionConflict)
+}
+
+func TestMockWallet_InsufficientFunds(t *testing.T) {
+	w := NewMockWallet()
+	ctx := context.Background()
+
+	_, err := w.Withdraw(ctx, "u1", DefaultInitialBalance.Add(decimal.NewFromInt(1)), "bet-1", "bet")
+	assert.ErrorIs(t, err, ports.ErrInsufficientFunds)
+
+	_, err = w.Deposit(ctx, "u1", decimal.Zero, "win-1", "win")
+	assert.ErrorIs(t, err, ports.ErrInvalidAmount)
+}
+
+func TestMockWallet_Concurrent(t *testing.T) {
+	w := NewMockWallet()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _ = w.Withdraw(ctx, "u1", decimal.NewFromInt(10), fmt.Sprintf("bet-%d", i), "bet")
+		}(i)
+	}
+	wg.Wait()
+
+	balance, _ := w.GetBalance(ctx, "u1")
+	assert.True(t, balance.Equal(DefaultInitialBalance.Sub(decimal.NewFromInt(1000))))
+
+	history, err := w.ListTransactions(ctx, "u1", 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, history, 10)
+}
+
+func TestMockWallet_Contract(t *testing.T) {
+	wallettest.RunContract(t, NewMockWallet())
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+)
+
+// walletModel 錢包餘額表 (每位使用者一列，交易時以 SELECT ... FOR UPDATE 鎖定)
+type walletModel struct {
+	UserID    string          `gorm:"primaryKey;size:64"`
+	Balance   decimal.Decimal `gorm:"type:decimal(20,4);not null"`
+	CreatedAt time.Time       `gorm:"not null"`
+	UpdatedAt time.Time       `gorm:"not null"`
+}
+
+func (walletModel) TableName() string { return "wallets" }
+
+// transactionModel 錢包帳本 (Ledger)，只新增不修改
+// tx_id 為唯一索引，作為冪等鍵
+type transactionModel struct {
+	ID           uint64          `gorm:"primaryKey;autoIncrement"`
+	TxID         string          `gorm:"size:128;not null;uniqueIndex"`
+	UserID       string          `gorm:"size:64;not null;index:idx_wallet_tx_user_created,priority:1"`
+	Type         string          `gorm:"size:16;not null"`
+	Amount       decimal.Decimal `gorm:"type:decimal(20,4);not null"`
+	BalanceAfter decimal.Decimal `gorm:"type:decimal(20,4);not null"`
+	Reason       string          `gorm:"size:255"`
+	CreatedAt    time.Time       `gorm:"not null;index:idx_wallet_tx_user_created,priority:2"`
+}
+
+func (transactionModel) TableName() string { return "wallet_transactions" }
+
+func (m *transactionModel) toDomain() *domain.Transaction {
+	return &domain.Transaction{
+		ID:           m.TxID,
+		UserID:       m.UserID,
+		Type:         domain.TransactionType(m.Type),
+		Amount:       m.Amount,
+		BalanceAfter: m.BalanceAfter,
+		Reason:       m.Reason,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// Migrate 建立或更新錢包相關資料表 (GORM Auto Migration)
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&walletModel{}, &transactionModel{})
+}
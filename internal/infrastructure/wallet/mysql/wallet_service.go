@@ -0,0 +1,222 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+const (
+	// initialTxReason 初始餘額的帳本紀錄原因
+	initialTxReason = "initial balance"
+
+	// MaxListLimit 單次查詢交易紀錄的上限
+	MaxListLimit = ports.MaxTransactionListLimit
+)
+
+// WalletService 使用 MySQL 實作 ports.WalletService
+//
+// 每筆交易在同一個 Transaction 中完成:
+//  1. 鎖定使用者錢包 (SELECT ... FOR UPDATE)，同一使用者的交易因此序列化
+//  2. 以 tx_id 檢查冪等 (已存在則回傳原交易)
+//  3. 檢查餘額、更新餘額並寫入帳本
+type WalletService struct {
+	db             *gorm.DB
+	initialBalance decimal.Decimal
+}
+
+var _ ports.WalletService = (*WalletService)(nil)
+
+// Option 定義了 WalletService 的配置選項函數
+type Option func(*WalletService)
+
+// WithInitialBalance 設定新錢包的初始餘額 (會寫入一筆帳本紀錄)
+func WithInitialBalance(balance decimal.Decimal) Option {
+	return func(s *WalletService) {
+		s.initialBalance = balance
+	}
+}
+
+func NewWalletService(db *gorm.DB, opts ...Option) *WalletService {
+	s := &WalletService{
+		db:             db,
+		initialBalance: decimal.Zero,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetBalance implements ports.WalletService.
+// 錢包尚未建立時回傳初始餘額 (不寫入資料庫)
+func (s *WalletService) GetBalance(ctx context.Context, userID string) (decimal.Decimal, error) {
+	var wallet walletModel
+	if err := s.db.WithContext(ctx).Take(&wallet, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.initialBalance, nil
+		}
+		return decimal.Zero, err
+	}
+	return wallet.Balance, nil
+}
+
+// Deposit implements ports.WalletService.
+func (s *WalletService) Deposit(ctx context.Context, userID string, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error) {
+	return s.apply(ctx, userID, domain.TransactionDeposit, amount, txID, reason)
+}
+
+// Withdraw implements ports.WalletService.
+func (s *WalletService) Withdraw(ctx context.Context, userID string, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error) {
+	return s.apply(ctx, userID, domain.TransactionWithdraw, amount, txID, reason)
+}
+
+// ListTransactions implements ports.WalletService.
+func (s *WalletService) ListTransactions(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, error) {
+	if limit <= 0 || limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+	offset = max(offset, 0)
+
+	var rows []transactionModel
+	err := s.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Transaction, 0, len(rows))
+	for i := range rows {
+		result = append(result, rows[i].toDomain())
+	}
+	return result, nil
+}
+
+func (s *WalletService) apply(ctx context.Context, userID string, txType domain.TransactionType, amount decimal.Decimal, txID string, reason string) (*domain.Transaction, error) {
+	if !amount.IsPositive() {
+		return nil, ports.ErrInvalidAmount
+	}
+	if txID == "" {
+		return nil, errors.New("wallet: transaction id is required")
+	}
+
+	var result *domain.Transaction
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 鎖定錢包 (不存在則以初始餘額建立)
+		wallet, err := s.lockWallet(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		// 2. 冪等檢查 (錢包已鎖定，同一使用者的重複請求不會同時通過)
+		var existing transactionModel
+		err = tx.Take(&existing, "tx_id = ?", txID).Error
+		if err == nil {
+			if existing.UserID != userID || existing.Type != string(txType) || !existing.Amount.Equal(amount) {
+				return ports.ErrTransactionConflict
+			}
+			result = existing.toDomain()
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// 3. 計算新餘額
+		balance := wallet.Balance
+		if txType == domain.TransactionWithdraw {
+			if balance.LessThan(amount) {
+				return ports.ErrInsufficientFunds
+			}
+			balance = balance.Sub(amount)
+		} else {
+			balance = balance.Add(amount)
+		}
+
+		// 4. 更新餘額並寫入帳本
+		now := time.Now()
+		if err := tx.Model(&walletModel{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]any{"balance": balance, "updated_at": now}).Error; err != nil {
+			return fmt.Errorf("failed to update balance: %w", err)
+		}
+
+		row := &transactionModel{
+			TxID:         txID,
+			UserID:       userID,
+			Type:         string(txType),
+			Amount:       amount,
+			BalanceAfter: balance,
+			Reason:       reason,
+			CreatedAt:    now,
+		}
+		if err := tx.Create(row).Error; err != nil {
+			// 其他使用者已使用相同 tx_id (唯一索引衝突)
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ports.ErrTransactionConflict
+			}
+			return fmt.Errorf("failed to write ledger: %w", err)
+		}
+
+		result = row.toDomain()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// lockWallet 以 SELECT ... FOR UPDATE 鎖定錢包，不存在時以初始餘額建立
+func (s *WalletService) lockWallet(tx *gorm.DB, userID string) (*walletModel, error) {
+	var wallet walletModel
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&wallet, "user_id = ?", userID).Error
+	if err == nil {
+		return &wallet, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// 建立新錢包 (並發建立時忽略衝突，再重新鎖定)
+	now := time.Now()
+	created := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&walletModel{
+		UserID:    userID,
+		Balance:   s.initialBalance,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if created.Error != nil {
+		return nil, fmt.Errorf("failed to create wallet: %w", created.Error)
+	}
+	if created.RowsAffected > 0 && s.initialBalance.IsPositive() {
+		if err := tx.Create(&transactionModel{
+			TxID:         "init:" + userID,
+			UserID:       userID,
+			Type:         string(domain.TransactionDeposit),
+			Amount:       s.initialBalance,
+			BalanceAfter: s.initialBalance,
+			Reason:       initialTxReason,
+			CreatedAt:    now,
+		}).Error; err != nil {
+			return nil, fmt.Errorf("failed to write initial ledger: %w", err)
+		}
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&wallet, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	gormMysql "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/infrastructure/wallet/wallettest"
+)
+
+// TestWalletService_Contract 以共用的行為測試驗證 MySQL 實作 (需實際的 MySQL)
+// 設定 WALLET_TEST_MYSQL_DSN 後執行，e.g. "root:password@tcp(localhost:3306)/game?parseTime=True&loc=Local"
+func TestWalletService_Contract(t *testing.T) {
+	dsn := os.Getenv("WALLET_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("WALLET_TEST_MYSQL_DSN not set")
+	}
+
+	db, err := gorm.Open(gormMysql.Open(dsn), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+	require.NoError(t, Migrate(db))
+
+	wallettest.RunContract(t, NewWalletService(db))
+}
@@ -0,0 +1,106 @@
+// Package wallettest 提供 ports.WalletService 各實作共用的行為測試 (Contract Test)
+// 確保開發用的 Mock 與正式的 MySQL 實作行為一致
+package wallettest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+)
+
+// RunContract 以 ports.WalletService 的共同語意測試錢包實作
+// 新錢包不可寫入初始餘額的帳本紀錄；每個案例使用不重複的 UserID 與 txID，可在既有資料庫上執行
+func RunContract(t *testing.T, w ports.WalletService) {
+	t.Run("Idempotency", func(t *testing.T) { testIdempotency(t, w) })
+	t.Run("ListTransactions", func(t *testing.T) { testListTransactions(t, w) })
+}
+
+var userSeq atomic.Int64
+
+// newUserID 產生不重複的 UserID (同一資料庫重複執行也不會衝突)
+func newUserID() string {
+	return fmt.Sprintf("contract-%d-%d", time.Now().UnixNano(), userSeq.Add(1))
+}
+
+func testIdempotency(t *testing.T, w ports.WalletService) {
+	ctx := context.Background()
+	userID := newUserID()
+	initial, err := w.GetBalance(ctx, userID)
+	require.NoError(t, err)
+
+	tx1, err := w.Deposit(ctx, userID, decimal.NewFromInt(100), userID+"-dep", "deposit")
+	require.NoError(t, err)
+	tx2, err := w.Deposit(ctx, userID, decimal.NewFromInt(100), userID+"-dep", "deposit")
+	require.NoError(t, err)
+	assert.Equal(t, tx1.ID, tx2.ID)
+	assert.True(t, tx1.BalanceAfter.Equal(tx2.BalanceAfter))
+
+	// 相同 txID 不同金額
+	_, err = w.Deposit(ctx, userID, decimal.NewFromInt(50), userID+"-dep", "deposit")
+	assert.ErrorIs(t, err, ports.ErrTransactionConflict)
+
+	_, err = w.Withdraw(ctx, userID, initial.Add(decimal.NewFromInt(101)), userID+"-bet", "bet")
+	assert.ErrorIs(t, err, ports.ErrInsufficientFunds)
+	_, err = w.Withdraw(ctx, userID, decimal.Zero, userID+"-bet", "bet")
+	assert.ErrorIs(t, err, ports.ErrInvalidAmount)
+
+	balance, err := w.GetBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, balance.Equal(initial.Add(decimal.NewFromInt(100))))
+
+	history, err := w.ListTransactions(ctx, userID, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func testListTransactions(t *testing.T, w ports.WalletService) {
+	ctx := context.Background()
+	userID := newUserID()
+
+	// 建立超過單次上限的交易紀錄 (金額依序遞增，用來確認排序)
+	total := ports.MaxTransactionListLimit + 5
+	for i := 1; i <= total; i++ {
+		_, err := w.Deposit(ctx, userID, decimal.NewFromInt(int64(i)), fmt.Sprintf("%s-%d", userID, i), "deposit")
+		require.NoError(t, err)
+	}
+
+	// 依時間新到舊
+	history, err := w.ListTransactions(ctx, userID, 3, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.True(t, history[0].Amount.Equal(decimal.NewFromInt(int64(total))))
+	assert.True(t, history[2].Amount.Equal(decimal.NewFromInt(int64(total-2))))
+
+	// offset 略過最新的紀錄；小於 0 視為 0
+	history, err = w.ListTransactions(ctx, userID, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.True(t, history[0].Amount.Equal(decimal.NewFromInt(int64(total-1))))
+	history, err = w.ListTransactions(ctx, userID, 1, -1)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.True(t, history[0].Amount.Equal(decimal.NewFromInt(int64(total))))
+
+	// limit 小於等於 0 或超過上限時以上限為準
+	for _, limit := range []int{0, -1, ports.MaxTransactionListLimit + 1} {
+		history, err = w.ListTransactions(ctx, userID, limit, 0)
+		require.NoError(t, err)
+		assert.Len(t, history, ports.MaxTransactionListLimit, "limit %d", limit)
+	}
+
+	// 超出範圍的 offset 與沒有紀錄的使用者回傳空列表
+	history, err = w.ListTransactions(ctx, userID, 10, total)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+	history, err = w.ListTransactions(ctx, newUserID(), 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/JoeShih716/go-k8s-game-server/internal/kit/config"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/health"
 )
 
 // App 封裝了應用程式的基礎組件
@@ -14,6 +15,7 @@ type App struct {
 	Name   string
 	Config *config.Config
 	Logger *slog.Logger
+	Health *health.Checker // 健康檢查 (各服務註冊實際依賴，收到停止信號後 Readiness 轉為失敗)
 }
 
 // NewApp 建立一個新的應用程式實例
@@ -50,6 +52,7 @@ func NewApp(appName string) *App {
 		Name:   appName,
 		Config: cfg,
 		Logger: logger,
+		Health: health.NewChecker(),
 	}
 }
 
@@ -73,6 +76,7 @@ func (a *App) Run(startFunc func() error, cleanupFunc func()) {
 	<-quit
 
 	a.Logger.Info("Shutting down service...", "app", a.Name)
+	a.Health.SetDraining()
 	if cleanupFunc != nil {
 		cleanupFunc()
 	}
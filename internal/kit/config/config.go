@@ -10,6 +10,7 @@ import (
 
 const (
 	DefaultGrpcPort    = 8090
+	DefaultMetricsPort = 9100
 	DefaultCentralAddr = "central:8090"
 )
 
@@ -30,15 +31,80 @@ type AppConfig struct {
 	Port     int    `mapstructure:"port"`      // HTTP/WebSocket Port (Public Gateway)
 	GrpcPort int    `mapstructure:"grpc_port"` // gRPC Server Port (Internal Communication, Default: 8090)
 	PodIP    string `mapstructure:"-"`         // Pod IP (runtime injected, not from file)
+	Version  string `mapstructure:"version"`   // 實例版本 (Game Server 註冊時回報，用於版本路由；可用 APP_VERSION 環境變數設定)
+	// MetricsPort Prometheus /metrics 的 HTTP Port (預設 9100，0 代表停用；可用 APP_METRICS_PORT 環境變數設定)
+	MetricsPort int `mapstructure:"metrics_port"`
 }
 
 // Config 總配置結構
 type Config struct {
-	App      AppConfig         `mapstructure:"app"`
-	Redis    RedisGlobalConfig `mapstructure:"redis"`
-	MySQL    MySQLConfig       `mapstructure:"mysql"`
-	WSS      WSSConfig         `mapstructure:"wss"`
-	Services map[string]string `mapstructure:"services"`
+	App       AppConfig         `mapstructure:"app"`
+	Redis     RedisGlobalConfig `mapstructure:"redis"`
+	MySQL     MySQLConfig       `mapstructure:"mysql"`
+	WSS       WSSConfig         `mapstructure:"wss"`
+	Routing   RoutingConfig     `mapstructure:"routing"`
+	Auth      AuthConfig        `mapstructure:"auth"`
+	Session   SessionConfig     `mapstructure:"session"`
+	Mgmt      MgmtConfig        `mapstructure:"mgmt"`
+	UserStore UserStoreConfig   `mapstructure:"user_store"`
+	Wallet    WalletConfig      `mapstructure:"wallet"`
+	Services  map[string]string `mapstructure:"services"`
+	Tracing   TracingConfig     `mapstructure:"tracing"`
+}
+
+// TracingConfig 定義 OpenTelemetry 分散式追蹤的輸出方式 (Exporter 為空代表停用)
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"`     // otlp (OTLP/gRPC Collector) / stdout (輸出至標準輸出，開發用)
+	Endpoint    string  `mapstructure:"endpoint"`     // OTLP Collector 位址 (ex: otel-collector:4317)，可用 TRACING_ENDPOINT 環境變數設定
+	Insecure    bool    `mapstructure:"insecure"`     // OTLP 不使用 TLS (叢集內 Collector 通常為 true)
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 取樣比例 (0-1，預設 1；上游已取樣的請求一律沿用上游決定)
+}
+
+// RoutingConfig 定義 Central 挑選遊戲服務實例時的負載均衡策略與版本路由規則
+// 可用策略: random, least_loaded, p2c, weighted_random
+type RoutingConfig struct {
+	DefaultStrategy string            `mapstructure:"default_strategy"` // 預設策略 (未設定則為 random)
+	GameStrategies  map[string]string `mapstructure:"game_strategies"`  // GameID -> 策略名稱
+	Rules           []RouteRuleConfig `mapstructure:"rules"`            // 版本路由規則 (Canary / Blue-Green，依序比對，第一條命中的規則生效)
+}
+
+// RouteRuleConfig 定義一條版本路由規則，設定的條件需全部符合才會命中 (未設定條件代表所有使用者)
+type RouteRuleConfig struct {
+	GameID           int32    `mapstructure:"game_id"`            // 套用的遊戲 (0 代表所有遊戲)
+	Version          string   `mapstructure:"version"`            // 命中時路由到的實例版本
+	Percent          int      `mapstructure:"percent"`            // 依使用者 ID 分流的百分比 (1-100)
+	UserIDs          []string `mapstructure:"user_ids"`           // 指定使用者
+	MinClientVersion string   `mapstructure:"min_client_version"` // 客戶端版本需大於等於此版本 (ex: "1.4")
+}
+
+// AuthConfig 定義 Central 的玩家登入驗證方式
+type AuthConfig struct {
+	GuestLogin bool      `mapstructure:"guest_login"` // 允許訪客登入 (非 JWT 的 Token 自動註冊為訪客，僅限開發/測試)
+	JWT        JWTConfig `mapstructure:"jwt"`
+}
+
+// SessionConfig 定義 Central 對每位使用者同時在線會話數的限制 (需 Redis Session DB 與 wss.presence)
+type SessionConfig struct {
+	MaxPerUser  int    `mapstructure:"max_per_user"` // 每位使用者同時在線的會話數上限 (0 代表不限制)
+	OnDuplicate string `mapstructure:"on_duplicate"` // 達到上限時: kick_old (踢除最早登入的會話，預設) / reject_new (拒絕新登入)
+}
+
+// MgmtConfig 定義管理服務 (cmd/mgmt) 的設定
+type MgmtConfig struct {
+	AdminToken string `mapstructure:"admin_token"` // 管理 API 的 Bearer Token (空字串代表不驗證，僅限 app.env 為 local，其他環境拒絕啟動)
+}
+
+// JWTConfig 定義 JWT 驗證參數 (Algorithm 為空代表停用)
+// HS256 使用 Secret；RS256 使用 PublicKeyFile (PEM) 或 JWKSFile (依 kid 選擇公鑰)
+type JWTConfig struct {
+	Algorithm     string `mapstructure:"algorithm"`       // HS256 / RS256
+	Secret        string `mapstructure:"secret"`          // HS256 共享金鑰 (建議以 AUTH_JWT_SECRET 環境變數注入)
+	PublicKeyFile string `mapstructure:"public_key_file"` // RS256 公鑰檔案 (PEM)
+	JWKSFile      string `mapstructure:"jwks_file"`       // RS256 JWKS 檔案
+	Issuer        string `mapstructure:"issuer"`          // 預期的 iss (空字串代表不檢查)
+	Audience      string `mapstructure:"audience"`        // 預期的 aud (空字串代表不檢查)
+	LeewaySec     int    `mapstructure:"leeway_sec"`      // exp / nbf 容許的時鐘誤差秒數
+	NameClaim     string `mapstructure:"name_claim"`      // 對應暱稱的 Claim (預設 name)
 }
 
 type MySQLConfig struct {
@@ -47,16 +113,54 @@ type MySQLConfig struct {
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
 	DBName   string `mapstructure:"dbname"`
+
+	// 連線池設定
+	MaxOpenConns       int    `mapstructure:"max_open_conns"`
+	MaxIdleConns       int    `mapstructure:"max_idle_conns"`
+	ConnMaxLifetimeSec int    `mapstructure:"conn_max_lifetime_sec"`
+	LogLevel           string `mapstructure:"log_level"` // silent / error / warn / info
+}
+
+// WalletConfig 定義錢包的儲存方式
+type WalletConfig struct {
+	Store          string `mapstructure:"store"`           // mock (預設，記憶體) / mysql
+	InitialBalance string `mapstructure:"initial_balance"` // 新錢包的初始餘額 (Decimal string)
+}
+
+// UserStoreConfig 定義使用者資料的儲存方式
+type UserStoreConfig struct {
+	Store       string `mapstructure:"store"`         // redis (預設，資料僅存活一小時) / mysql
+	CacheTTLSec int    `mapstructure:"cache_ttl_sec"` // mysql 模式下 Redis Read-Through 快取秒數 (0 代表停用快取)
 }
 
 type WSSConfig struct {
-	Path            string   `mapstructure:"path"`
-	AllowedOrigins  []string `mapstructure:"allowed_origins"`
-	ReadBufferSize  int      `mapstructure:"read_buffer_size"`
-	WriteBufferSize int      `mapstructure:"write_buffer_size"`
-	WriteWaitSec    int      `mapstructure:"write_wait_sec"`
-	PongWaitSec     int      `mapstructure:"pong_wait_sec"`
-	MaxMessageSize  int64    `mapstructure:"max_message_size"`
+	Path               string          `mapstructure:"path"`
+	AllowedOrigins     []string        `mapstructure:"allowed_origins"`
+	ReadBufferSize     int             `mapstructure:"read_buffer_size"`
+	WriteBufferSize    int             `mapstructure:"write_buffer_size"`
+	WriteWaitSec       int             `mapstructure:"write_wait_sec"`
+	PongWaitSec        int             `mapstructure:"pong_wait_sec"`
+	MaxMessageSize     int64           `mapstructure:"max_message_size"`
+	ResumeGraceSec     int             `mapstructure:"resume_grace_sec"`     // 斷線後可恢復會話的保留秒數 (0 代表停用)
+	SendQueueSize      int             `mapstructure:"send_queue_size"`      // 每條連線的發送佇列長度 (0 代表預設 256)
+	OverflowPolicy     string          `mapstructure:"overflow_policy"`      // 發送佇列已滿時的策略: drop_oldest / kick
+	GameStream         bool            `mapstructure:"game_stream"`          // 與 Game Server 之間使用雙向串流轉發訊息 (不可用時退回 Unary)
+	Presence           bool            `mapstructure:"presence"`             // 將在線狀態寫入 Redis (Central GetPresence / ListOnline)，需 Redis Session DB
+	ClusterPush        bool            `mapstructure:"cluster_push"`         // 訂閱叢集推播 (Central PushToUser / BroadcastAll)，會同時啟用在線狀態
+	MaintenancePollSec int             `mapstructure:"maintenance_poll_sec"` // 向 Central 查詢維護排程的間隔秒數 (推送維護通知與開始時踢除玩家，0 代表停用)
+	RateLimit          RateLimitConfig `mapstructure:"rate_limit"`
+}
+
+// RateLimitConfig 定義 Connector 的流量限制 (Token Bucket，各項為 0 代表不限制)
+type RateLimitConfig struct {
+	MessagesPerSec  float64            `mapstructure:"messages_per_sec"` // 每個會話、每個指令的每秒訊息數
+	MessageBurst    int                `mapstructure:"message_burst"`    // 訊息的突發上限 (0 代表與每秒訊息數相同)
+	ActionRates     map[string]float64 `mapstructure:"action_rates"`     // 個別指令的每秒訊息數，覆蓋 messages_per_sec
+	MaxConnsPerIP   int                `mapstructure:"max_conns_per_ip"` // 同一 IP 的同時連線數上限
+	LoginsPerMin    float64            `mapstructure:"logins_per_min"`   // 同一 IP、同一 Token 每分鐘的登入 (含 Resume) 次數
+	LoginBurst      int                `mapstructure:"login_burst"`      // 登入的突發上限 (0 代表與每分鐘次數相同)
+	KickAfter       int                `mapstructure:"kick_after"`       // 同一會話累計違規達此次數時踢除 (0 代表只回傳錯誤)
+	ForwardedHeader string             `mapstructure:"forwarded_header"` // 信任的來源 IP 標頭 (ex: X-Forwarded-For)，空字串代表使用連線位址
 }
 
 // Load 讀取設定檔
@@ -78,6 +182,12 @@ func Load(configPath ...string) (*Config, error) {
 
 	// Set Defaults
 	v.SetDefault("app.grpc_port", DefaultGrpcPort)
+	v.SetDefault("app.version", "")
+	v.SetDefault("app.metrics_port", DefaultMetricsPort)
+	v.SetDefault("tracing.exporter", "")
+	v.SetDefault("tracing.endpoint", "")
+	v.SetDefault("tracing.insecure", true)
+	v.SetDefault("tracing.sample_ratio", 1.0)
 	v.SetDefault("services", map[string]string{
 		"central": DefaultCentralAddr,
 	})
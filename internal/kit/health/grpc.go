@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC Health Service 可查詢的服務名稱
+const (
+	// ServiceReadiness 空字串代表整體狀態 (標準用法)，對應 Readiness
+	ServiceReadiness = ""
+	// ServiceLiveness 對應 Liveness
+	ServiceLiveness = "liveness"
+)
+
+// watchInterval Watch 重新檢查狀態的間隔
+const watchInterval = time.Second
+
+// RegisterGRPC 將標準 gRPC Health Service (grpc.health.v1.Health) 註冊到 gRPC Server
+// K8s 可直接使用 grpc Probe，或以 grpc_health_probe 查詢
+func (c *Checker) RegisterGRPC(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, &grpcServer{checker: c})
+}
+
+// grpcServer 以 Checker 的即時檢查結果實作 grpc.health.v1.Health
+type grpcServer struct {
+	healthpb.UnimplementedHealthServer
+	checker *Checker
+}
+
+func (g *grpcServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, ok := g.status(ctx, req.Service)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+func (g *grpcServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	statuses := make(map[string]*healthpb.HealthCheckResponse, 2)
+	for _, service := range []string{ServiceReadiness, ServiceLiveness} {
+		st, _ := g.status(ctx, service)
+		statuses[service] = &healthpb.HealthCheckResponse{Status: st}
+	}
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch 先送出目前狀態，之後每秒重新檢查並於狀態改變時送出
+func (g *grpcServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		st, ok := g.status(stream.Context(), req.Service)
+		if !ok {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// status 將檢查結果轉為 gRPC Health 狀態，未知的服務名稱回傳 false
+func (g *grpcServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	var report Report
+	switch service {
+	case ServiceReadiness:
+		report = g.checker.Readiness(ctx)
+	case ServiceLiveness:
+		report = g.checker.Liveness(ctx)
+	default:
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+	if report.Healthy {
+		return healthpb.HealthCheckResponse_SERVING, true
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING, true
+}
+
+// GRPCCheck 回傳以標準 gRPC Health Service 查詢遠端服務的檢查 (ex: Central 可達且就緒)
+func GRPCCheck(conn grpc.ClientConnInterface) Check {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: ServiceReadiness})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return status.Errorf(codes.Unavailable, "remote status %s", resp.Status)
+		}
+		return nil
+	}
+}
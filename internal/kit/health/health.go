@@ -0,0 +1,158 @@
+// Package health 提供各服務共用的健康檢查 (Liveness / Readiness)
+//
+// 服務啟動時註冊實際依賴的檢查 (Redis、Central、Registrar 租約、WebSocket Hub ...)，
+// 同時以 HTTP (/healthz、/readyz，掛在 Metrics Port) 與標準 gRPC Health Service 對外提供。
+// 進入排空或關機流程後 Readiness 一律回報失敗，讓 K8s 停止導入新流量。
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrDraining 服務排空或關機中，不再接受新流量
+var ErrDraining = errors.New("health: draining")
+
+// defaultCheckTimeout 單一檢查的逾時
+const defaultCheckTimeout = 2 * time.Second
+
+// Check 回傳 nil 代表健康
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker 管理已註冊的 Liveness / Readiness 檢查 (Thread-Safe)
+type Checker struct {
+	mu        sync.RWMutex
+	liveness  []namedCheck
+	readiness []namedCheck
+	draining  atomic.Bool
+	timeout   time.Duration
+}
+
+// Option 定義 Checker 的配置選項
+type Option func(*Checker)
+
+// WithTimeout 設定單一檢查的逾時 (預設 2 秒)
+func WithTimeout(d time.Duration) Option {
+	return func(c *Checker) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// NewChecker 建立 Checker (未註冊任何檢查時 Liveness / Readiness 皆為健康)
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{timeout: defaultCheckTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// AddLiveness 註冊存活檢查: 失敗代表程序已無法自行恢復，K8s 會重啟 Pod
+// 只應放入程序內部狀態的檢查，外部依賴請使用 AddReadiness
+func (c *Checker) AddLiveness(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.liveness = append(c.liveness, namedCheck{name: name, check: check})
+}
+
+// AddReadiness 註冊就緒檢查: 失敗代表暫時不應接收新流量 (ex: Redis、Central 無法連線)
+func (c *Checker) AddReadiness(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readiness = append(c.readiness, namedCheck{name: name, check: check})
+}
+
+// SetDraining 標記服務進入排空/關機 (之後 Readiness 一律失敗，Liveness 不受影響)
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Draining 是否已進入排空/關機
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Report 一次健康檢查的結果
+type Report struct {
+	Healthy bool              `json:"healthy"`
+	Checks  map[string]string `json:"checks,omitempty"` // 檢查名稱 -> "ok" 或錯誤訊息
+}
+
+// Liveness 執行所有存活檢查
+func (c *Checker) Liveness(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := c.liveness
+	c.mu.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// Readiness 執行所有就緒檢查 (排空中直接回報失敗)
+func (c *Checker) Readiness(ctx context.Context) Report {
+	if c.Draining() {
+		return Report{Healthy: false, Checks: map[string]string{"drain": ErrDraining.Error()}}
+	}
+	c.mu.RLock()
+	checks := c.readiness
+	c.mu.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// run 並行執行檢查，每個檢查各自受 timeout 限制
+func (c *Checker) run(ctx context.Context, checks []namedCheck) Report {
+	report := Report{Healthy: true, Checks: make(map[string]string, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			err := nc.check(checkCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Healthy = false
+				report.Checks[nc.name] = err.Error()
+			} else {
+				report.Checks[nc.name] = "ok"
+			}
+		}(nc)
+	}
+	wg.Wait()
+	return report
+}
+
+// Mount 將 /healthz (Liveness) 與 /readyz (Readiness) 掛到 mux
+// 健康時回應 200，否則回應 503，Body 為各檢查結果 (JSON)
+func (c *Checker) Mount(mux *http.ServeMux) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, c.Liveness(r.Context()))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, c.Readiness(r.Context()))
+	})
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if report.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(report)
+}
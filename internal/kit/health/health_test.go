@@ -0,0 +1,104 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/health"
+)
+
+// TestChecker_Readiness 測試就緒檢查的彙總結果與排空後一律失敗
+func TestChecker_Readiness(t *testing.T) {
+	checker := health.NewChecker()
+	redisErr := errors.New("connection refused")
+	var redisDown bool
+	checker.AddReadiness("redis", func(context.Context) error {
+		if redisDown {
+			return redisErr
+		}
+		return nil
+	})
+	checker.AddLiveness("hub", func(context.Context) error { return nil })
+
+	report := checker.Readiness(context.Background())
+	assert.True(t, report.Healthy)
+	assert.Equal(t, map[string]string{"redis": "ok"}, report.Checks)
+
+	redisDown = true
+	report = checker.Readiness(context.Background())
+	assert.False(t, report.Healthy)
+	assert.Equal(t, redisErr.Error(), report.Checks["redis"])
+
+	redisDown = false
+	checker.SetDraining()
+	assert.False(t, checker.Readiness(context.Background()).Healthy)
+	// 排空不影響存活
+	assert.True(t, checker.Liveness(context.Background()).Healthy)
+}
+
+// TestChecker_HTTP 測試 /healthz 與 /readyz 的狀態碼
+func TestChecker_HTTP(t *testing.T) {
+	checker := health.NewChecker()
+	checker.AddReadiness("central", func(context.Context) error { return errors.New("unreachable") })
+
+	mux := http.NewServeMux()
+	checker.Mount(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unreachable")
+}
+
+// TestChecker_GRPC 測試標準 gRPC Health Service 與 GRPCCheck
+func TestChecker_GRPC(t *testing.T) {
+	checker := health.NewChecker()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	checker.RegisterGRPC(server)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx := context.Background()
+	client := healthpb.NewHealthClient(conn)
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	assert.NoError(t, health.GRPCCheck(conn)(ctx))
+
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Error(t, err)
+
+	checker.SetDraining()
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	assert.Error(t, health.GRPCCheck(conn)(ctx))
+
+	resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: health.ServiceLiveness})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
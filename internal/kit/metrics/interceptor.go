@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor 記錄每次 Unary RPC 的延遲與結果
+// 透過 grpcpkg.WithInterceptor 套用到 Pool 建立的所有連線
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		GRPCClientDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+		GRPCClientRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+		return err
+	}
+}
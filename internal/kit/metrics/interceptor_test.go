@@ -0,0 +1,33 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/metrics"
+)
+
+// TestUnaryClientInterceptor 測試攔截器依方法與狀態碼記錄 RPC 結果
+func TestUnaryClientInterceptor(t *testing.T) {
+	interceptor := metrics.UnaryClientInterceptor()
+	const method = "/test.Service/Call"
+
+	ok := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return nil }
+	fail := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	assert.NoError(t, interceptor(context.Background(), method, nil, nil, nil, ok))
+	err := interceptor(context.Background(), method, nil, nil, nil, fail)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.GRPCClientRequests.WithLabelValues(method, "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.GRPCClientRequests.WithLabelValues(method, "Unavailable")))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.GRPCClientDuration))
+}
@@ -0,0 +1,188 @@
+// Package metrics 定義各服務共用的 Prometheus 指標，並提供 /metrics HTTP 端點
+//
+// 指標皆註冊於 Prometheus 預設 Registry (同時包含 Go Runtime 與 Process 指標)，
+// 各服務依自身角色更新對應的指標，未使用的指標不會有任何樣本。
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "game"
+
+// Connector 指標
+var (
+	// ConnectorSessions 目前連線中的 WebSocket 會話數
+	ConnectorSessions = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "connector",
+		Name:      "sessions",
+		Help:      "Number of connected WebSocket sessions.",
+	})
+
+	// ConnectorLogins 登入次數 (result: success / invalid_payload / maintenance / auth_failed / session_limit / error ...)
+	ConnectorLogins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connector",
+		Name:      "logins_total",
+		Help:      "Login attempts by result.",
+	}, []string{"result"})
+
+	// ConnectorRateLimited 超過流量限制而被拒絕的次數 (kind: message / connection / login)
+	ConnectorRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connector",
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected by connector rate limits by kind.",
+	}, []string{"kind"})
+
+	// ConnectorForwarded 轉發給 Game Server 的訊息數 (result: ok / error)
+	// 以 game_id 區分而非 Pod Endpoint，避免 Pod 重新排程時產生新的時間序列 (Endpoint 記錄於 Trace 與 Log)
+	ConnectorForwarded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "connector",
+		Name:      "forwarded_messages_total",
+		Help:      "Client messages forwarded to game servers by game ID and result.",
+	}, []string{"game_id", "result"})
+
+	// ConnectorForwardDuration 轉發訊息的 RPC 延遲 (含串流與 Unary)
+	ConnectorForwardDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "connector",
+		Name:      "forward_duration_seconds",
+		Help:      "Latency of forwarding a client message to a game server by game ID.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"game_id"})
+)
+
+// Central 指標
+var (
+	// CentralRouteDuration GetRoute 處理延遲
+	CentralRouteDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "central",
+		Name:      "route_duration_seconds",
+		Help:      "Latency of GetRoute by game ID.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"game_id"})
+
+	// CentralRouteFailures GetRoute 失敗次數 (reason: maintenance / not_found / error)
+	CentralRouteFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "central",
+		Name:      "route_failures_total",
+		Help:      "Failed GetRoute calls by game ID and reason.",
+	}, []string{"game_id", "reason"})
+)
+
+// Engine 指標
+var (
+	// EngineHookDuration GameHandler / RoomHandler Hook 的執行時間 (不含等待 Tick Loop 的時間)
+	EngineHookDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "hook_duration_seconds",
+		Help:      "Execution time of game handler hooks by service and hook.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "hook"})
+)
+
+// gRPC Client 指標 (由 UnaryClientInterceptor 產生)
+var (
+	// GRPCClientRequests 已完成的 Unary RPC 數 (code: gRPC Status Code)
+	GRPCClientRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_client",
+		Name:      "requests_total",
+		Help:      "Completed unary RPCs by method and status code.",
+	}, []string{"method", "code"})
+
+	// GRPCClientDuration Unary RPC 延遲
+	GRPCClientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc_client",
+		Name:      "duration_seconds",
+		Help:      "Latency of unary RPCs by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// GaugeFunc 註冊一個於抓取時才計算數值的 Gauge (ex: 佇列深度、租約數)
+// 同名指標重複註冊時 (ex: 測試中重複初始化) 忽略後者
+func GaugeFunc(subsystem, name, help string, fn func() float64) {
+	register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// CounterFunc 註冊一個於抓取時才讀取數值的 Counter (數值需單調遞增)
+func CounterFunc(subsystem, name, help string, fn func() float64) {
+	register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+func register(c prometheus.Collector) {
+	if err := prometheus.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if !errors.As(err, &already) {
+			slog.Warn("Failed to register metric", "error", err)
+		}
+	}
+}
+
+// Handler 回傳輸出所有指標的 HTTP Handler
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve 在 port 上啟動 /metrics HTTP Server (背景執行)
+// mounts 可在同一 Port 掛上其他內部端點 (ex: health.Checker.Mount 的 /healthz、/readyz)
+// port 為 0 時不啟動並回傳 nil；呼叫端於關機時呼叫 Shutdown (可傳入 nil)
+func Serve(port int, mounts ...func(mux *http.ServeMux)) *http.Server {
+	if port == 0 {
+		return nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	for _, mount := range mounts {
+		mount(mux)
+	}
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		slog.Info("Metrics listening", "addr", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Metrics server failed", "error", err)
+		}
+	}()
+	return server
+}
+
+// Shutdown 關閉 Serve 啟動的 HTTP Server (server 為 nil 時不做任何事)
+func Shutdown(server *http.Server) {
+	if server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctx)
+}
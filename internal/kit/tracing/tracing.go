@@ -0,0 +1,181 @@
+// Package tracing 初始化各服務共用的 OpenTelemetry 分散式追蹤
+//
+// 一則玩家訊息的追蹤從 Connector 收到 WebSocket 訊框開始，
+// 經由 gRPC Metadata (Unary) 或 PacketHeader.trace_context (串流) 傳遞到 Game Server 的 Handler，
+// Game Server 透過 Peer.Send 推播時再以 ChannelPush.trace_context (或 gRPC Metadata) 帶回 Connector。
+// 未呼叫 Init (或 Exporter 為空) 時使用 OpenTelemetry 預設的 No-op Provider，所有 Span 皆不會輸出。
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/config"
+)
+
+const instrumentationName = "github.com/JoeShih716/go-k8s-game-server"
+
+// Span 屬性
+const (
+	AttrUserID    = attribute.Key("game.user_id")
+	AttrSessionID = attribute.Key("game.session_id")
+	AttrGameID    = attribute.Key("game.game_id")
+	AttrAction    = attribute.Key("game.action")
+	AttrService   = attribute.Key("game.service")
+	AttrEndpoint  = attribute.Key("game.endpoint")
+)
+
+// 可用的 Exporter
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+func init() {
+	// 即使未啟用輸出，仍需傳遞上游的追蹤上下文 (ex: Connector 停用追蹤但 Game Server 啟用)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Init 依設定建立 TracerProvider 並設為全域 Provider
+// Exporter 為空時不啟用並回傳 nil；呼叫端於關機時呼叫 Shutdown (可傳入 nil) 送出緩衝中的 Span
+func Init(ctx context.Context, serviceName, version string, cfg config.TracingConfig) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "", "none":
+		return nil, nil
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: failed to create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(serviceName)}
+	if version != "" {
+		attrs = append(attrs, semconv.ServiceVersion(version))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attrs...))
+	if err != nil {
+		return nil, fmt.Errorf("tracing: failed to build resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio(cfg.SampleRatio)))),
+	)
+	otel.SetTracerProvider(provider)
+	slog.Info("Tracing enabled", "exporter", cfg.Exporter, "endpoint", cfg.Endpoint, "sample_ratio", sampleRatio(cfg.SampleRatio))
+	return provider, nil
+}
+
+// Shutdown 送出緩衝中的 Span 並關閉 Provider (provider 為 nil 時不做任何事)
+func Shutdown(provider *sdktrace.TracerProvider) {
+	if provider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := provider.Shutdown(ctx); err != nil {
+		slog.Warn("Failed to flush traces", "error", err)
+	}
+}
+
+// sampleRatio 將取樣比例限制在 (0, 1]，未設定 (0) 視為全部取樣
+func sampleRatio(ratio float64) float64 {
+	if ratio <= 0 || ratio > 1 {
+		return 1
+	}
+	return ratio
+}
+
+// Tracer 回傳本專案使用的 Tracer (每次呼叫皆取自目前的全域 Provider)
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start 建立子 Span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End 結束 Span，err 不為 nil 時標記為錯誤
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Inject 將 ctx 的追蹤上下文寫成可放入訊框的 Map (無追蹤時回傳 nil)
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract 從訊框的追蹤上下文還原遠端的 Span (carrier 為空時回傳原 ctx)
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// TraceID 回傳 ctx 中的 Trace ID (無有效的追蹤時回傳空字串)
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// ServerOption 回傳為 gRPC Server 的每個 RPC 建立 Span 的選項 (從 gRPC Metadata 延續上游追蹤)
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(skipChannel)))
+}
+
+// DialOption 回傳為 gRPC Client 的每個 RPC 建立 Span 並將追蹤上下文寫入 gRPC Metadata 的選項
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithFilter(skipChannel)))
+}
+
+// skipChannel 不追蹤 GameRPC.Channel 串流本身 (存活期間與連線相同)，串流內的每則訊息以訊框的追蹤上下文各自延續
+func skipChannel(info *stats.RPCTagInfo) bool {
+	return !strings.HasSuffix(info.FullMethodName, "/Channel")
+}
@@ -0,0 +1,46 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/config"
+	"github.com/JoeShih716/go-k8s-game-server/internal/kit/tracing"
+)
+
+// TestInit 測試未設定 Exporter 時不啟用，未知的 Exporter 回傳錯誤
+func TestInit(t *testing.T) {
+	provider, err := tracing.Init(context.Background(), "test", "", config.TracingConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+	tracing.Shutdown(provider) // nil 安全
+
+	_, err = tracing.Init(context.Background(), "test", "", config.TracingConfig{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
+
+// TestInjectExtract 測試追蹤上下文寫入訊框後可在另一端還原
+func TestInjectExtract(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	// 無追蹤時不產生 Carrier
+	assert.Nil(t, tracing.Inject(context.Background()))
+	assert.Empty(t, tracing.TraceID(context.Background()))
+
+	ctx, span := tracing.Start(context.Background(), "frame")
+	defer span.End()
+
+	carrier := tracing.Inject(ctx)
+	require.Contains(t, carrier, "traceparent")
+
+	remote := tracing.Extract(context.Background(), carrier)
+	assert.Equal(t, tracing.TraceID(ctx), tracing.TraceID(remote))
+	assert.Len(t, tracing.TraceID(remote), 32)
+}
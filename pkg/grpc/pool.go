@@ -17,6 +17,7 @@ type Pool struct {
 	conns       sync.Map // map[string]*grpc.ClientConn
 	mu          sync.Mutex
 	interceptor grpc.UnaryClientInterceptor // 全局的單一請求攔截器 (Optional)
+	dialOpts    []grpc.DialOption           // 全局的額外連線選項 (Optional)
 }
 
 // PoolOption 定義了 Pool 的配置選項函數
@@ -30,6 +31,14 @@ func WithInterceptor(interceptor grpc.UnaryClientInterceptor) PoolOption {
 	}
 }
 
+// WithDialOptions 設定 Pool 建立每條連線時附加的 gRPC 連線選項
+// 用於 StatsHandler (e.g. Tracing) 等無法以 UnaryClientInterceptor 表達的設定。
+func WithDialOptions(opts ...grpc.DialOption) PoolOption {
+	return func(p *Pool) {
+		p.dialOpts = append(p.dialOpts, opts...)
+	}
+}
+
 // NewPool 建立並回傳一個新的 gRPC 連線池。
 // 可以傳入多個 PoolOption 來配置連線池。
 func NewPool(opts ...PoolOption) *Pool {
@@ -96,6 +105,7 @@ func (p *Pool) GetConnection(target string, opts ...grpc.DialOption) (*grpc.Clie
 		defaultOpts = append(defaultOpts, grpc.WithUnaryInterceptor(p.interceptor))
 	}
 
+	defaultOpts = append(defaultOpts, p.dialOpts...)
 	finalOpts := append(defaultOpts, opts...)
 
 	// 注意: 在新版 gRPC 中，grpc.NewClient 取代了 DialContext
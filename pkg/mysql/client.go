@@ -29,7 +29,9 @@ func NewClient(cfg Config) (*Client, error) {
 		// 預設跳過事務模式，顯著提升寫入效能 (除非業務邏輯明確需要 Transaction)
 		// 對於遊戲 Log 或狀態更新這類高頻操作很有幫助
 		SkipDefaultTransaction: true,
-		Logger:                 newLogger(cfg.LogLevel),
+		// 將 Driver 錯誤轉換為 GORM 通用錯誤 (e.g. gorm.ErrDuplicatedKey)，方便判斷唯一索引衝突
+		TranslateError: true,
+		Logger:         newLogger(cfg.LogLevel),
 	}
 
 	var db *gorm.DB
@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -71,6 +72,11 @@ func (c *Client) Close() error {
 	return c.rdb.Close()
 }
 
+// Ping 檢查 Redis 連線是否可用 (用於健康檢查)
+func (c *Client) Ping(ctx context.Context) error {
+	return c.rdb.Ping(ctx).Err()
+}
+
 // SetStruct 將結構體序列化為 JSON 並儲存到 Redis
 //
 // 參數:
@@ -93,6 +99,50 @@ func (c *Client) SetStruct(ctx context.Context, key string, value any, expiratio
 	return c.rdb.Set(ctx, key, data, exp).Err()
 }
 
+// SetStructNX 僅在 Key 不存在時將結構體序列化為 JSON 並儲存 (使用 SETNX)
+//
+// 參數:
+//
+//	ctx: context.Context - 上下文
+//	key: string - Redis 鍵
+//	value: any - 要儲存的結構體 (必須能被 json.Marshal)
+//	expiration: time.Duration - 過期時間 (0 代表不過期)
+//
+// 回傳值:
+//
+//	bool: 是否寫入 (Key 已存在時為 false)
+//	error: Redis 系統錯誤
+func (c *Client) SetStructNX(ctx context.Context, key string, value any, expiration time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	return c.rdb.SetNX(ctx, key, data, expiration).Result()
+}
+
+// SetStructs 以 Pipeline 批次將多個結構體序列化為 JSON 並儲存 (一次往返)
+//
+// 參數:
+//
+//	ctx: context.Context - 上下文
+//	values: map[string]any - Redis 鍵與對應的結構體
+//	expiration: time.Duration - 過期時間 (0 代表不過期)
+func (c *Client) SetStructs(ctx context.Context, values map[string]any, expiration time.Duration) error {
+	if len(values) == 0 {
+		return nil
+	}
+	pipe := c.rdb.Pipeline()
+	for key, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value: %w", err)
+		}
+		pipe.Set(ctx, key, data, expiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // GetStruct 從 Redis 讀取 JSON 並反序列化為結構體
 //
 // 參數:
@@ -103,7 +153,7 @@ func (c *Client) SetStruct(ctx context.Context, key string, value any, expiratio
 func (c *Client) GetStruct(ctx context.Context, key string, dest any) error {
 	val, err := c.rdb.Get(ctx, key).Result()
 	if err == redis.Nil {
-		return fmt.Errorf("key not found: %s", key)
+		return fmt.Errorf("key not found: %s: %w", key, redis.Nil)
 	} else if err != nil {
 		return err
 	}
@@ -175,6 +225,16 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return c.rdb.Get(ctx, key).Result()
 }
 
+// MGet 一次取得多個 Key 的值 (不存在的 Key 對應 nil)
+func (c *Client) MGet(ctx context.Context, keys ...string) ([]any, error) {
+	return c.rdb.MGet(ctx, keys...).Result()
+}
+
+// GetDel 取得 Key-Value 並立即刪除 (原子操作)
+func (c *Client) GetDel(ctx context.Context, key string) (string, error) {
+	return c.rdb.GetDel(ctx, key).Result()
+}
+
 // Del 刪除 Key
 func (c *Client) Del(ctx context.Context, keys ...string) error {
 	return c.rdb.Del(ctx, keys...).Err()
@@ -223,7 +283,48 @@ func (c *Client) SRandMember(ctx context.Context, key string) (string, error) {
 	return c.rdb.SRandMember(ctx, key).Result()
 }
 
-// IsNil 檢查是否為 Redis Key 不存在錯誤
+// -----------------------------------------------------------
+// Sorted Set Commands
+// -----------------------------------------------------------
+
+// ZAdd 加入有序集合 (score 相同成員會被覆蓋)
+func (c *Client) ZAdd(ctx context.Context, key string, score float64, member any) error {
+	return c.rdb.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err()
+}
+
+// ZRem 移除有序集合成員，回傳實際移除的數量
+// 可用於多個實例競爭同一成員時的原子性認領 (回傳 1 者勝出)
+func (c *Client) ZRem(ctx context.Context, key string, members ...any) (int64, error) {
+	return c.rdb.ZRem(ctx, key, members...).Result()
+}
+
+// ZRangeByScore 取得分數介於 [min, max] 的成員 (依分數由小到大)
+func (c *Client) ZRangeByScore(ctx context.Context, key string, min, max string) ([]string, error) {
+	return c.rdb.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: min, Max: max}).Result()
+}
+
+// -----------------------------------------------------------
+// Scripting
+// -----------------------------------------------------------
+
+// Eval 執行 Lua Script (Script 內的多個指令以原子方式執行)
+//
+// 參數:
+//
+//	ctx: context.Context - 上下文
+//	script: string - Lua Script
+//	keys: []string - Script 存取的鍵名 (KEYS)
+//	args: ...any - Script 參數 (ARGV)
+//
+// 回傳值:
+//
+//	any: Script 的回傳值
+//	error: Redis 系統錯誤
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	return c.rdb.Eval(ctx, script, keys, args...).Result()
+}
+
+// IsNil 檢查是否為 Redis Key 不存在錯誤 (包含 GetStruct 回傳的錯誤)
 func IsNil(err error) bool {
-	return err == redis.Nil
+	return errors.Is(err, redis.Nil)
 }
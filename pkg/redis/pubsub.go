@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // MessageHandler 定義訂閱訊息的處理函式類型
@@ -60,3 +62,64 @@ func (c *Client) Subscribe(ctx context.Context, channel string, handler MessageH
 
 	return nil
 }
+
+// ChannelMessageHandler 定義多頻道訂閱的處理函式類型
+//
+// 參數:
+//
+//	channel: string - 訊息來源頻道
+//	payload: string - 接收到的訊息內容
+type ChannelMessageHandler func(channel, payload string)
+
+// Subscription 可動態增減頻道的訂閱，所有頻道共用同一條 Redis 連線
+type Subscription struct {
+	pubsub *redis.PubSub
+}
+
+// SubscribeChannels 訂閱多個頻道，之後可透過 Add / Remove 動態調整
+// 此方法會啟動一個背景 goroutine 來處理接收到的訊息，直到 Subscription.Close 被呼叫。
+//
+// 參數:
+//
+//	ctx: context.Context - 上下文
+//	handler: ChannelMessageHandler - 訊息處理函式
+//	channels: ...string - 初始訂閱的頻道
+//
+// 回傳值:
+//
+//	*Subscription: 訂閱物件
+//	error: 若訂閱失敗則回傳錯誤
+func (c *Client) SubscribeChannels(ctx context.Context, handler ChannelMessageHandler, channels ...string) (*Subscription, error) {
+	pubsub := c.rdb.Subscribe(ctx, channels...)
+
+	// 有初始頻道時等待訂閱確認
+	if len(channels) > 0 {
+		if _, err := pubsub.Receive(ctx); err != nil {
+			_ = pubsub.Close()
+			return nil, err
+		}
+	}
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			handler(msg.Channel, msg.Payload)
+		}
+	}()
+
+	return &Subscription{pubsub: pubsub}, nil
+}
+
+// Add 追加訂閱頻道
+func (s *Subscription) Add(ctx context.Context, channels ...string) error {
+	return s.pubsub.Subscribe(ctx, channels...)
+}
+
+// Remove 取消訂閱頻道
+func (s *Subscription) Remove(ctx context.Context, channels ...string) error {
+	return s.pubsub.Unsubscribe(ctx, channels...)
+}
+
+// Close 關閉訂閱 (背景 goroutine 隨之結束)
+func (s *Subscription) Close() error {
+	return s.pubsub.Close()
+}
@@ -1,6 +1,16 @@
 package wss
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	// ErrSendQueueFull 連線的發送佇列已滿 (慢速消費者)，訊息未被放入佇列。
+	ErrSendQueueFull = errors.New("wss: send queue full")
+	// ErrConnectionClosed 連線已關閉，無法再發送訊息。
+	ErrConnectionClosed = errors.New("wss: connection closed")
+)
 
 // Client 定義了客戶端連線對外暴露的行為。
 // 業務邏輯層將依賴此介面，而非具體的 Connection 實作。
@@ -9,8 +19,13 @@ import "net/http"
 type Client interface {
 	// ID 返回客戶端的唯一標識符。
 	ID() string
-	// SendMessage 發送文字訊息給客戶端。
+	// SendMessage 發送文字訊息給客戶端 (非阻塞)。
+	// 佇列已滿時依 Config.OverflowPolicy 處理，無法放入佇列時回傳 ErrSendQueueFull；連線已關閉回傳 ErrConnectionClosed。
 	SendMessage(message string) error
+	// SendBinary 發送二進位訊息給客戶端 (非阻塞)，錯誤語意同 SendMessage。
+	SendBinary(data []byte) error
+	// Subprotocol 返回升級時協商出的 WebSocket 子協議 (未協商則為空字串)。
+	Subprotocol() string
 	// Kick 中斷與客戶端的連線。
 	Kick(reason string) error
 	// RemoteAddr 返回客戶端的網路位址。
@@ -23,4 +38,6 @@ type Client interface {
 	SetTag(key string, value any)
 	// GetTag 根據鍵名讀取之前用 SetTag 附加的資料。
 	GetTag(key string) (value any, exists bool)
+	// DelTag 移除之前用 SetTag 附加的資料。
+	DelTag(key string)
 }
@@ -2,13 +2,30 @@ package wss
 
 import "time"
 
+// OverflowPolicy 定義發送佇列已滿時的處理策略。
+type OverflowPolicy string
+
+const (
+	// OverflowDropOldest 丟棄佇列中最舊的訊息，為新訊息騰出空間 (預設)。
+	// 新訊息一定會放入佇列，被丟棄的舊訊息不會回報給呼叫端 (只記錄於 Stats)。
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowKick 視為慢速消費者，拒絕新訊息 (回傳 ErrSendQueueFull) 並中斷該連線。
+	OverflowKick OverflowPolicy = "kick"
+)
+
+// DefaultSendQueueSize 未設定 SendQueueSize 時每條連線的發送佇列長度。
+const DefaultSendQueueSize = 256
+
 // Config 定義了 WebSocket 伺服器的所有可設定參數。
 type Config struct {
-	WriteWait       time.Duration // 寫入操作的超時時間
-	PongWait        time.Duration // 等待 Pong 訊息的超時時間
-	PingPeriod      time.Duration // 發送 Ping 訊息的間隔
-	MaxMessageSize  int64         // 允許接收的最大訊息大小
-	ReadBufferSize  int           // 讀取緩衝區的大小
-	WriteBufferSize int           // 寫入緩衝區的大小
-	AllowedOrigins  []string      // 允許進行跨域請求的來源列表 (e.g., "http://localhost:3000")
+	WriteWait       time.Duration  // 寫入操作的超時時間
+	PongWait        time.Duration  // 等待 Pong 訊息的超時時間
+	PingPeriod      time.Duration  // 發送 Ping 訊息的間隔
+	MaxMessageSize  int64          // 允許接收的最大訊息大小
+	ReadBufferSize  int            // 讀取緩衝區的大小
+	WriteBufferSize int            // 寫入緩衝區的大小
+	AllowedOrigins  []string       // 允許進行跨域請求的來源列表 (e.g., "http://localhost:3000")
+	Subprotocols    []string       // 伺服器支援的子協議 (依優先順序)，用於與客戶端協商封包格式
+	SendQueueSize   int            // 每條連線的發送佇列長度 (0 代表使用 DefaultSendQueueSize)
+	OverflowPolicy  OverflowPolicy // 發送佇列已滿時的處理策略 (空字串代表 OverflowDropOldest)
 }
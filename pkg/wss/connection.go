@@ -10,18 +10,29 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+// outboundMessage 是放入發送佇列的訊息，包含 WebSocket 訊息類型 (Text/Binary)。
+type outboundMessage struct {
+	msgType int
+	data    []byte
+}
+
 // connection 是 Client 介面的具體實現，負責管理底層 WebSocket 連線。
 type connection struct {
-	id         string
-	hub        *hub
-	conn       *websocket.Conn
-	send       chan []byte
-	mu         sync.Mutex
-	remoteAddr string
-	headers    http.Header
-	tags       map[string]any
-	tagsMutex  sync.RWMutex
-	logger     *slog.Logger
+	id          string
+	hub         *hub
+	conn        *websocket.Conn
+	send        chan outboundMessage
+	sendMu      sync.Mutex // 序列化佇列寫入與關閉，避免對已關閉的 channel 寫入
+	closed      bool       // send channel 是否已關閉 (受 sendMu 保護)
+	overflow    OverflowPolicy
+	kickOnce    sync.Once
+	mu          sync.Mutex
+	remoteAddr  string
+	headers     http.Header
+	subprotocol string
+	tags        map[string]any
+	tagsMutex   sync.RWMutex
+	logger      *slog.Logger
 }
 
 // 確保 connection 類型在編譯時期就實現了 Client 接口。
@@ -32,19 +43,30 @@ var _ Client = (*connection)(nil)
 // @param hub - 指向 hub 的指標，用於註冊和訊息傳遞。
 // @param conn - 底層的 websocket 連線。
 // @param r - 建立連線時的 HTTP 請求，用於獲取標頭和遠端位址。
+// @param cfg - WebSocket 伺服器的設定參數 (發送佇列長度與溢出策略)。
 // @param logger - 用於記錄日誌的 slog 實例。
 // @return *connection - 一個初始化完成的連線實例。
-func newConnection(hub *hub, conn *websocket.Conn, r *http.Request, logger *slog.Logger) *connection {
+func newConnection(hub *hub, conn *websocket.Conn, r *http.Request, cfg *Config, logger *slog.Logger) *connection {
 	clientID := generateClientID()
+	queueSize := cfg.SendQueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultSendQueueSize
+	}
+	overflow := cfg.OverflowPolicy
+	if overflow == "" {
+		overflow = OverflowDropOldest
+	}
 	return &connection{
-		id:         clientID,
-		hub:        hub,
-		conn:       conn,
-		send:       make(chan []byte, 256),
-		remoteAddr: r.RemoteAddr,
-		headers:    r.Header.Clone(), // 複製標頭以確保安全
-		tags:       make(map[string]any),
-		logger:     logger.With("clientID", clientID),
+		id:          clientID,
+		hub:         hub,
+		conn:        conn,
+		send:        make(chan outboundMessage, queueSize),
+		overflow:    overflow,
+		remoteAddr:  r.RemoteAddr,
+		headers:     r.Header.Clone(), // 複製標頭以確保安全
+		subprotocol: conn.Subprotocol(),
+		tags:        make(map[string]any),
+		logger:      logger.With("clientID", clientID),
 	}
 }
 
@@ -53,10 +75,85 @@ func (c *connection) ID() string {
 	return c.id
 }
 
-// SendMessage 將一則訊息放入發送佇列，由 writePump 異步發送。
+// SendMessage 將一則文字訊息放入發送佇列，由 writePump 異步發送。
 func (c *connection) SendMessage(message string) error {
-	c.send <- []byte(message)
-	return nil
+	return c.enqueue(outboundMessage{msgType: websocket.TextMessage, data: []byte(message)})
+}
+
+// SendBinary 將一則二進位訊息放入發送佇列，由 writePump 異步發送。
+func (c *connection) SendBinary(data []byte) error {
+	return c.enqueue(outboundMessage{msgType: websocket.BinaryMessage, data: data})
+}
+
+// enqueue 以非阻塞方式將訊息放入發送佇列。
+// 佇列已滿時依 overflow 策略丟棄最舊訊息，或拒絕並踢除慢速消費者。
+func (c *connection) enqueue(msg outboundMessage) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.closed {
+		return ErrConnectionClosed
+	}
+
+	select {
+	case c.send <- msg:
+		return nil
+	default:
+	}
+
+	c.countDropped()
+	if c.overflow == OverflowKick {
+		c.logger.Warn("send queue full, kicking slow consumer", "queue_size", cap(c.send))
+		c.kickSlowConsumer()
+		return ErrSendQueueFull
+	}
+
+	// OverflowDropOldest: 只有 writePump 會從佇列取出，持有 sendMu 時騰出的空位不會被搶走
+	select {
+	case <-c.send:
+		c.logger.Warn("send queue full, dropped oldest message", "queue_size", cap(c.send))
+	default:
+	}
+	select {
+	case c.send <- msg:
+		return nil
+	default:
+		return ErrSendQueueFull
+	}
+}
+
+// countDropped 累計因佇列已滿而丟棄或拒絕的訊息數 (供 Server.Stats 使用)。
+func (c *connection) countDropped() {
+	if c.hub != nil {
+		c.hub.dropped.Add(1)
+	}
+}
+
+// kickSlowConsumer 異步中斷慢速消費者的連線 (只執行一次)。
+// 關閉底層連線後 readPump 會結束並透過 hub 走正常的註銷流程。
+func (c *connection) kickSlowConsumer() {
+	c.kickOnce.Do(func() {
+		go func() {
+			if err := c.Kick("send queue overflow"); err != nil {
+				c.logger.Warn("kick slow consumer failed", "error", err)
+			}
+			_ = c.conn.Close()
+		}()
+	})
+}
+
+// closeSend 關閉發送佇列，通知 writePump 結束 (由 hub 在註銷時呼叫)。
+func (c *connection) closeSend() {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+// Subprotocol 返回升級時協商出的 WebSocket 子協議。
+func (c *connection) Subprotocol() string {
+	return c.subprotocol
 }
 
 // Kick 立即中斷與客戶端的連線。
@@ -96,6 +193,13 @@ func (c *connection) GetTag(key string) (value any, exists bool) {
 	return
 }
 
+// DelTag 移除之前用 SetTag 附加的資料。
+func (c *connection) DelTag(key string) {
+	c.tagsMutex.Lock()
+	defer c.tagsMutex.Unlock()
+	delete(c.tags, key)
+}
+
 // readPump 從 WebSocket 連線讀取訊息，並直接分派給註冊的 Subscriber。
 // 它會持續讀取客戶端訊息，直到連線關閉或發生錯誤。
 //
@@ -166,13 +270,13 @@ func (c *connection) writePump(cfg *Config) {
 				return
 			}
 
-			w, err := c.conn.NextWriter(websocket.TextMessage)
+			w, err := c.conn.NextWriter(message.msgType)
 			if err != nil {
 				c.logger.Warn("write pump failed on getting next writer", "error", err)
 				c.mu.Unlock()
 				return
 			}
-			_, err = w.Write(message)
+			_, err = w.Write(message.data)
 			if err != nil {
 				c.logger.Warn("write pump failed on writing message", "error", err)
 				c.mu.Unlock()
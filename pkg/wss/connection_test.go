@@ -0,0 +1,62 @@
+package wss
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func newTestConnection(queueSize int, policy OverflowPolicy) *connection {
+	return &connection{
+		id:       "test",
+		send:     make(chan outboundMessage, queueSize),
+		overflow: policy,
+		tags:     make(map[string]any),
+		logger:   slog.Default(),
+	}
+}
+
+func TestConnection_Enqueue_DropOldest(t *testing.T) {
+	c := newTestConnection(2, OverflowDropOldest)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		if err := c.SendMessage(msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 佇列長度 2，最舊的 "a" 應被丟棄
+	if got := string((<-c.send).data); got != "b" {
+		t.Errorf("expected b, got %s", got)
+	}
+	if got := string((<-c.send).data); got != "c" {
+		t.Errorf("expected c, got %s", got)
+	}
+}
+
+func TestConnection_Enqueue_Closed(t *testing.T) {
+	c := newTestConnection(1, OverflowDropOldest)
+	c.closeSend()
+	c.closeSend() // 重複關閉不應 panic
+
+	if err := c.SendBinary([]byte{0x01}); !errors.Is(err, ErrConnectionClosed) {
+		t.Errorf("expected ErrConnectionClosed, got %v", err)
+	}
+}
+
+func TestConnection_Enqueue_Kick(t *testing.T) {
+	c := newTestConnection(1, OverflowKick)
+	c.kickOnce.Do(func() {}) // 測試中沒有底層連線，略過實際踢除
+
+	if err := c.SendMessage("a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.SendMessage("b"); !errors.Is(err, ErrSendQueueFull) {
+		t.Errorf("expected ErrSendQueueFull, got %v", err)
+	}
+	if msg := <-c.send; msg.msgType != websocket.TextMessage || string(msg.data) != "a" {
+		t.Errorf("queued message must be kept, got %s", msg.data)
+	}
+}
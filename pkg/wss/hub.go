@@ -3,6 +3,7 @@ package wss
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 )
 
 // hub 維護一組活躍的客戶端，並將事件分派給所有已註冊的 Subscriber
@@ -10,9 +11,12 @@ type hub struct {
 	clients     map[*connection]bool
 	register    chan *connection
 	unregister  chan *connection
+	statsReq    chan chan Stats // 統計查詢 (由 run 迴圈回覆，避免並發存取 clients)
+	dropped     atomic.Uint64   // 發送佇列已滿而丟棄或拒絕的訊息累計數
 	subscribers []Subscriber
 	ctx         context.Context
 	done        chan struct{} // 通知 Server Hub 已完全停止
+	running     atomic.Bool   // run 迴圈執行中 (panic 或關閉後為 false)
 	logger      *slog.Logger
 }
 
@@ -25,6 +29,7 @@ func newHub(ctx context.Context, logger *slog.Logger) *hub {
 	return &hub{
 		register:    make(chan *connection),
 		unregister:  make(chan *connection),
+		statsReq:    make(chan chan Stats),
 		clients:     make(map[*connection]bool),
 		subscribers: make([]Subscriber, 0),
 		ctx:         ctx,
@@ -45,6 +50,7 @@ func (h *hub) registerSubscriber(subscriber Subscriber) {
 // run 啟動 hub 的主事件迴圈。
 // 這個迴圈會處理客戶端的註冊、註銷、訊息傳遞以及優雅關閉的邏輯。
 func (h *hub) run() {
+	defer h.running.Store(false)
 	defer func() {
 		if r := recover(); r != nil {
 			h.logger.Error("hub run panic recovered", "panic", r)
@@ -64,12 +70,14 @@ func (h *hub) run() {
 		case client := <-h.unregister:
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
-				close(client.send)
+				client.closeSend()
 				h.logger.Info("client unregistered", "clientID", client.ID())
 				for _, subscriber := range h.subscribers {
 					subscriber.OnDisconnect(client)
 				}
 			}
+		case reply := <-h.statsReq:
+			reply <- h.stats()
 		case <-h.ctx.Done():
 			// Context 被取消，開始關閉程序
 			h.logger.Info("hub shutting down")
@@ -85,8 +93,9 @@ func (h *hub) run() {
 					h.logger.Error("kick client failed", "error", err, "clientID", client.ID())
 				}
 				delete(h.clients, client)
-				close(client.send)
+				client.closeSend()
 			}
+			h.running.Store(false)
 			close(h.done) // 通知 Server: Hub 關機完畢
 			return        // 結束 run 迴圈
 		}
@@ -35,6 +35,7 @@ func NewServer(ctx context.Context, cfg *Config, logger *slog.Logger) *Server {
 	idsCtx, cancel := context.WithCancel(ctx)
 
 	h := newHub(idsCtx, logger.With("component", "hub"))
+	h.running.Store(true)
 	go h.run()
 	return &Server{
 		hub:        h,
@@ -63,6 +64,12 @@ func (s *Server) Shutdown() {
 	}
 }
 
+// Running 回報 Hub 事件迴圈是否仍在執行 (用於存活檢查)。
+// Hub 因 panic 停止或已 Shutdown 時回傳 false，此時不會再處理新連線。
+func (s *Server) Running() bool {
+	return s.hub.running.Load()
+}
+
 // ServeHTTP 實現 http.Handler 介面，處理 WebSocket 的升級請求。
 //
 // @param w - http.ResponseWriter，用於寫入 HTTP 回應。
@@ -71,6 +78,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  s.cfg.ReadBufferSize,
 		WriteBufferSize: s.cfg.WriteBufferSize,
+		// 依客戶端 Sec-WebSocket-Protocol 與伺服器支援列表協商子協議
+		Subprotocols: s.cfg.Subprotocols,
 		CheckOrigin: func(r *http.Request) bool {
 			// 若未設定 AllowedOrigins，為了安全起見，預設拒絕所有跨域連線 (只允許同源)
 			// 或者可以選擇開發模式下 return true，但這裡採取較嚴格的策略。
@@ -104,7 +113,7 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	clientLogger := s.logger.With("component", "client")
-	client := newConnection(s.hub, conn, r, clientLogger)
+	client := newConnection(s.hub, conn, r, s.cfg, clientLogger)
 	client.hub.register <- client
 
 	go client.writePump(s.cfg)
@@ -0,0 +1,29 @@
+package wss
+
+// Stats 是 WebSocket 伺服器的即時統計，用於監控 (ex: Prometheus)。
+type Stats struct {
+	Connections    int    // 目前的連線數
+	QueuedMessages int    // 所有連線發送佇列中尚未寫出的訊息總數
+	Dropped        uint64 // 因發送佇列已滿而丟棄或拒絕的訊息累計數
+}
+
+// Stats 回傳伺服器目前的統計資料。
+// 由 Hub 的事件迴圈計算，Hub 停止後只回傳累計的丟棄數。
+func (s *Server) Stats() Stats {
+	reply := make(chan Stats, 1)
+	select {
+	case s.hub.statsReq <- reply:
+		return <-reply
+	case <-s.hub.done:
+		return Stats{Dropped: s.hub.dropped.Load()}
+	}
+}
+
+// stats 統計目前的連線與佇列深度 (僅在 run 迴圈中呼叫)。
+func (h *hub) stats() Stats {
+	st := Stats{Connections: len(h.clients), Dropped: h.dropped.Load()}
+	for client := range h.clients {
+		st.QueuedMessages += len(client.send)
+	}
+	return st
+}
@@ -0,0 +1,40 @@
+package wss
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestServer_Stats(t *testing.T) {
+	s := NewServer(context.Background(), &Config{}, slog.Default())
+	if !s.Running() {
+		t.Fatal("expected hub to be running")
+	}
+
+	c := newTestConnection(2, OverflowDropOldest)
+	c.hub = s.hub
+	s.hub.register <- c
+
+	for _, msg := range []string{"a", "b", "c"} {
+		if err := c.SendMessage(msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 佇列長度 2，第三則訊息擠掉最舊的一則
+	if got := s.Stats(); got != (Stats{Connections: 1, QueuedMessages: 2, Dropped: 1}) {
+		t.Errorf("unexpected stats: %+v", got)
+	}
+
+	s.hub.unregister <- c
+	s.Shutdown()
+	if s.Running() {
+		t.Error("expected hub to be stopped after shutdown")
+	}
+
+	// Hub 停止後仍保留累計的丟棄數
+	if got := s.Stats(); got != (Stats{Dropped: 1}) {
+		t.Errorf("unexpected stats after shutdown: %+v", got)
+	}
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/JoeShih716/go-k8s-game-server/internal/core/ports (interfaces: MaintenanceStore)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../test/mocks/core/ports/mock_maintenance_store.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports MaintenanceStore
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMaintenanceStore is a mock of MaintenanceStore interface.
+type MockMaintenanceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMaintenanceStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMaintenanceStoreMockRecorder is the mock recorder for MockMaintenanceStore.
+type MockMaintenanceStoreMockRecorder struct {
+	mock *MockMaintenanceStore
+}
+
+// NewMockMaintenanceStore creates a new mock instance.
+func NewMockMaintenanceStore(ctrl *gomock.Controller) *MockMaintenanceStore {
+	mock := &MockMaintenanceStore{ctrl: ctrl}
+	mock.recorder = &MockMaintenanceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMaintenanceStore) EXPECT() *MockMaintenanceStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMaintenanceStore) Delete(ctx context.Context, gameID int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, gameID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMaintenanceStoreMockRecorder) Delete(ctx, gameID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMaintenanceStore)(nil).Delete), ctx, gameID)
+}
+
+// Get mocks base method.
+func (m *MockMaintenanceStore) Get(ctx context.Context, gameID int32) (*domain.Maintenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, gameID)
+	ret0, _ := ret[0].(*domain.Maintenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockMaintenanceStoreMockRecorder) Get(ctx, gameID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMaintenanceStore)(nil).Get), ctx, gameID)
+}
+
+// List mocks base method.
+func (m *MockMaintenanceStore) List(ctx context.Context) ([]*domain.Maintenance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Maintenance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMaintenanceStoreMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMaintenanceStore)(nil).List), ctx)
+}
+
+// Set mocks base method.
+func (m *MockMaintenanceStore) Set(ctx context.Context, maintenance *domain.Maintenance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, maintenance)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockMaintenanceStoreMockRecorder) Set(ctx, maintenance any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockMaintenanceStore)(nil).Set), ctx, maintenance)
+}
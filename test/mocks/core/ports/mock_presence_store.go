@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/JoeShih716/go-k8s-game-server/internal/core/ports (interfaces: PresenceStore)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../test/mocks/core/ports/mock_presence_store.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports PresenceStore
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresenceStore is a mock of PresenceStore interface.
+type MockPresenceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockPresenceStoreMockRecorder is the mock recorder for MockPresenceStore.
+type MockPresenceStoreMockRecorder struct {
+	mock *MockPresenceStore
+}
+
+// NewMockPresenceStore creates a new mock instance.
+func NewMockPresenceStore(ctrl *gomock.Controller) *MockPresenceStore {
+	mock := &MockPresenceStore{ctrl: ctrl}
+	mock.recorder = &MockPresenceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceStore) EXPECT() *MockPresenceStoreMockRecorder {
+	return m.recorder
+}
+
+// ListByGame mocks base method.
+func (m *MockPresenceStore) ListByGame(ctx context.Context, gameID int32) ([]*domain.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByGame", ctx, gameID)
+	ret0, _ := ret[0].([]*domain.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByGame indicates an expected call of ListByGame.
+func (mr *MockPresenceStoreMockRecorder) ListByGame(ctx, gameID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByGame", reflect.TypeOf((*MockPresenceStore)(nil).ListByGame), ctx, gameID)
+}
+
+// ListByUser mocks base method.
+func (m *MockPresenceStore) ListByUser(ctx context.Context, userID string) ([]*domain.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockPresenceStoreMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockPresenceStore)(nil).ListByUser), ctx, userID)
+}
+
+// Refresh mocks base method.
+func (m *MockPresenceStore) Refresh(ctx context.Context, presences []*domain.Presence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, presences)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockPresenceStoreMockRecorder) Refresh(ctx, presences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockPresenceStore)(nil).Refresh), ctx, presences)
+}
+
+// Remove mocks base method.
+func (m *MockPresenceStore) Remove(ctx context.Context, userID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockPresenceStoreMockRecorder) Remove(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockPresenceStore)(nil).Remove), ctx, userID, sessionID)
+}
+
+// Reserve mocks base method.
+func (m *MockPresenceStore) Reserve(ctx context.Context, presence *domain.Presence, limit int) ([]*domain.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, presence, limit)
+	ret0, _ := ret[0].([]*domain.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockPresenceStoreMockRecorder) Reserve(ctx, presence, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockPresenceStore)(nil).Reserve), ctx, presence, limit)
+}
+
+// Set mocks base method.
+func (m *MockPresenceStore) Set(ctx context.Context, presence *domain.Presence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, presence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockPresenceStoreMockRecorder) Set(ctx, presence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockPresenceStore)(nil).Set), ctx, presence)
+}
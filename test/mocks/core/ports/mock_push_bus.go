@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/JoeShih716/go-k8s-game-server/internal/core/ports (interfaces: PushBus)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../test/mocks/core/ports/mock_push_bus.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports PushBus
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	ports "github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPushBus is a mock of PushBus interface.
+type MockPushBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockPushBusMockRecorder
+	isgomock struct{}
+}
+
+// MockPushBusMockRecorder is the mock recorder for MockPushBus.
+type MockPushBusMockRecorder struct {
+	mock *MockPushBus
+}
+
+// NewMockPushBus creates a new mock instance.
+func NewMockPushBus(ctrl *gomock.Controller) *MockPushBus {
+	mock := &MockPushBus{ctrl: ctrl}
+	mock.recorder = &MockPushBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPushBus) EXPECT() *MockPushBusMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPushBus) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPushBusMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPushBus)(nil).Close))
+}
+
+// Listen mocks base method.
+func (m *MockPushBus) Listen(ctx context.Context, handler ports.PushHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockPushBusMockRecorder) Listen(ctx, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockPushBus)(nil).Listen), ctx, handler)
+}
+
+// Publish mocks base method.
+func (m *MockPushBus) Publish(ctx context.Context, msg *domain.PushMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPushBusMockRecorder) Publish(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPushBus)(nil).Publish), ctx, msg)
+}
+
+// Unwatch mocks base method.
+func (m *MockPushBus) Unwatch(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unwatch", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unwatch indicates an expected call of Unwatch.
+func (mr *MockPushBusMockRecorder) Unwatch(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unwatch", reflect.TypeOf((*MockPushBus)(nil).Unwatch), ctx, userID)
+}
+
+// Watch mocks base method.
+func (m *MockPushBus) Watch(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockPushBusMockRecorder) Watch(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockPushBus)(nil).Watch), ctx, userID)
+}
@@ -13,8 +13,9 @@ import (
 	context "context"
 	reflect "reflect"
 
-	proto "github.com/JoeShih716/go-k8s-game-server/api/proto"
 	centralRPC "github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	ports "github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -84,6 +85,21 @@ func (mr *MockRegistryServiceMockRecorder) Heartbeat(ctx, leaseID, load any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Heartbeat", reflect.TypeOf((*MockRegistryService)(nil).Heartbeat), ctx, leaseID, load)
 }
 
+// ListServices mocks base method.
+func (m *MockRegistryService) ListServices(ctx context.Context) ([]*domain.ServiceInstance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServices", ctx)
+	ret0, _ := ret[0].([]*domain.ServiceInstance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServices indicates an expected call of ListServices.
+func (mr *MockRegistryServiceMockRecorder) ListServices(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockRegistryService)(nil).ListServices), ctx)
+}
+
 // Register mocks base method.
 func (m *MockRegistryService) Register(ctx context.Context, req *centralRPC.RegisterRequest) (string, error) {
 	m.ctrl.T.Helper()
@@ -100,17 +116,30 @@ func (mr *MockRegistryServiceMockRecorder) Register(ctx, req any) *gomock.Call {
 }
 
 // SelectServiceByGame mocks base method.
-func (m *MockRegistryService) SelectServiceByGame(ctx context.Context, gameID int32) (string, proto.ServiceType, error) {
+func (m *MockRegistryService) SelectServiceByGame(ctx context.Context, gameID int32, selector domain.VersionSelector) (*ports.Route, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SelectServiceByGame", ctx, gameID)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(proto.ServiceType)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "SelectServiceByGame", ctx, gameID, selector)
+	ret0, _ := ret[0].(*ports.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SelectServiceByGame indicates an expected call of SelectServiceByGame.
-func (mr *MockRegistryServiceMockRecorder) SelectServiceByGame(ctx, gameID any) *gomock.Call {
+func (mr *MockRegistryServiceMockRecorder) SelectServiceByGame(ctx, gameID, selector any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectServiceByGame", reflect.TypeOf((*MockRegistryService)(nil).SelectServiceByGame), ctx, gameID, selector)
+}
+
+// SetDraining mocks base method.
+func (m *MockRegistryService) SetDraining(ctx context.Context, leaseID string, draining bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDraining", ctx, leaseID, draining)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDraining indicates an expected call of SetDraining.
+func (mr *MockRegistryServiceMockRecorder) SetDraining(ctx, leaseID, draining any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectServiceByGame", reflect.TypeOf((*MockRegistryService)(nil).SelectServiceByGame), ctx, gameID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDraining", reflect.TypeOf((*MockRegistryService)(nil).SetDraining), ctx, leaseID, draining)
 }
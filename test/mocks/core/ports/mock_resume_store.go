@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/JoeShih716/go-k8s-game-server/internal/core/ports (interfaces: ResumeStore)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../test/mocks/core/ports/mock_resume_store.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports ResumeStore
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockResumeStore is a mock of ResumeStore interface.
+type MockResumeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockResumeStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockResumeStoreMockRecorder is the mock recorder for MockResumeStore.
+type MockResumeStoreMockRecorder struct {
+	mock *MockResumeStore
+}
+
+// NewMockResumeStore creates a new mock instance.
+func NewMockResumeStore(ctrl *gomock.Controller) *MockResumeStore {
+	mock := &MockResumeStore{ctrl: ctrl}
+	mock.recorder = &MockResumeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResumeStore) EXPECT() *MockResumeStoreMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockResumeStore) Claim(ctx context.Context, token string) (*domain.ResumeState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, token)
+	ret0, _ := ret[0].(*domain.ResumeState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockResumeStoreMockRecorder) Claim(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockResumeStore)(nil).Claim), ctx, token)
+}
+
+// ListExpired mocks base method.
+func (m *MockResumeStore) ListExpired(ctx context.Context, before time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, before)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockResumeStoreMockRecorder) ListExpired(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockResumeStore)(nil).ListExpired), ctx, before)
+}
+
+// Park mocks base method.
+func (m *MockResumeStore) Park(ctx context.Context, token string, state *domain.ResumeState, grace time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Park", ctx, token, state, grace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Park indicates an expected call of Park.
+func (mr *MockResumeStoreMockRecorder) Park(ctx, token, state, grace any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Park", reflect.TypeOf((*MockResumeStore)(nil).Park), ctx, token, state, grace)
+}
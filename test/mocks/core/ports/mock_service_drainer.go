@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/JoeShih716/go-k8s-game-server/internal/core/ports (interfaces: ServiceDrainer)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../test/mocks/core/ports/mock_service_drainer.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports ServiceDrainer
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockServiceDrainer is a mock of ServiceDrainer interface.
+type MockServiceDrainer struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceDrainerMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceDrainerMockRecorder is the mock recorder for MockServiceDrainer.
+type MockServiceDrainerMockRecorder struct {
+	mock *MockServiceDrainer
+}
+
+// NewMockServiceDrainer creates a new mock instance.
+func NewMockServiceDrainer(ctrl *gomock.Controller) *MockServiceDrainer {
+	mock := &MockServiceDrainer{ctrl: ctrl}
+	mock.recorder = &MockServiceDrainerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceDrainer) EXPECT() *MockServiceDrainerMockRecorder {
+	return m.recorder
+}
+
+// Drain mocks base method.
+func (m *MockServiceDrainer) Drain(ctx context.Context, endpoint string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", ctx, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockServiceDrainerMockRecorder) Drain(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockServiceDrainer)(nil).Drain), ctx, endpoint)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/JoeShih716/go-k8s-game-server/internal/core/ports (interfaces: SessionKicker)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../../test/mocks/core/ports/mock_session_kicker.go -package=mock_ports github.com/JoeShih716/go-k8s-game-server/internal/core/ports SessionKicker
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSessionKicker is a mock of SessionKicker interface.
+type MockSessionKicker struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionKickerMockRecorder
+	isgomock struct{}
+}
+
+// MockSessionKickerMockRecorder is the mock recorder for MockSessionKicker.
+type MockSessionKickerMockRecorder struct {
+	mock *MockSessionKicker
+}
+
+// NewMockSessionKicker creates a new mock instance.
+func NewMockSessionKicker(ctrl *gomock.Controller) *MockSessionKicker {
+	mock := &MockSessionKicker{ctrl: ctrl}
+	mock.recorder = &MockSessionKickerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionKicker) EXPECT() *MockSessionKickerMockRecorder {
+	return m.recorder
+}
+
+// Kick mocks base method.
+func (m *MockSessionKicker) Kick(ctx context.Context, connectorHost, sessionID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Kick", ctx, connectorHost, sessionID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Kick indicates an expected call of Kick.
+func (mr *MockSessionKickerMockRecorder) Kick(ctx, connectorHost, sessionID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Kick", reflect.TypeOf((*MockSessionKicker)(nil).Kick), ctx, connectorHost, sessionID, reason)
+}
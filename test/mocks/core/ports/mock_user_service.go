@@ -55,6 +55,20 @@ func (mr *MockUserServiceMockRecorder) CreateGuestUser(ctx, token, user any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestUser", reflect.TypeOf((*MockUserService)(nil).CreateGuestUser), ctx, token, user)
 }
 
+// EnsureUser mocks base method.
+func (m *MockUserService) EnsureUser(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureUser indicates an expected call of EnsureUser.
+func (mr *MockUserServiceMockRecorder) EnsureUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureUser", reflect.TypeOf((*MockUserService)(nil).EnsureUser), ctx, user)
+}
+
 // GetUser mocks base method.
 func (m *MockUserService) GetUser(ctx context.Context, token string) (*domain.User, error) {
 	m.ctrl.T.Helper()
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
 	decimal "github.com/shopspring/decimal"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,17 +43,18 @@ func (m *MockWalletService) EXPECT() *MockWalletServiceMockRecorder {
 }
 
 // Deposit mocks base method.
-func (m *MockWalletService) Deposit(ctx context.Context, userID string, amount decimal.Decimal, reason string) error {
+func (m *MockWalletService) Deposit(ctx context.Context, userID string, amount decimal.Decimal, txID, reason string) (*domain.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Deposit", ctx, userID, amount, reason)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Deposit", ctx, userID, amount, txID, reason)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Deposit indicates an expected call of Deposit.
-func (mr *MockWalletServiceMockRecorder) Deposit(ctx, userID, amount, reason any) *gomock.Call {
+func (mr *MockWalletServiceMockRecorder) Deposit(ctx, userID, amount, txID, reason any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockWalletService)(nil).Deposit), ctx, userID, amount, reason)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deposit", reflect.TypeOf((*MockWalletService)(nil).Deposit), ctx, userID, amount, txID, reason)
 }
 
 // GetBalance mocks base method.
@@ -70,16 +72,32 @@ func (mr *MockWalletServiceMockRecorder) GetBalance(ctx, userID any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockWalletService)(nil).GetBalance), ctx, userID)
 }
 
+// ListTransactions mocks base method.
+func (m *MockWalletService) ListTransactions(ctx context.Context, userID string, limit, offset int) ([]*domain.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockWalletServiceMockRecorder) ListTransactions(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockWalletService)(nil).ListTransactions), ctx, userID, limit, offset)
+}
+
 // Withdraw mocks base method.
-func (m *MockWalletService) Withdraw(ctx context.Context, userID string, amount decimal.Decimal, reason string) error {
+func (m *MockWalletService) Withdraw(ctx context.Context, userID string, amount decimal.Decimal, txID, reason string) (*domain.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Withdraw", ctx, userID, amount, reason)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Withdraw", ctx, userID, amount, txID, reason)
+	ret0, _ := ret[0].(*domain.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Withdraw indicates an expected call of Withdraw.
-func (mr *MockWalletServiceMockRecorder) Withdraw(ctx, userID, amount, reason any) *gomock.Call {
+func (mr *MockWalletServiceMockRecorder) Withdraw(ctx, userID, amount, txID, reason any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockWalletService)(nil).Withdraw), ctx, userID, amount, reason)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockWalletService)(nil).Withdraw), ctx, userID, amount, txID, reason)
 }
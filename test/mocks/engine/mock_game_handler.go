@@ -83,3 +83,41 @@ func (mr *MockGameHandlerMockRecorder) OnQuit(ctx, peer any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnQuit", reflect.TypeOf((*MockGameHandler)(nil).OnQuit), ctx, peer)
 }
+
+// MockReconnectHandler is a mock of ReconnectHandler interface.
+type MockReconnectHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconnectHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockReconnectHandlerMockRecorder is the mock recorder for MockReconnectHandler.
+type MockReconnectHandlerMockRecorder struct {
+	mock *MockReconnectHandler
+}
+
+// NewMockReconnectHandler creates a new mock instance.
+func NewMockReconnectHandler(ctrl *gomock.Controller) *MockReconnectHandler {
+	mock := &MockReconnectHandler{ctrl: ctrl}
+	mock.recorder = &MockReconnectHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconnectHandler) EXPECT() *MockReconnectHandlerMockRecorder {
+	return m.recorder
+}
+
+// OnReconnect mocks base method.
+func (m *MockReconnectHandler) OnReconnect(ctx context.Context, peer *engine.Peer, oldSessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnReconnect", ctx, peer, oldSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnReconnect indicates an expected call of OnReconnect.
+func (mr *MockReconnectHandlerMockRecorder) OnReconnect(ctx, peer, oldSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnReconnect", reflect.TypeOf((*MockReconnectHandler)(nil).OnReconnect), ctx, peer, oldSessionID)
+}
@@ -13,8 +13,9 @@ import (
 	context "context"
 	reflect "reflect"
 
-	proto "github.com/JoeShih716/go-k8s-game-server/api/proto"
 	centralRPC "github.com/JoeShih716/go-k8s-game-server/api/proto/centralRPC"
+	domain "github.com/JoeShih716/go-k8s-game-server/internal/core/domain"
+	ports "github.com/JoeShih716/go-k8s-game-server/internal/core/ports"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,32 +44,61 @@ func (m *MockCentralClient) EXPECT() *MockCentralClientMockRecorder {
 }
 
 // GetRoute mocks base method.
-func (m *MockCentralClient) GetRoute(ctx context.Context, gameID int32) (string, proto.ServiceType, error) {
+func (m *MockCentralClient) GetRoute(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRoute", ctx, gameID)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(proto.ServiceType)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetRoute", ctx, req)
+	ret0, _ := ret[0].(*ports.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetRoute indicates an expected call of GetRoute.
-func (mr *MockCentralClientMockRecorder) GetRoute(ctx, gameID any) *gomock.Call {
+func (mr *MockCentralClientMockRecorder) GetRoute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoute", reflect.TypeOf((*MockCentralClient)(nil).GetRoute), ctx, req)
+}
+
+// JoinRoute mocks base method.
+func (m *MockCentralClient) JoinRoute(ctx context.Context, req *domain.RouteRequest) (*ports.Route, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinRoute", ctx, req)
+	ret0, _ := ret[0].(*ports.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinRoute indicates an expected call of JoinRoute.
+func (mr *MockCentralClientMockRecorder) JoinRoute(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinRoute", reflect.TypeOf((*MockCentralClient)(nil).JoinRoute), ctx, req)
+}
+
+// ListMaintenance mocks base method.
+func (m *MockCentralClient) ListMaintenance(ctx context.Context) ([]*centralRPC.MaintenanceInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMaintenance", ctx)
+	ret0, _ := ret[0].([]*centralRPC.MaintenanceInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMaintenance indicates an expected call of ListMaintenance.
+func (mr *MockCentralClientMockRecorder) ListMaintenance(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoute", reflect.TypeOf((*MockCentralClient)(nil).GetRoute), ctx, gameID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMaintenance", reflect.TypeOf((*MockCentralClient)(nil).ListMaintenance), ctx)
 }
 
 // Login mocks base method.
-func (m *MockCentralClient) Login(ctx context.Context, token string) (*centralRPC.LoginResponse, error) {
+func (m *MockCentralClient) Login(ctx context.Context, token, sessionID, connectorHost string) (*centralRPC.LoginResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, token)
+	ret := m.ctrl.Call(m, "Login", ctx, token, sessionID, connectorHost)
 	ret0, _ := ret[0].(*centralRPC.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockCentralClientMockRecorder) Login(ctx, token any) *gomock.Call {
+func (mr *MockCentralClientMockRecorder) Login(ctx, token, sessionID, connectorHost any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockCentralClient)(nil).Login), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockCentralClient)(nil).Login), ctx, token, sessionID, connectorHost)
 }
@@ -40,6 +40,18 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// DelTag mocks base method.
+func (m *MockClient) DelTag(key string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DelTag", key)
+}
+
+// DelTag indicates an expected call of DelTag.
+func (mr *MockClientMockRecorder) DelTag(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelTag", reflect.TypeOf((*MockClient)(nil).DelTag), key)
+}
+
 // GetTag mocks base method.
 func (m *MockClient) GetTag(key string) (any, bool) {
 	m.ctrl.T.Helper()
@@ -111,6 +123,20 @@ func (mr *MockClientMockRecorder) RemoteAddr() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteAddr", reflect.TypeOf((*MockClient)(nil).RemoteAddr))
 }
 
+// SendBinary mocks base method.
+func (m *MockClient) SendBinary(data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBinary", data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendBinary indicates an expected call of SendBinary.
+func (mr *MockClientMockRecorder) SendBinary(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBinary", reflect.TypeOf((*MockClient)(nil).SendBinary), data)
+}
+
 // SendMessage mocks base method.
 func (m *MockClient) SendMessage(message string) error {
 	m.ctrl.T.Helper()
@@ -137,6 +163,20 @@ func (mr *MockClientMockRecorder) SetTag(key, value any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTag", reflect.TypeOf((*MockClient)(nil).SetTag), key, value)
 }
 
+// Subprotocol mocks base method.
+func (m *MockClient) Subprotocol() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subprotocol")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Subprotocol indicates an expected call of Subprotocol.
+func (mr *MockClientMockRecorder) Subprotocol() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subprotocol", reflect.TypeOf((*MockClient)(nil).Subprotocol))
+}
+
 // UserAgent mocks base method.
 func (m *MockClient) UserAgent() string {
 	m.ctrl.T.Helper()